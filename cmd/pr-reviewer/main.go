@@ -1,15 +1,28 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata"
 
 	"pr-reviewer-service/internal/config"
+	"pr-reviewer-service/internal/events"
+	grpcapi "pr-reviewer-service/internal/grpc"
 	apihandler "pr-reviewer-service/internal/http/handlers"
+	"pr-reviewer-service/internal/http/scim"
 	"pr-reviewer-service/internal/lib/logger"
+	"pr-reviewer-service/internal/sla"
 	"pr-reviewer-service/internal/storage"
 	"pr-reviewer-service/internal/storage/repo"
+
+	"google.golang.org/grpc"
 )
 
 const (
@@ -17,6 +30,9 @@ const (
 	envProd  = "prod"
 )
 
+// shutdownTimeout — сколько ждать завершения запросов после SIGINT/SIGTERM.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 	cfg := config.MustLoadConfig()
 
@@ -34,8 +50,51 @@ func main() {
 		os.Exit(1)
 	}
 
-	store := repo.New(pool)
-	r := apihandler.NewRouter(store)
+	if !repo.ValidStrategy(cfg.Assignment.Strategy) {
+		log.Error("unknown assignment strategy", slog.String("strategy", cfg.Assignment.Strategy))
+		os.Exit(1)
+	}
+
+	if !repo.ValidMode(cfg.Assignment.Mode) {
+		log.Error("unknown assignment mode", slog.String("mode", cfg.Assignment.Mode))
+		os.Exit(1)
+	}
+
+	store := repo.New(pool, repo.Options{
+		Strategy: cfg.Assignment.Strategy,
+		Mode:     cfg.Assignment.Mode,
+		Seed:     cfg.Assignment.Seed,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if cfg.SLA.Enabled {
+		go sla.NewScheduler(store, log, cfg.SLA.Interval).Run(ctx)
+	}
+
+	broker := events.NewBroker()
+	go events.NewListener(pool, store, broker, log).Run(ctx)
+
+	go purgeIdempotencyKeys(ctx, store, log)
+
+	r := apihandler.NewRouter(store, broker, log, apihandler.Options{
+		IdempotencyTTL:       cfg.Idempotency.TTL,
+		GraphQLMaxComplexity: cfg.GraphQL.MaxComplexity,
+		SCIM:                 scim.Options{Token: cfg.SCIM.Token, DefaultTeam: cfg.SCIM.DefaultTeam},
+		AdminToken:           cfg.Admin.Token,
+	})
+
+	grpcDone := make(chan struct{})
+	if cfg.GRPCServer.Enabled {
+		go func() {
+			serveGRPC(ctx, cfg.GRPCServer.Address, grpcapi.NewServer(store, broker, log), log)
+			close(grpcDone)
+		}()
+	} else {
+		close(grpcDone)
+	}
+
 	server := &http.Server{
 		Addr:         cfg.HTTPServer.Address,
 		Handler:      r,
@@ -43,9 +102,75 @@ func main() {
 		WriteTimeout: cfg.HTTPServer.Timeout,
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
 	}
-	log.Info("starting server", slog.String("addr", cfg.HTTPServer.Address))
-	if err := server.ListenAndServe(); err != nil {
-		log.Error("server error", logger.Err(err))
+	go func() {
+		log.Info("starting server", slog.String("addr", cfg.HTTPServer.Address))
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("server error", logger.Err(err))
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	log.Info("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	// открытые SSE-подписки сами не завершаются: по истечении таймаута соединения закрываются
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Error("server shutdown", logger.Err(err))
+		server.Close()
+	}
+	<-grpcDone
+}
+
+// serveGRPC обслуживает gRPC на отдельном порту и возвращается, когда сервер
+// остановлен после отмены ctx.
+func serveGRPC(ctx context.Context, addr string, srv *grpc.Server, log *slog.Logger) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Error("grpc listen", slog.String("addr", addr), logger.Err(err))
+		os.Exit(1)
+	}
+	go func() {
+		log.Info("starting grpc server", slog.String("addr", addr))
+		if err := srv.Serve(lis); err != nil {
+			log.Error("grpc server error", logger.Err(err))
+		}
+	}()
+
+	<-ctx.Done()
+	// подписки StreamEvents сами не завершаются, поэтому GracefulStop ограничен по времени
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		srv.Stop()
+		<-stopped
+	}
+}
+
+// purgeIdempotencyKeys раз в час удаляет просроченные ключи идемпотентности.
+func purgeIdempotencyKeys(ctx context.Context, store *repo.Store, log *slog.Logger) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := store.PurgeIdempotencyKeys(ctx)
+			if err != nil {
+				log.Error("purge idempotency keys", logger.Err(err))
+				continue
+			}
+			if n > 0 {
+				log.Debug("purged idempotency keys", slog.Int64("count", n))
+			}
+		}
 	}
 }
 
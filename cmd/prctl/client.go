@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// client обращается к HTTP API сервиса.
+type client struct {
+	base string
+	// token — bearer-токен для административных операций (/admin).
+	token string
+	http  *http.Client
+}
+
+// apiError — ответ сервиса с кодом ошибки из тела {"error": {...}}.
+type apiError struct {
+	Status    int
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+}
+
+func (e *apiError) Error() string {
+	msg := fmt.Sprintf("%s: %s (HTTP %d)", e.Code, e.Message, e.Status)
+	if e.RequestID != "" {
+		msg += ", request_id " + e.RequestID
+	}
+	return msg
+}
+
+// request — параметры одного вызова API; IfMatch == 0 — без проверки версии.
+// Body кодируется в JSON; Raw отправляется как есть с типом ContentType.
+// Если задан Output, тело успешного ответа копируется туда, а не возвращается.
+type request struct {
+	Method      string
+	Path        string
+	Query       url.Values
+	Body        any
+	Raw         []byte
+	ContentType string
+	IfMatch     int64
+	Output      io.Writer
+}
+
+// do выполняет запрос и возвращает тело успешного ответа как есть.
+func (c *client) do(ctx context.Context, req request) ([]byte, error) {
+	u := strings.TrimRight(c.base, "/") + req.Path
+	if len(req.Query) > 0 {
+		u += "?" + req.Query.Encode()
+	}
+
+	var body io.Reader
+	contentType := "application/json"
+	switch {
+	case req.Raw != nil:
+		body, contentType = bytes.NewReader(req.Raw), req.ContentType
+	case req.Body != nil:
+		b, err := json.Marshal(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	r, err := http.NewRequestWithContext(ctx, req.Method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		r.Header.Set("Content-Type", contentType)
+	}
+	if req.IfMatch > 0 {
+		r.Header.Set("If-Match", strconv.Quote(strconv.FormatInt(req.IfMatch, 10)))
+	}
+	if c.token != "" {
+		r.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if req.Output != nil && resp.StatusCode < 400 {
+		_, err := io.Copy(req.Output, resp.Body)
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		var e struct {
+			Error apiError `json:"error"`
+		}
+		if json.Unmarshal(data, &e) != nil || e.Error.Code == "" {
+			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+		}
+		e.Error.Status = resp.StatusCode
+		return nil, &e.Error
+	}
+	return data, nil
+}
@@ -0,0 +1,724 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+type env struct {
+	client *client
+	out    printer
+	stdin  io.Reader
+}
+
+type action func(ctx context.Context, e env, args []string) error
+
+// command — подкоманда вида «team get». flags регистрирует флаги команды и
+// возвращает действие, которое прочитает их после разбора.
+type command struct {
+	group string
+	name  string
+	args  []string
+	help  string
+	flags func(fs *flag.FlagSet) action
+}
+
+func (c command) usage() string {
+	s := c.group + " " + c.name
+	for _, a := range c.args {
+		s += " <" + a + ">"
+	}
+	return s
+}
+
+var commands = []command{
+	{group: "team", name: "get", args: []string{"team_name"}, help: "show team members and version", flags: teamGet},
+	{group: "team", name: "add", help: "create or update a team from a JSON file (-f, '-' for stdin)", flags: teamAdd},
+	{group: "team", name: "import", help: "bulk import teams and members from CSV or YAML (-f); use --dry-run to see the diff", flags: teamImport},
+	{group: "team", name: "deactivate", args: []string{"team_name"}, help: "deactivate every member of a team; use --dry-run to preview", flags: teamDeactivate},
+	{group: "team", name: "policy", args: []string{"team_name"}, help: "show the team assignment policy", flags: teamPolicy},
+
+	{group: "org", name: "plan", help: "show what syncing the org to a CSV or YAML document (-f) would change", flags: orgSync(true)},
+	{group: "org", name: "apply", help: "sync the org to a CSV or YAML document (-f); --plan guards against drift", flags: orgSync(false)},
+
+	{group: "user", name: "set-active", args: []string{"user_id"}, help: "activate or deactivate a user (--active=false)", flags: userSetActive},
+	{group: "user", name: "availability", args: []string{"user_id"}, help: "set away period and open review limit", flags: userAvailability},
+	{group: "user", name: "reviews", args: []string{"user_id"}, help: "list pull requests the user reviews", flags: userReviews},
+
+	{group: "pr", name: "get", args: []string{"pull_request_id"}, help: "show a pull request", flags: prGet},
+	{group: "pr", name: "create", help: "create a pull request and assign reviewers", flags: prCreate},
+	{group: "pr", name: "merge", args: []string{"pull_request_id"}, help: "mark a pull request as merged", flags: prMerge},
+	{group: "pr", name: "reassign", args: []string{"pull_request_id"}, help: "replace a reviewer (--old) with a new one", flags: prReassign},
+	{group: "pr", name: "explain", args: []string{"pull_request_id"}, help: "explain the last reviewer assignment", flags: prExplain},
+	{group: "pr", name: "assignments", args: []string{"pull_request_id"}, help: "show assignment history", flags: prAssignments},
+
+	{group: "stats", name: "reviewers", help: "review counts per reviewer", flags: statsReviewers},
+	{group: "stats", name: "pairs", args: []string{"team_name"}, help: "author x reviewer matrix of a team", flags: statsPairs},
+
+	{group: "admin", name: "export", help: "write a JSON Lines snapshot of the service state (-f, stdout by default)", flags: adminExport},
+	{group: "admin", name: "import", help: "restore a snapshot (-f) into an empty database; use --dry-run to validate", flags: adminImport},
+	{group: "admin", name: "simulate", args: []string{"team_name"}, help: "replay the team's past pull requests with another policy; nothing is changed", flags: adminSimulate},
+}
+
+// ---------- teams ----------
+
+func teamGet(fs *flag.FlagSet) action {
+	return func(ctx context.Context, e env, args []string) error {
+		data, err := e.client.do(ctx, request{Method: "GET", Path: "/team/get", Query: url.Values{"team_name": {args[0]}}})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, printTeam)
+	}
+}
+
+func printTeam(t *tabwriter.Writer, team repo.Team) {
+	fmt.Fprintf(t, "team %s, version %d\n\n", team.TeamName, team.Version)
+	row(t, "USER_ID", "USERNAME", "LEVEL", "ACTIVE", "SKILLS")
+	for _, m := range team.Members {
+		skills := make([]string, 0, len(m.Skills))
+		for _, s := range m.Skills {
+			skills = append(skills, fmt.Sprintf("%s:%d", s.Tag, s.Level))
+		}
+		row(t, m.UserID, m.Username, m.Level, m.IsActive, skills)
+	}
+}
+
+func teamAdd(fs *flag.FlagSet) action {
+	file := fs.String("f", "", "JSON file with the team ('-' for stdin)")
+	ifMatch := fs.Int64("if-match", 0, "expected team version")
+	return func(ctx context.Context, e env, args []string) error {
+		if *file == "" {
+			return usageError("-f is required")
+		}
+		var team repo.Team
+		if err := readJSON(*file, e.stdin, &team); err != nil {
+			return err
+		}
+		data, err := e.client.do(ctx, request{Method: "POST", Path: "/team/add", Body: team, IfMatch: *ifMatch})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, func(t *tabwriter.Writer, v struct {
+			Team repo.Team `json:"team"`
+		}) {
+			printTeam(t, v.Team)
+		})
+	}
+}
+
+func teamImport(fs *flag.FlagSet) action {
+	file := fs.String("f", "", "CSV or YAML file ('-' for stdin)")
+	format := fs.String("format", "", "csv or yaml (default: by file extension)")
+	dryRun := fs.Bool("dry-run", false, "show the diff without changing anything")
+	deactivateMissing := fs.Bool("deactivate-missing", false, "deactivate members of imported teams that are not in the file")
+	return func(ctx context.Context, e env, args []string) error {
+		if *file == "" {
+			return usageError("-f is required")
+		}
+		contentType, err := importContentType(*format, *file)
+		if err != nil {
+			return err
+		}
+		raw, err := readFile(*file, e.stdin)
+		if err != nil {
+			return err
+		}
+		data, err := e.client.do(ctx, request{
+			Method:      "POST",
+			Path:        "/team/import",
+			Query:       url.Values{"dry_run": {fmt.Sprint(*dryRun)}, "deactivate_missing": {fmt.Sprint(*deactivateMissing)}},
+			Raw:         raw,
+			ContentType: contentType,
+		})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, printImport)
+	}
+}
+
+func printImport(t *tabwriter.Writer, r repo.ImportResult) {
+	if r.DryRun {
+		fmt.Fprintln(t, "dry run: nothing was changed")
+	}
+	row(t, "TEAM", "CREATED", "CHANGED", "VERSION")
+	for _, team := range r.Teams {
+		row(t, team.TeamName, team.Created, team.Changed, team.Version)
+	}
+	fmt.Fprintln(t)
+	row(t, "ACTION", "USER_ID", "TEAM", "FROM", "FIELDS")
+	for _, ch := range r.Changes {
+		row(t, ch.Action, ch.UserID, ch.TeamName, ch.FromTeam, ch.Fields)
+	}
+	fmt.Fprintf(t, "\n%d changes, %d unchanged\n", len(r.Changes), r.Unchanged)
+}
+
+// ---------- org ----------
+
+// orgSync — plan и apply отличаются только dry_run: план показывает хеш, который
+// apply --plan сверит с текущим состоянием.
+func orgSync(dryRun bool) func(fs *flag.FlagSet) action {
+	return func(fs *flag.FlagSet) action {
+		file := fs.String("f", "", "CSV or YAML document with the whole org ('-' for stdin)")
+		format := fs.String("format", "", "csv or yaml (default: by file extension)")
+		plan := new(string)
+		if !dryRun {
+			plan = fs.String("plan", "", "apply only if the plan hash still matches")
+		}
+		return func(ctx context.Context, e env, args []string) error {
+			if *file == "" {
+				return usageError("-f is required")
+			}
+			contentType, err := importContentType(*format, *file)
+			if err != nil {
+				return err
+			}
+			raw, err := readFile(*file, e.stdin)
+			if err != nil {
+				return err
+			}
+			q := url.Values{"dry_run": {fmt.Sprint(dryRun)}}
+			if *plan != "" {
+				q.Set("plan", *plan)
+			}
+			data, err := e.client.do(ctx, request{Method: "POST", Path: "/org/sync", Query: q, Raw: raw, ContentType: contentType})
+			if err != nil {
+				return err
+			}
+			return show(e.out, data, func(t *tabwriter.Writer, r repo.SyncResult) {
+				printImport(t, r.ImportResult)
+				fmt.Fprintf(t, "%d reviews reassigned\n", r.ReassignedPRsCount)
+				for pr, reason := range r.ReassignFailures {
+					fmt.Fprintf(t, "failed %s: %s\n", pr, reason)
+				}
+				if dryRun {
+					fmt.Fprintf(t, "plan %s; apply it with: org apply -f %s --plan %s\n", r.Plan, *file, r.Plan)
+				}
+			})
+		}
+	}
+}
+
+func importContentType(format, file string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".csv":
+			format = "csv"
+		case ".yaml", ".yml":
+			format = "yaml"
+		default:
+			return "", usageError("cannot guess the format, pass --format csv or --format yaml")
+		}
+	}
+	switch format {
+	case "csv":
+		return "text/csv", nil
+	case "yaml":
+		return "application/yaml", nil
+	}
+	return "", usageError("--format must be csv or yaml")
+}
+
+func teamDeactivate(fs *flag.FlagSet) action {
+	dryRun := fs.Bool("dry-run", false, "show what would change without changing anything")
+	ifMatch := fs.Int64("if-match", 0, "expected team version")
+	return func(ctx context.Context, e env, args []string) error {
+		data, err := e.client.do(ctx, request{
+			Method:  "POST",
+			Path:    "/team/deactivate",
+			Body:    map[string]any{"team_name": args[0], "dry_run": *dryRun},
+			IfMatch: *ifMatch,
+		})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, func(t *tabwriter.Writer, r repo.BulkDeactivateResult) {
+			if r.DryRun {
+				fmt.Fprintln(t, "dry run: nothing was changed")
+			}
+			row(t, "team", r.TeamName)
+			row(t, "deactivated users", r.DeactivatedUsers)
+			row(t, "reassigned PRs", r.ReassignedPRsCount)
+			row(t, "version", r.Version)
+			for pr, reason := range r.ReassignFailures {
+				row(t, "failed "+pr, reason)
+			}
+		})
+	}
+}
+
+func teamPolicy(fs *flag.FlagSet) action {
+	return func(ctx context.Context, e env, args []string) error {
+		data, err := e.client.do(ctx, request{Method: "GET", Path: "/team/policy", Query: url.Values{"team_name": {args[0]}}})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, func(t *tabwriter.Writer, v struct {
+			Policy repo.TeamPolicy `json:"policy"`
+		}) {
+			p := v.Policy
+			row(t, "team", p.TeamName)
+			row(t, "version", p.Version)
+			row(t, "reviewer count", p.ReviewerCount)
+			row(t, "strategy", p.Strategy)
+			row(t, "fallback teams", p.FallbackTeams)
+			row(t, "seniority", fmt.Sprintf("%d x %s", p.MinCount, cell(p.MinLevel)))
+			row(t, "default max open reviews", p.DefaultMaxOpenReviews)
+			row(t, "reminder after hours", p.ReminderAfterHours)
+			row(t, "escalate after hours", p.EscalateAfterHours)
+			row(t, "allow merged changes", p.AllowMergedChanges)
+		})
+	}
+}
+
+// ---------- users ----------
+
+func printUser(t *tabwriter.Writer, v struct {
+	User repo.User `json:"user"`
+}) {
+	u := v.User
+	row(t, "USER_ID", "USERNAME", "TEAM", "LEVEL", "ACTIVE", "MAX_OPEN", "AWAY_UNTIL")
+	row(t, u.UserID, u.Username, u.TeamName, u.Level, u.IsActive, u.MaxOpenReviews, u.AwayUntil)
+}
+
+func userSetActive(fs *flag.FlagSet) action {
+	active := fs.Bool("active", true, "new is_active value")
+	return func(ctx context.Context, e env, args []string) error {
+		data, err := e.client.do(ctx, request{
+			Method: "POST",
+			Path:   "/users/setIsActive",
+			Body:   map[string]any{"user_id": args[0], "is_active": *active},
+		})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, printUser)
+	}
+}
+
+func userAvailability(fs *flag.FlagSet) action {
+	until := fs.String("away-until", "", "RFC 3339 time until which the user is away (empty - available)")
+	maxOpen := fs.Int("max-open", -1, "open review limit (-1 - no limit)")
+	return func(ctx context.Context, e env, args []string) error {
+		body := map[string]any{"user_id": args[0], "away_until": nil, "max_open_reviews": nil}
+		if *until != "" {
+			t, err := time.Parse(time.RFC3339, *until)
+			if err != nil {
+				return usageError("--away-until must be an RFC 3339 time")
+			}
+			body["away_until"] = t
+		}
+		if *maxOpen >= 0 {
+			body["max_open_reviews"] = *maxOpen
+		}
+		data, err := e.client.do(ctx, request{Method: "POST", Path: "/users/setAvailability", Body: body})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, printUser)
+	}
+}
+
+func userReviews(fs *flag.FlagSet) action {
+	return func(ctx context.Context, e env, args []string) error {
+		data, err := e.client.do(ctx, request{Method: "GET", Path: "/users/getReview", Query: url.Values{"user_id": {args[0]}}})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, func(t *tabwriter.Writer, v struct {
+			PullRequests []repo.PullRequestShort `json:"pull_requests"`
+		}) {
+			row(t, "PULL_REQUEST_ID", "NAME", "AUTHOR", "STATUS", "ASSIGNED_AT", "REVIEW_AGE")
+			for _, pr := range v.PullRequests {
+				row(t, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.AssignedAt, pr.ReviewAge)
+			}
+		})
+	}
+}
+
+// ---------- pull requests ----------
+
+type prResponse struct {
+	PR         repo.PullRequest `json:"pr"`
+	ReplacedBy string           `json:"replaced_by"`
+}
+
+func printPR(t *tabwriter.Writer, v prResponse) {
+	pr := v.PR
+	row(t, "id", pr.PullRequestID)
+	row(t, "name", pr.PullRequestName)
+	row(t, "author", pr.AuthorID)
+	row(t, "status", pr.Status)
+	row(t, "reviewers", pr.AssignedReviewers)
+	row(t, "required tags", pr.RequiredTags)
+	row(t, "created at", pr.CreatedAt)
+	row(t, "merged at", pr.MergedAt)
+	row(t, "version", pr.Version)
+	if v.ReplacedBy != "" {
+		row(t, "replaced by", v.ReplacedBy)
+	}
+}
+
+func prGet(fs *flag.FlagSet) action {
+	return func(ctx context.Context, e env, args []string) error {
+		data, err := e.client.do(ctx, request{Method: "GET", Path: "/pullRequest/get", Query: url.Values{"pull_request_id": {args[0]}}})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, printPR)
+	}
+}
+
+// listFlag — повторяемый флаг: --tag go --tag sql.
+type listFlag []string
+
+func (l *listFlag) String() string { return strings.Join(*l, ",") }
+
+func (l *listFlag) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+func prCreate(fs *flag.FlagSet) action {
+	id := fs.String("id", "", "pull request id")
+	name := fs.String("name", "", "pull request name")
+	author := fs.String("author", "", "author user id")
+	var tags listFlag
+	fs.Var(&tags, "tag", "required reviewer skill (repeatable)")
+	return func(ctx context.Context, e env, args []string) error {
+		if *id == "" || *name == "" || *author == "" {
+			return usageError("--id, --name and --author are required")
+		}
+		data, err := e.client.do(ctx, request{
+			Method: "POST",
+			Path:   "/pullRequest/create",
+			Body: map[string]any{
+				"pull_request_id":   *id,
+				"pull_request_name": *name,
+				"author_id":         *author,
+				"required_tags":     []string(tags),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, printPR)
+	}
+}
+
+func prMerge(fs *flag.FlagSet) action {
+	ifMatch := fs.Int64("if-match", 0, "expected pull request version")
+	return func(ctx context.Context, e env, args []string) error {
+		data, err := e.client.do(ctx, request{
+			Method:  "POST",
+			Path:    "/pullRequest/merge",
+			Body:    map[string]any{"pull_request_id": args[0]},
+			IfMatch: *ifMatch,
+		})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, printPR)
+	}
+}
+
+func prReassign(fs *flag.FlagSet) action {
+	old := fs.String("old", "", "reviewer to replace")
+	ifMatch := fs.Int64("if-match", 0, "expected pull request version")
+	return func(ctx context.Context, e env, args []string) error {
+		if *old == "" {
+			return usageError("--old is required")
+		}
+		data, err := e.client.do(ctx, request{
+			Method:  "POST",
+			Path:    "/pullRequest/reassign",
+			Body:    map[string]any{"pull_request_id": args[0], "old_user_id": *old},
+			IfMatch: *ifMatch,
+		})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, printPR)
+	}
+}
+
+func prExplain(fs *flag.FlagSet) action {
+	return func(ctx context.Context, e env, args []string) error {
+		data, err := e.client.do(ctx, request{Method: "GET", Path: "/pullRequest/explain", Query: url.Values{"pull_request_id": {args[0]}}})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, func(t *tabwriter.Writer, x repo.Explanation) {
+			fmt.Fprintf(t, "%s assignment #%d, strategy %s, seed %d, tags %s\n\n",
+				x.Kind, x.ID, x.Strategy, x.Seed, cell(x.Tags))
+			row(t, "USER_ID", "LEVEL", "POSITION", "SELECTED", "TAG_OVERLAP", "OPEN", "EXCLUDED")
+			for _, c := range x.Candidates {
+				pos := any(c.Position)
+				if c.Position == 0 {
+					pos = nil
+				}
+				row(t, c.UserID, c.Level, pos, c.Selected, c.TagOverlap, c.OpenReviews, c.Excluded)
+			}
+		})
+	}
+}
+
+func prAssignments(fs *flag.FlagSet) action {
+	return func(ctx context.Context, e env, args []string) error {
+		data, err := e.client.do(ctx, request{Method: "GET", Path: "/pullRequest/assignments", Query: url.Values{"pull_request_id": {args[0]}}})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, func(t *tabwriter.Writer, v struct {
+			Assignments []repo.Assignment `json:"assignments"`
+		}) {
+			row(t, "ID", "KIND", "CREATED_AT", "REVIEWERS", "REPLACED", "REASON")
+			for _, a := range v.Assignments {
+				row(t, a.ID, a.Kind, a.CreatedAt, a.Reviewers, a.ReplacedReviewerID, a.Reason)
+			}
+		})
+	}
+}
+
+// ---------- stats ----------
+
+func statsReviewers(fs *flag.FlagSet) action {
+	return func(ctx context.Context, e env, args []string) error {
+		data, err := e.client.do(ctx, request{Method: "GET", Path: "/stats/reviewers"})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, func(t *tabwriter.Writer, v struct {
+			Stats []repo.ReviewerStat `json:"stats"`
+		}) {
+			row(t, "USER_ID", "REVIEWS", "OPEN", "OLDEST_OPEN", "DECLINES")
+			for _, s := range v.Stats {
+				oldest := any((time.Duration(s.OldestOpenAgeSeconds) * time.Second).String())
+				if s.OpenCount == 0 {
+					oldest = nil
+				}
+				row(t, s.UserID, s.Count, s.OpenCount, oldest, s.DeclineCount)
+			}
+		})
+	}
+}
+
+func statsPairs(fs *flag.FlagSet) action {
+	return func(ctx context.Context, e env, args []string) error {
+		data, err := e.client.do(ctx, request{Method: "GET", Path: "/stats/pairs", Query: url.Values{"team_name": {args[0]}}})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, func(t *tabwriter.Writer, m repo.PairMatrix) {
+			// строки — авторы, столбцы — ревьюверы
+			header := append([]any{"AUTHOR \\ REVIEWER"}, toAny(m.Members)...)
+			row(t, header...)
+			for i, author := range m.Members {
+				line := []any{author}
+				for _, n := range m.Matrix[i] {
+					line = append(line, n)
+				}
+				row(t, line...)
+			}
+		})
+	}
+}
+
+// ---------- admin ----------
+func adminExport(fs *flag.FlagSet) action {
+	file := fs.String("f", "-", "output file ('-' for stdout)")
+	return func(ctx context.Context, e env, args []string) error {
+		req := request{Method: "GET", Path: "/admin/export", Output: e.out.out}
+		if *file == "-" {
+			_, err := e.client.do(ctx, req)
+			return err
+		}
+		// снимок пишется во временный файл, чтобы оборванная выгрузка не затёрла прежний
+		tmp, err := os.CreateTemp(filepath.Dir(*file), ".prctl-export-*")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(tmp.Name())
+		req.Output = tmp
+		_, err = e.client.do(ctx, req)
+		if cerr := tmp.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+		return os.Rename(tmp.Name(), *file)
+	}
+}
+
+func adminImport(fs *flag.FlagSet) action {
+	file := fs.String("f", "", "snapshot from 'admin export' ('-' for stdin)")
+	dryRun := fs.Bool("dry-run", false, "validate and load in a rolled back transaction")
+	return func(ctx context.Context, e env, args []string) error {
+		if *file == "" {
+			return usageError("-f is required")
+		}
+		raw, err := readFile(*file, e.stdin)
+		if err != nil {
+			return err
+		}
+		data, err := e.client.do(ctx, request{
+			Method:      "POST",
+			Path:        "/admin/import",
+			Query:       url.Values{"dry_run": {fmt.Sprint(*dryRun)}},
+			Raw:         raw,
+			ContentType: "application/x-ndjson",
+		})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, printRestore)
+	}
+}
+
+func printRestore(t *tabwriter.Writer, r repo.RestoreResult) {
+	if r.DryRun {
+		fmt.Fprintln(t, "dry run: nothing was changed")
+	}
+	row(t, "TABLE", "ROWS")
+	for _, spec := range repo.BackupTables {
+		if n, ok := r.Tables[spec.Name]; ok {
+			row(t, spec.Name, n)
+		}
+	}
+	fmt.Fprintf(t, "\n%d rows restored\n", r.Rows)
+}
+
+func adminSimulate(fs *flag.FlagSet) action {
+	reviewers := fs.Int("reviewers", -1, "reviewer count (-1 - from the team policy)")
+	strategy := fs.String("strategy", "", "random or least_recent (empty - from the team policy)")
+	minLevel := fs.String("min-level", "", "seniority rule level (empty - from the team policy)")
+	minCount := fs.Int("min-count", -1, "seniority rule count (-1 - from the team policy)")
+	maxOpen := fs.Int("max-open", -1, "default open review limit (-1 - from the team policy)")
+	var fallback listFlag
+	fs.Var(&fallback, "fallback", "fallback team (repeatable, replaces the policy list)")
+	from := fs.String("from", "", "RFC 3339 time: only pull requests created at or after it")
+	to := fs.String("to", "", "RFC 3339 time: only pull requests created before it")
+	limit := fs.Int("limit", 0, "replay at most this many latest pull requests (0 - server default)")
+	seed := fs.Int64("seed", 0, "seed for ties between equal candidates")
+	return func(ctx context.Context, e env, args []string) error {
+		body := map[string]any{"team_name": args[0], "limit": *limit, "seed": *seed}
+		if *reviewers >= 0 {
+			body["reviewer_count"] = *reviewers
+		}
+		if *strategy != "" {
+			body["strategy"] = *strategy
+		}
+		if *minLevel != "" {
+			body["min_level"] = *minLevel
+		}
+		if *minCount >= 0 {
+			body["min_count"] = *minCount
+		}
+		if *maxOpen >= 0 {
+			body["default_max_open_reviews"] = *maxOpen
+		}
+		if fallback != nil {
+			body["fallback_teams"] = []string(fallback)
+		}
+		for name, v := range map[string]string{"from": *from, "to": *to} {
+			if v == "" {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return usageError("--" + name + " must be an RFC 3339 time")
+			}
+			body[name] = t
+		}
+		data, err := e.client.do(ctx, request{Method: "POST", Path: "/admin/simulate", Body: body})
+		if err != nil {
+			return err
+		}
+		return show(e.out, data, printSimulation)
+	}
+}
+
+func printSimulation(t *tabwriter.Writer, r repo.SimulationResult) {
+	p := r.Policy
+	strategy := "default"
+	if p.Strategy != nil {
+		strategy = *p.Strategy
+	}
+	fmt.Fprintf(t, "team %s: %d reviewers, strategy %s, at least %d %s, fallback %s\n",
+		p.TeamName, p.ReviewerCount, strategy, p.MinCount, p.MinLevel, cell(p.FallbackTeams))
+	if r.PRs == 0 {
+		fmt.Fprintln(t, "no pull requests to replay")
+		return
+	}
+	fmt.Fprintf(t, "%d pull requests from %s to %s\n\n", r.PRs, r.From.Format(time.RFC3339), r.To.Format(time.RFC3339))
+
+	o := r.Outcomes
+	fmt.Fprintf(t, "full %d, short %d, unassigned %d, rejected by seniority %d, used fallback %d\n",
+		o.Full, o.Short, o.Unassigned, o.Rejected, o.UsedFallback)
+	fmt.Fprintf(t, "ran out of candidates: %.1f%%\n\n", o.RanOutRate*100)
+
+	row(t, "RUN", "ASSIGNMENTS", "MIN", "MAX", "MEAN", "STDDEV", "CV", "GINI")
+	for _, f := range []struct {
+		name string
+		v    repo.Fairness
+	}{{"simulated", r.Simulated}, {"actual", r.Actual}} {
+		row(t, f.name, f.v.Assignments, f.v.Min, f.v.Max,
+			fmt.Sprintf("%.2f", f.v.Mean), fmt.Sprintf("%.2f", f.v.StdDev), fmt.Sprintf("%.2f", f.v.CV), fmt.Sprintf("%.2f", f.v.Gini))
+	}
+	fmt.Fprintln(t)
+	row(t, "USER_ID", "TEAM", "SIMULATED", "ACTUAL", "PEAK_OPEN")
+	for _, rv := range r.Reviewers {
+		row(t, rv.UserID, rv.TeamName, rv.Assigned, rv.Actual, rv.PeakOpen)
+	}
+}
+
+func toAny(s []string) []any {
+	res := make([]any, len(s))
+	for i, v := range s {
+		res[i] = v
+	}
+	return res
+}
+
+// readFile читает файл или stdin, если имя — «-».
+func readFile(name string, stdin io.Reader) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(stdin)
+	}
+	return os.ReadFile(name)
+}
+
+func readJSON(name string, stdin io.Reader, v any) error {
+	raw, err := readFile(name, stdin)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("read %s: %w", name, err)
+	}
+	return nil
+}
+
+// errUsage — неверные аргументы; main печатает справку и завершается с кодом 2.
+var errUsage = errors.New("usage")
+
+func usageError(msg string) error {
+	return fmt.Errorf("%w: %s", errUsage, msg)
+}
@@ -0,0 +1,123 @@
+// prctl — административный CLI сервиса назначения ревьюверов. Работает через
+// HTTP API, поэтому изменения проходят те же проверки, что и запросы других клиентов.
+//
+//	prctl [--addr URL] [-o table|json] <group> <command> [flags] [args]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"pr-reviewer-service/internal/lib/cliutil"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run возвращает код выхода: 0 — успех, 1 — ошибка API или сети, 2 — неверные аргументы.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	global := flag.NewFlagSet("prctl", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	addr := global.String("addr", cliutil.EnvOr("PRCTL_ADDR", "http://localhost:8080"), "service base URL (env PRCTL_ADDR)")
+	format := global.String("o", outputTable, "output format: table or json")
+	token := global.String("token", os.Getenv("PRCTL_TOKEN"), "admin bearer token for admin commands (env PRCTL_TOKEN)")
+	timeout := global.Duration("timeout", 30*time.Second, "request timeout")
+	global.Usage = func() { printUsage(stderr, global) }
+	if err := global.Parse(args); err != nil {
+		return 2
+	}
+	if *format != outputTable && *format != outputJSON {
+		fmt.Fprintf(stderr, "unknown output format %q\n", *format)
+		return 2
+	}
+
+	rest := global.Args()
+	if len(rest) < 2 {
+		global.Usage()
+		return 2
+	}
+	cmd, ok := findCommand(rest[0], rest[1])
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n", rest[0]+" "+rest[1])
+		global.Usage()
+		return 2
+	}
+
+	fs := flag.NewFlagSet(cmd.group+" "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	act := cmd.flags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: prctl %s [flags]\n\n%s\n", cmd.usage(), cmd.help)
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, rest[2:])
+	if err != nil {
+		return 2
+	}
+	if len(positional) != len(cmd.args) {
+		fs.Usage()
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	e := env{
+		client: &client{base: *addr, token: *token, http: &http.Client{}},
+		out:    printer{out: stdout, format: *format},
+		stdin:  stdin,
+	}
+	if err := act(ctx, e, positional); err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		if errors.Is(err, errUsage) {
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
+
+// parseInterspersed разрешает флаги после позиционных аргументов:
+// «team deactivate backend --dry-run» работает так же, как «team deactivate --dry-run backend».
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func findCommand(group, name string) (command, bool) {
+	for _, c := range commands {
+		if c.group == group && c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+func printUsage(w io.Writer, global *flag.FlagSet) {
+	fmt.Fprintln(w, "usage: prctl [global flags] <group> <command> [flags] [args]")
+	fmt.Fprintln(w, "\ncommands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-40s %s\n", c.usage(), c.help)
+	}
+	fmt.Fprintln(w, "\nglobal flags:")
+	global.PrintDefaults()
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseInterspersed(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		positional []string
+		dryRun     bool
+		ifMatch    int64
+	}{
+		{"flags first", []string{"--dry-run", "--if-match", "3", "backend"}, []string{"backend"}, true, 3},
+		{"flags last", []string{"backend", "--dry-run", "--if-match=3"}, []string{"backend"}, true, 3},
+		{"no flags", []string{"backend"}, []string{"backend"}, false, 0},
+		{"terminator", []string{"--", "--dry-run"}, []string{"--dry-run"}, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			dryRun := fs.Bool("dry-run", false, "")
+			ifMatch := fs.Int64("if-match", 0, "")
+			positional, err := parseInterspersed(fs, tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(positional, tt.positional) || *dryRun != tt.dryRun || *ifMatch != tt.ifMatch {
+				t.Errorf("got %v dry-run=%v if-match=%d, want %v %v %d", positional, *dryRun, *ifMatch, tt.positional, tt.dryRun, tt.ifMatch)
+			}
+		})
+	}
+}
+
+// apiStub — сервис, который на каждый запрос отвечает status и body
+// и запоминает последний запрос.
+type apiStub struct {
+	status int
+	body   string
+
+	mu      sync.Mutex
+	method  string
+	path    string
+	query   string
+	ifMatch string
+	payload map[string]any
+}
+
+func (s *apiStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.method, s.path, s.query = r.Method, r.URL.Path, r.URL.RawQuery
+	s.ifMatch = r.Header.Get("If-Match")
+	s.payload = nil
+	_ = json.NewDecoder(r.Body).Decode(&s.payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(s.status)
+	_, _ = w.Write([]byte(s.body))
+}
+
+func runAgainst(t *testing.T, stub *apiStub, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	srv := httptest.NewServer(stub)
+	t.Cleanup(srv.Close)
+	var out, errOut bytes.Buffer
+	code = run(context.Background(), append([]string{"--addr", srv.URL, "--token", ""}, args...), strings.NewReader(""), &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRunExitCodes(t *testing.T) {
+	ok := &apiStub{status: 200, body: `{"team":{"team_name":"backend","version":2,"members":[]}}`}
+	notFound := &apiStub{status: 404, body: `{"error":{"code":"NOT_FOUND","message":"team not found"}}`}
+
+	tests := []struct {
+		name   string
+		stub   *apiStub
+		args   []string
+		code   int
+		stderr string
+	}{
+		{"success", ok, []string{"team", "get", "backend"}, 0, ""},
+		{"api error", notFound, []string{"team", "get", "backend"}, 1, "NOT_FOUND: team not found (HTTP 404)"},
+		{"no command", ok, nil, 2, "usage: prctl"},
+		{"unknown command", ok, []string{"team", "explode"}, 2, `unknown command "team explode"`},
+		{"unknown global flag", ok, []string{"--verbose", "team", "get", "backend"}, 2, "flag provided but not defined"},
+		{"bad output format", ok, []string{"-o", "xml", "team", "get", "backend"}, 2, `unknown output format "xml"`},
+		{"missing argument", ok, []string{"team", "get"}, 2, "usage: prctl team get <team_name>"},
+		{"extra argument", ok, []string{"team", "get", "a", "b"}, 2, "usage: prctl team get <team_name>"},
+		{"unknown command flag", ok, []string{"team", "get", "--force", "backend"}, 2, "flag provided but not defined"},
+		{"usage error from action", ok, []string{"team", "import"}, 2, "-f is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runAgainst(t, tt.stub, tt.args...)
+			if code != tt.code {
+				t.Errorf("exit code %d, want %d; stderr:\n%s", code, tt.code, stderr)
+			}
+			if !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("stderr %q does not mention %q", stderr, tt.stderr)
+			}
+		})
+	}
+}
+
+func TestRunNetworkErrorExitCode(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	var out, errOut bytes.Buffer
+	code := run(context.Background(), []string{"--addr", srv.URL, "team", "get", "backend"}, strings.NewReader(""), &out, &errOut)
+	if code != 1 {
+		t.Errorf("exit code %d, want 1; stderr:\n%s", code, errOut.String())
+	}
+}
+
+func TestTeamDeactivateDryRun(t *testing.T) {
+	stub := &apiStub{status: 200, body: `{"team_name":"backend","deactivated_users":["u1","u2"],"reassigned_prs_count":3,"reassign_failures":{},"version":4,"dry_run":true}`}
+
+	code, stdout, stderr := runAgainst(t, stub, "team", "deactivate", "backend", "--dry-run", "--if-match", "4")
+	if code != 0 {
+		t.Fatalf("exit code %d; stderr:\n%s", code, stderr)
+	}
+	if stub.method != "POST" || stub.path != "/team/deactivate" {
+		t.Errorf("request %s %s, want POST /team/deactivate", stub.method, stub.path)
+	}
+	if want := map[string]any{"team_name": "backend", "dry_run": true}; !reflect.DeepEqual(stub.payload, want) {
+		t.Errorf("body %v, want %v", stub.payload, want)
+	}
+	if stub.ifMatch != `"4"` {
+		t.Errorf("If-Match %q, want %q", stub.ifMatch, `"4"`)
+	}
+	for _, s := range []string{"dry run: nothing was changed", "u1,u2", "reassigned PRs", "3"} {
+		if !strings.Contains(stdout, s) {
+			t.Errorf("output does not contain %q:\n%s", s, stdout)
+		}
+	}
+
+	// без --dry-run пометки в выводе нет, и в запросе dry_run=false
+	stub.body = `{"team_name":"backend","deactivated_users":[],"reassigned_prs_count":0,"reassign_failures":{},"version":5}`
+	code, stdout, _ = runAgainst(t, stub, "team", "deactivate", "backend")
+	if code != 0 || strings.Contains(stdout, "dry run") || stub.payload["dry_run"] != false || stub.ifMatch != "" {
+		t.Errorf("code %d, body %v, If-Match %q, output:\n%s", code, stub.payload, stub.ifMatch, stdout)
+	}
+}
+
+func TestTeamImportDryRunQuery(t *testing.T) {
+	stub := &apiStub{status: 200, body: `{"dry_run":true,"teams":[],"changes":[]}`}
+	var out, errOut bytes.Buffer
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	csv := "team_name,user_id,username\nbackend,u1,Alice\n"
+	code := run(context.Background(), []string{"--addr", srv.URL, "-o", "json", "team", "import", "-f", "-", "--format", "csv", "--dry-run"},
+		strings.NewReader(csv), &out, &errOut)
+	if code != 0 {
+		t.Fatalf("exit code %d; stderr:\n%s", code, errOut.String())
+	}
+	if stub.path != "/team/import" || !strings.Contains(stub.query, "dry_run=true") {
+		t.Errorf("request %s?%s, want /team/import with dry_run=true", stub.path, stub.query)
+	}
+	if !json.Valid(out.Bytes()) {
+		t.Errorf("-o json printed invalid JSON:\n%s", out.String())
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// printer печатает ответ API: в режиме json — тело ответа как есть (с отступами),
+// в режиме table — таблицу, которую строит команда.
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+// show декодирует ответ в v и печатает его; table вызывается только в табличном режиме.
+func show[T any](p printer, data []byte, table func(t *tabwriter.Writer, v T)) error {
+	if p.format == outputJSON {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+		_, err := buf.WriteTo(p.out)
+		return err
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	t := tabwriter.NewWriter(p.out, 0, 4, 2, ' ', 0)
+	table(t, v)
+	return t.Flush()
+}
+
+// row печатает строку таблицы из значений, разделённых табуляцией.
+func row(t *tabwriter.Writer, values ...any) {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = cell(v)
+	}
+	fmt.Fprintln(t, strings.Join(s, "\t"))
+}
+
+func cell(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		if x == "" {
+			return "-"
+		}
+		return x
+	case []string:
+		if len(x) == 0 {
+			return "-"
+		}
+		return strings.Join(x, ",")
+	case *int:
+		if x == nil {
+			return "-"
+		}
+		return fmt.Sprint(*x)
+	case *string:
+		if x == nil {
+			return "-"
+		}
+		return cell(*x)
+	case time.Time:
+		return x.Local().Format("2006-01-02 15:04")
+	case *time.Time:
+		if x == nil {
+			return "-"
+		}
+		return cell(*x)
+	case bool:
+		if x {
+			return "yes"
+		}
+		return "no"
+	default:
+		return fmt.Sprint(v)
+	}
+}
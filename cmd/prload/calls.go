@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// call — один вызов API из файла, по JSON-объекту на строку:
+//
+//	{"method":"POST","path":"/pullRequest/create","body":{"pull_request_id":"load-{{run}}-{{seq}}",...}}
+//
+// В path, body и значениях заголовков подставляются {{seq}} — сквозной номер
+// вызова с 1, {{iter}} — номер прохода по файлу с 1 и {{run}} — метка запуска,
+// чтобы идентификаторы не повторялись между запусками на одной базе.
+type call struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+	// Name — маршрут в отчёте; по умолчанию метод и путь без query.
+	Name string `json:"name,omitempty"`
+}
+
+// readCalls читает файл вызовов ('-' — stdin); пустые строки пропускаются.
+func readCalls(name string, stdin io.Reader) ([]call, error) {
+	var r io.Reader = stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var calls []call
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), 4<<20)
+	for n := 1; sc.Scan(); n++ {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var c call
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&c); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", name, n, err)
+		}
+		c.Method = strings.ToUpper(c.Method)
+		if c.Method == "" {
+			c.Method = http.MethodGet
+		}
+		if !strings.HasPrefix(c.Path, "/") {
+			return nil, fmt.Errorf("%s:%d: path must start with /", name, n)
+		}
+		if c.Name == "" {
+			path, _, _ := strings.Cut(c.Path, "?")
+			c.Name = c.Method + " " + path
+		}
+		calls = append(calls, c)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("%s: no calls", name)
+	}
+	return calls, nil
+}
+
+// vars — значения подстановок для конкретного вызова.
+type vars struct {
+	run       string
+	seq, iter int
+}
+
+func (v vars) expand(s string) string {
+	if !strings.Contains(s, "{{") {
+		return s
+	}
+	return strings.NewReplacer(
+		"{{seq}}", strconv.Itoa(v.seq),
+		"{{iter}}", strconv.Itoa(v.iter),
+		"{{run}}", v.run,
+	).Replace(s)
+}
+
+// request собирает HTTP-запрос к base с подставленными значениями.
+func (c call) request(base string, v vars) (*http.Request, error) {
+	var body io.Reader
+	if len(c.Body) > 0 {
+		body = strings.NewReader(v.expand(string(c.Body)))
+	}
+	req, err := http.NewRequest(c.Method, strings.TrimRight(base, "/")+v.expand(c.Path), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, val := range c.Headers {
+		req.Header.Set(k, v.expand(val))
+	}
+	return req, nil
+}
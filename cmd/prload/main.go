@@ -0,0 +1,161 @@
+// prload — воспроизведение и нагрузочная проверка API. Читает файл вызовов в
+// JSON Lines и отправляет их с заданной частотой и параллельностью в работающий
+// сервис (--addr) или в роутер в том же процессе поверх базы (--db). В конце
+// печатает перцентили задержек по маршрутам, разбивку кодов ответов и то, как
+// ровно назначения разошлись по ревьюверам.
+//
+//	prload [flags] -f calls.jsonl
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"pr-reviewer-service/internal/events"
+	apihandler "pr-reviewer-service/internal/http/handlers"
+	"pr-reviewer-service/internal/lib/cliutil"
+	"pr-reviewer-service/internal/storage"
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run возвращает код выхода: 0 — успех, 1 — превышены пороги или сбой,
+// 2 — неверные аргументы.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("prload", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("f", "", "JSON Lines file with calls ('-' for stdin)")
+	setupFile := fs.String("setup", "", "calls run once, one by one and unmeasured, before the load")
+	addr := fs.String("addr", cliutil.EnvOr("PRLOAD_ADDR", "http://localhost:8080"), "service base URL (env PRLOAD_ADDR)")
+	dsn := fs.String("db", "", "run the router in-process over this Postgres DSN instead of --addr")
+	strategy := fs.String("strategy", repo.StrategyRandom, "assignment strategy for --db")
+	concurrency := fs.Int("c", 8, "concurrent workers")
+	rate := fs.Float64("rate", 0, "calls per second (0 — as fast as possible)")
+	repeat := fs.Int("repeat", 1, "passes over the file")
+	duration := fs.Duration("duration", 0, "loop over the file for this long instead of --repeat")
+	timeout := fs.Duration("timeout", 10*time.Second, "per-call timeout")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	max5xx := fs.Float64("max-error-rate", 0, "fail if the share of 5xx and network errors is higher")
+	maxP99 := fs.Duration("max-p99", 0, "fail if any route's p99 latency is higher (0 — no limit)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: prload [flags] -f calls.jsonl")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" || *concurrency < 1 || *repeat < 1 || *rate < 0 {
+		fs.Usage()
+		return 2
+	}
+
+	calls, err := readCalls(*file, stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return 2
+	}
+	var setupCalls []call
+	if *setupFile != "" {
+		if setupCalls, err = readCalls(*setupFile, stdin); err != nil {
+			fmt.Fprintln(stderr, "error:", err)
+			return 2
+		}
+	}
+
+	opts := options{
+		base:        *addr,
+		concurrency: *concurrency,
+		rate:        *rate,
+		repeat:      *repeat,
+		duration:    *duration,
+		timeout:     *timeout,
+		run:         strconv.FormatInt(time.Now().Unix(), 36),
+	}
+	client := &http.Client{Transport: &http.Transport{MaxIdleConnsPerHost: *concurrency}}
+	if *dsn != "" {
+		h, closeFn, err := inProcess(*dsn, *strategy)
+		if err != nil {
+			fmt.Fprintln(stderr, "error:", err)
+			return 1
+		}
+		defer closeFn()
+		client = &http.Client{Transport: handlerTransport{h: h}}
+		opts.base = "http://prload.local"
+	}
+
+	if err := setup(ctx, client, opts, setupCalls); err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return 1
+	}
+
+	col := newCollector()
+	start := time.Now()
+	load(ctx, client, opts, calls, col.add)
+	rep := col.report(time.Since(start))
+
+	if *asJSON {
+		if err := rep.printJSON(stdout); err != nil {
+			fmt.Fprintln(stderr, "error:", err)
+			return 1
+		}
+	} else {
+		rep.printTable(stdout)
+	}
+
+	if err := rep.check(*max5xx, *maxP99); err != nil {
+		fmt.Fprintln(stderr, "FAIL:", err)
+		return 1
+	}
+	return 0
+}
+
+// check сверяет отчёт с порогами, с которыми сборка считается годной к релизу.
+func (r report) check(maxErrorRate float64, maxP99 time.Duration) error {
+	var errs []error
+	if r.ErrorRate > maxErrorRate {
+		errs = append(errs, fmt.Errorf("error rate %.2f%% is above %.2f%%", r.ErrorRate*100, maxErrorRate*100))
+	}
+	if maxP99 > 0 {
+		for _, rr := range r.Routes {
+			if rr.P99 > ms(maxP99) {
+				errs = append(errs, fmt.Errorf("%s p99 %.2fms is above %s", rr.Route, rr.P99, maxP99))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// inProcess собирает роутер сервиса поверх базы без HTTP-сервера. Миграции не
+// запускаются: база должна быть подготовлена самим сервисом.
+func inProcess(dsn, strategy string) (http.Handler, func(), error) {
+	if !repo.ValidStrategy(strategy) {
+		return nil, nil, fmt.Errorf("unknown assignment strategy %q", strategy)
+	}
+	pool, err := storage.NewPool(dsn)
+	if err != nil {
+		return nil, nil, err
+	}
+	// журнал запросов chi пишет в стандартный логгер и заглушил бы отчёт
+	log.SetOutput(io.Discard)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// поток событий при нагрузке не нужен: брокер без слушателя базы
+	store := repo.New(pool, repo.Options{Strategy: strategy})
+	h := apihandler.NewRouter(store, events.NewBroker(), logger, apihandler.Options{})
+	return h, pool.Close, nil
+}
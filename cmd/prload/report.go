@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// collector накапливает результаты вызовов из всех воркеров.
+type collector struct {
+	mu      sync.Mutex
+	routes  map[string]*routeStats
+	load    map[string]int
+	perPR   map[int]int
+	creates int
+}
+
+type routeStats struct {
+	latencies []time.Duration
+	codes     map[string]int
+	errors5xx int
+}
+
+func newCollector() *collector {
+	return &collector{routes: map[string]*routeStats{}, load: map[string]int{}, perPR: map[int]int{}}
+}
+
+func (c *collector) add(r result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.routes[r.route]
+	if s == nil {
+		s = &routeStats{codes: map[string]int{}}
+		c.routes[r.route] = s
+	}
+	s.latencies = append(s.latencies, r.latency)
+	s.codes[outcome(r)]++
+	if r.status == 0 || r.status >= 500 {
+		s.errors5xx++
+	}
+
+	for _, id := range r.reviewers {
+		c.load[id]++
+	}
+	if r.create && r.status < 300 {
+		c.creates++
+		c.perPR[len(r.reviewers)]++
+	}
+}
+
+// outcome — строка разбивки ответов: статус и код ошибки сервиса, если он есть.
+func outcome(r result) string {
+	switch {
+	case r.status == 0:
+		return r.code
+	case r.code != "":
+		return strconv.Itoa(r.status) + " " + r.code
+	default:
+		return strconv.Itoa(r.status)
+	}
+}
+
+type report struct {
+	Calls      int               `json:"calls"`
+	Elapsed    float64           `json:"elapsed_seconds"`
+	Throughput float64           `json:"calls_per_second"`
+	ErrorRate  float64           `json:"error_rate"`
+	Routes     []routeReport     `json:"routes"`
+	Outcomes   map[string]int    `json:"outcomes"`
+	Assignment *assignmentReport `json:"assignment,omitempty"`
+}
+
+type routeReport struct {
+	Route    string         `json:"route"`
+	Calls    int            `json:"calls"`
+	P50      float64        `json:"p50_ms"`
+	P90      float64        `json:"p90_ms"`
+	P99      float64        `json:"p99_ms"`
+	Max      float64        `json:"max_ms"`
+	Errors   int            `json:"errors_5xx"`
+	Outcomes map[string]int `json:"outcomes"`
+}
+
+// assignmentReport — насколько ровно назначения разошлись по ревьюверам.
+// CV — коэффициент вариации (stddev / mean): 0 — идеально ровно.
+type assignmentReport struct {
+	PRs          int            `json:"created_prs"`
+	Assignments  int            `json:"assignments"`
+	Reviewers    int            `json:"reviewers"`
+	Min          int            `json:"min"`
+	Max          int            `json:"max"`
+	Mean         float64        `json:"mean"`
+	StdDev       float64        `json:"stddev"`
+	CV           float64        `json:"cv"`
+	ReviewersPer map[string]int `json:"reviewers_per_pr"`
+	Top          []reviewerLoad `json:"top"`
+}
+
+type reviewerLoad struct {
+	UserID string `json:"user_id"`
+	Count  int    `json:"count"`
+}
+
+func (c *collector) report(elapsed time.Duration) report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rep := report{Elapsed: elapsed.Seconds(), Outcomes: map[string]int{}}
+	failed := 0
+	for name, s := range c.routes {
+		sort.Slice(s.latencies, func(i, j int) bool { return s.latencies[i] < s.latencies[j] })
+		rr := routeReport{
+			Route:    name,
+			Calls:    len(s.latencies),
+			P50:      ms(percentile(s.latencies, 50)),
+			P90:      ms(percentile(s.latencies, 90)),
+			P99:      ms(percentile(s.latencies, 99)),
+			Max:      ms(s.latencies[len(s.latencies)-1]),
+			Errors:   s.errors5xx,
+			Outcomes: s.codes,
+		}
+		rep.Routes = append(rep.Routes, rr)
+		rep.Calls += rr.Calls
+		failed += s.errors5xx
+		for k, v := range s.codes {
+			rep.Outcomes[k] += v
+		}
+	}
+	sort.Slice(rep.Routes, func(i, j int) bool { return rep.Routes[i].Route < rep.Routes[j].Route })
+	if rep.Calls > 0 {
+		rep.ErrorRate = float64(failed) / float64(rep.Calls)
+	}
+	if elapsed > 0 {
+		rep.Throughput = float64(rep.Calls) / elapsed.Seconds()
+	}
+
+	if len(c.load) > 0 {
+		a := &assignmentReport{PRs: c.creates, Reviewers: len(c.load), Min: math.MaxInt, ReviewersPer: map[string]int{}}
+		for id, n := range c.load {
+			a.Assignments += n
+			a.Min = min(a.Min, n)
+			a.Max = max(a.Max, n)
+			a.Top = append(a.Top, reviewerLoad{UserID: id, Count: n})
+		}
+		a.Mean = float64(a.Assignments) / float64(a.Reviewers)
+		var sq float64
+		for _, n := range c.load {
+			sq += (float64(n) - a.Mean) * (float64(n) - a.Mean)
+		}
+		a.StdDev = math.Sqrt(sq / float64(a.Reviewers))
+		if a.Mean > 0 {
+			a.CV = a.StdDev / a.Mean
+		}
+		for k, v := range c.perPR {
+			a.ReviewersPer[strconv.Itoa(k)] = v
+		}
+		sort.Slice(a.Top, func(i, j int) bool {
+			if a.Top[i].Count != a.Top[j].Count {
+				return a.Top[i].Count > a.Top[j].Count
+			}
+			return a.Top[i].UserID < a.Top[j].UserID
+		})
+		a.Top = a.Top[:min(len(a.Top), 10)]
+		rep.Assignment = a
+	}
+	return rep
+}
+
+// percentile — значение по методу ближайшего ранга; latencies отсортированы.
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(latencies))))
+	return latencies[max(rank, 1)-1]
+}
+
+func ms(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Millisecond)*100) / 100
+}
+
+func (r report) printJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func (r report) printTable(w io.Writer) {
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(t, "%d calls in %.1fs, %.1f calls/s, 5xx and network errors %.2f%%\n\n",
+		r.Calls, r.Elapsed, r.Throughput, r.ErrorRate*100)
+
+	fmt.Fprintln(t, "ROUTE\tCALLS\tP50 MS\tP90 MS\tP99 MS\tMAX MS\tOUTCOMES")
+	for _, rr := range r.Routes {
+		fmt.Fprintf(t, "%s\t%d\t%.2f\t%.2f\t%.2f\t%.2f\t%s\n", rr.Route, rr.Calls, rr.P50, rr.P90, rr.P99, rr.Max, outcomes(rr.Outcomes))
+	}
+
+	if a := r.Assignment; a != nil {
+		fmt.Fprintf(t, "\n%d PRs created, %d assignments over %d reviewers\n", a.PRs, a.Assignments, a.Reviewers)
+		fmt.Fprintf(t, "per reviewer: min %d, max %d, mean %.2f, stddev %.2f, cv %.2f\n", a.Min, a.Max, a.Mean, a.StdDev, a.CV)
+		fmt.Fprintf(t, "reviewers per PR: %s\n", outcomes(a.ReviewersPer))
+		fmt.Fprintln(t, "\nREVIEWER\tASSIGNED")
+		for _, l := range a.Top {
+			fmt.Fprintf(t, "%s\t%d\n", l.UserID, l.Count)
+		}
+	}
+	t.Flush()
+}
+
+// outcomes печатает разбивку «ключ=число» по убыванию числа.
+func outcomes(m map[string]int) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if m[keys[i]] != m[keys[j]] {
+			return m[keys[i]] > m[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	s := ""
+	for i, k := range keys {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%s=%d", k, m[k])
+	}
+	return s
+}
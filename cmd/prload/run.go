@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// handlerTransport отдаёт запросы роутеру в том же процессе, минуя сеть:
+// так измеряется сам сервис и база без накладных расходов HTTP.
+type handlerTransport struct {
+	h http.Handler
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	t.h.ServeHTTP(rec, req)
+	return rec.Result(), nil
+}
+
+type options struct {
+	base        string
+	concurrency int
+	// rate — вызовов в секунду; 0 — без ограничения.
+	rate     float64
+	repeat   int
+	duration time.Duration
+	timeout  time.Duration
+	run      string
+}
+
+// result — исход одного вызова.
+type result struct {
+	route   string
+	status  int
+	code    string
+	latency time.Duration
+	// reviewers — кого назначил вызов (для распределения назначений).
+	reviewers []string
+	create    bool
+}
+
+// setup выполняет подготовительные вызовы по одному и не измеряет их:
+// любой ответ 4xx/5xx останавливает запуск.
+func setup(ctx context.Context, client *http.Client, opts options, calls []call) error {
+	for i, c := range calls {
+		req, err := c.request(opts.base, vars{run: opts.run, seq: i + 1, iter: 1})
+		if err != nil {
+			return err
+		}
+		res := do(ctx, client, opts.timeout, c.Name, req)
+		if res.status == 0 || res.status >= 400 {
+			return fmt.Errorf("setup call %d (%s): %s", i+1, c.Name, res.code)
+		}
+	}
+	return nil
+}
+
+// load раздаёт вызовы воркерам с заданной частотой: файл проходится repeat
+// раз или по кругу, пока не истечёт duration. Вызовы уходят в порядке файла,
+// но при concurrency > 1 выполняются вперемешку.
+func load(ctx context.Context, client *http.Client, opts options, calls []call, collect func(result)) {
+	type job struct {
+		call call
+		vars vars
+	}
+	jobs := make(chan job)
+
+	var wg sync.WaitGroup
+	for range opts.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				req, err := j.call.request(opts.base, j.vars)
+				if err != nil {
+					collect(result{route: j.call.Name, code: "BAD_CALL"})
+					continue
+				}
+				collect(do(ctx, client, opts.timeout, j.call.Name, req))
+			}
+		}()
+	}
+
+	var tick <-chan time.Time
+	if opts.rate > 0 {
+		t := time.NewTicker(time.Duration(float64(time.Second) / opts.rate))
+		defer t.Stop()
+		tick = t.C
+	}
+	var deadline <-chan time.Time
+	if opts.duration > 0 {
+		deadline = time.After(opts.duration)
+	}
+
+	seq := 0
+dispatch:
+	for iter := 1; opts.duration > 0 || iter <= opts.repeat; iter++ {
+		for _, c := range calls {
+			if tick != nil {
+				select {
+				case <-tick:
+				case <-deadline:
+					break dispatch
+				case <-ctx.Done():
+					break dispatch
+				}
+			}
+			seq++
+			select {
+			case jobs <- job{call: c, vars: vars{run: opts.run, seq: seq, iter: iter}}:
+			case <-deadline:
+				break dispatch
+			case <-ctx.Done():
+				break dispatch
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// do выполняет запрос и разбирает ответ: код ошибки сервиса берётся из тела
+// {"error":{"code":...}}, назначенные ревьюверы — из ответов создания PR и замены.
+func do(ctx context.Context, client *http.Client, timeout time.Duration, route string, req *http.Request) result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		code := "NETWORK"
+		if ctx.Err() != nil {
+			code = "CLIENT_TIMEOUT"
+		}
+		return result{route: route, code: code, latency: time.Since(start)}
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	res := result{route: route, status: resp.StatusCode, latency: time.Since(start)}
+	if err != nil {
+		res.code = "NETWORK"
+		return res
+	}
+
+	if resp.StatusCode >= 400 {
+		var e struct {
+			Error struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &e) == nil && e.Error.Code != "" {
+			res.code = e.Error.Code
+		}
+		return res
+	}
+
+	path := req.URL.Path
+	res.create = path == "/pullRequest/create"
+	if res.create || strings.Contains(string(data), `"replaced_by"`) {
+		var body struct {
+			PR struct {
+				AssignedReviewers []string `json:"assigned_reviewers"`
+			} `json:"pr"`
+			ReplacedBy string `json:"replaced_by"`
+		}
+		if json.Unmarshal(data, &body) == nil {
+			if res.create {
+				res.reviewers = body.PR.AssignedReviewers
+			} else if body.ReplacedBy != "" {
+				res.reviewers = []string{body.ReplacedBy}
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,73 @@
+// Package backup — снимок состояния сервиса в формате JSON Lines: по одному
+// JSON-объекту на строку, как в requests.jsonl. Первая строка — заголовок,
+// затем строки таблиц в порядке зависимостей, последняя — итог с числом строк:
+//
+//	{"format":"pr-reviewer-export","version":1,"exported_at":"2026-10-19T10:00:00Z"}
+//	{"table":"teams","row":{"team_name":"backend","version":3,...}}
+//	...
+//	{"end":true,"rows":1234}
+//
+// По итоговой строке восстановление отличает полный снимок от оборванного.
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+const (
+	Format  = "pr-reviewer-export"
+	Version = 1
+	// MediaType — тип содержимого снимка в HTTP.
+	MediaType = "application/x-ndjson"
+)
+
+// line — любая строка снимка; какая именно, видно по заполненным полям.
+type line struct {
+	Format     string          `json:"format,omitempty"`
+	Version    int             `json:"version,omitempty"`
+	ExportedAt *time.Time      `json:"exported_at,omitempty"`
+	Table      string          `json:"table,omitempty"`
+	Row        json.RawMessage `json:"row,omitempty"`
+	End        bool            `json:"end,omitempty"`
+	Rows       *int            `json:"rows,omitempty"`
+}
+
+// Source — хранилище, из которого снимается снимок; *repo.Store его реализует.
+type Source interface {
+	ExportRows(ctx context.Context, fn func(table string, row []byte) error) error
+}
+
+// Write пишет снимок src в w и возвращает число строк таблиц. При ошибке
+// итоговая строка не пишется, и такой снимок не восстановится.
+func Write(ctx context.Context, w io.Writer, src Source, now time.Time) (int, error) {
+	bw := bufio.NewWriterSize(w, 64<<10)
+	enc := json.NewEncoder(bw)
+	now = now.UTC()
+	if err := enc.Encode(line{Format: Format, Version: Version, ExportedAt: &now}); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	var buf bytes.Buffer
+	err := src.ExportRows(ctx, func(table string, row []byte) error {
+		// row_to_json пишет объект в одну строку, но лишние пробелы всё равно убираются
+		buf.Reset()
+		if err := json.Compact(&buf, row); err != nil {
+			return err
+		}
+		n++
+		return enc.Encode(line{Table: table, Row: buf.Bytes()})
+	})
+	if err != nil {
+		return n, err
+	}
+	if err := enc.Encode(line{End: true, Rows: &n}); err != nil {
+		return n, err
+	}
+	return n, bw.Flush()
+}
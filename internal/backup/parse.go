@@ -0,0 +1,231 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"pr-reviewer-service/internal/bulkimport"
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+const (
+	// maxLineBytes — предел длины одной строки снимка.
+	maxLineBytes = 16 << 20
+	// maxProblems — сколько проблем перечисляется, прежде чем разбор остановится.
+	maxProblems = 100
+)
+
+// row — строка таблицы с номером строки файла и разобранными значениями колонок.
+type row struct {
+	line   int
+	values map[string]any
+}
+
+type table struct {
+	spec    repo.BackupTable
+	columns []string
+	raw     []json.RawMessage
+	rows    []row
+}
+
+// Parse читает снимок и проверяет его целиком: формат, полноту, уникальность
+// первичных ключей и ссылки между таблицами. Ошибка возвращается только при
+// сбое чтения; проблемы снимка — в problems, и тогда таблицы не возвращаются.
+func Parse(r io.Reader) ([]repo.RestoreTable, []bulkimport.Problem, error) {
+	specs := map[string]repo.BackupTable{}
+	for _, t := range repo.BackupTables {
+		specs[t.Name] = t
+	}
+	tables := map[string]*table{}
+	var problems []bulkimport.Problem
+	add := func(n int, field, format string, args ...any) {
+		problems = append(problems, bulkimport.Problem{Line: n, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), maxLineBytes)
+	n, header, end, count := 0, false, 0, 0
+	for sc.Scan() && len(problems) < maxProblems {
+		n++
+		text := bytes.TrimSpace(sc.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var l line
+		if err := json.Unmarshal(text, &l); err != nil {
+			add(n, "", "invalid json: %v", err)
+			continue
+		}
+		switch {
+		case end > 0:
+			add(n, "", "unexpected line after the end line %d", end)
+		case !header:
+			if l.Format != Format {
+				add(n, "format", "must be %q: the first line is the snapshot header", Format)
+				return nil, problems, nil
+			}
+			if l.Version < 1 || l.Version > Version {
+				add(n, "version", "%d is not supported (max %d)", l.Version, Version)
+				return nil, problems, nil
+			}
+			header = true
+		case l.End:
+			end = n
+			if l.Rows == nil || *l.Rows != count {
+				add(n, "rows", "does not match the number of table rows (%d)", count)
+			}
+		case l.Table != "":
+			count++
+			spec, ok := specs[l.Table]
+			if !ok {
+				add(n, "table", "unknown table %q", l.Table)
+				continue
+			}
+			t := tables[l.Table]
+			if t == nil {
+				t = &table{spec: spec}
+				tables[l.Table] = t
+			}
+			if msg := t.add(n, l.Row); msg != "" {
+				add(n, l.Table, "%s", msg)
+			}
+		default:
+			add(n, "", "line must be the header, a table row or the end line")
+		}
+	}
+	if err := sc.Err(); err != nil {
+		if err == bufio.ErrTooLong {
+			add(n+1, "", "line is longer than %d bytes", maxLineBytes)
+			return nil, problems, nil
+		}
+		return nil, nil, err
+	}
+	if len(problems) >= maxProblems {
+		add(0, "", "too many problems, stopped at line %d", n)
+		return nil, problems, nil
+	}
+	if !header {
+		add(0, "", "snapshot is empty")
+	} else if end == 0 {
+		add(0, "", "snapshot is truncated: no end line")
+	}
+
+	problems = append(problems, validate(tables)...)
+	if len(problems) > 0 {
+		// по порядку строк; проблемы всего файла (Line == 0) — в конце
+		sort.SliceStable(problems, func(i, j int) bool {
+			li, lj := problems[i].Line, problems[j].Line
+			return li != 0 && (lj == 0 || li < lj)
+		})
+		return nil, problems, nil
+	}
+
+	res := make([]repo.RestoreTable, 0, len(tables))
+	for _, spec := range repo.BackupTables {
+		if t := tables[spec.Name]; t != nil {
+			res = append(res, repo.RestoreTable{Name: spec.Name, Columns: t.columns, Rows: t.raw})
+		}
+	}
+	return res, nil, nil
+}
+
+// add запоминает строку таблицы; все строки одной таблицы должны иметь один
+// набор колонок — так их можно вставлять пачками.
+func (t *table) add(n int, raw json.RawMessage) string {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var values map[string]any
+	if err := dec.Decode(&values); err != nil || values == nil {
+		return "row must be a JSON object"
+	}
+	cols := make([]string, 0, len(values))
+	for c := range values {
+		cols = append(cols, c)
+	}
+	sort.Strings(cols)
+	if t.columns == nil {
+		t.columns = cols
+	} else if !slices.Equal(cols, t.columns) {
+		return fmt.Sprintf("row columns differ from the first row of the table (%s)", strings.Join(t.columns, ", "))
+	}
+	t.raw = append(t.raw, raw)
+	t.rows = append(t.rows, row{line: n, values: values})
+	return ""
+}
+
+// validate проверяет первичные ключи и ссылки. Ссылки сверяются со всем
+// файлом, поэтому порядок таблиц в нём не важен.
+func validate(tables map[string]*table) []bulkimport.Problem {
+	var res []bulkimport.Problem
+	keys := map[string]map[string]int{}
+	for _, spec := range repo.BackupTables {
+		t := tables[spec.Name]
+		if t == nil {
+			continue
+		}
+		seen := map[string]int{}
+		for _, r := range t.rows {
+			parts := make([]string, 0, len(spec.Key))
+			for _, c := range spec.Key {
+				v, ok := value(r.values[c])
+				if !ok {
+					res = append(res, bulkimport.Problem{Line: r.line, Field: spec.Name + "." + c, Message: "is required"})
+				}
+				parts = append(parts, v)
+			}
+			key := strings.Join(parts, "\x00")
+			if first, dup := seen[key]; dup {
+				res = append(res, bulkimport.Problem{Line: r.line, Field: spec.Name, Message: fmt.Sprintf("duplicate key %s (first seen on line %d)", strings.Join(parts, ", "), first)})
+				continue
+			}
+			seen[key] = r.line
+		}
+		keys[spec.Name] = seen
+	}
+
+	for _, spec := range repo.BackupTables {
+		t := tables[spec.Name]
+		if t == nil {
+			continue
+		}
+		cols := make([]string, 0, len(spec.Refs))
+		for c := range spec.Refs {
+			cols = append(cols, c)
+		}
+		sort.Strings(cols)
+		for _, r := range t.rows {
+			for _, c := range cols {
+				v, ok := value(r.values[c])
+				if !ok {
+					// обязательность колонок проверяет база
+					continue
+				}
+				target := spec.Refs[c]
+				if _, found := keys[target][v]; !found {
+					res = append(res, bulkimport.Problem{Line: r.line, Field: spec.Name + "." + c, Message: fmt.Sprintf("references unknown %s %q", target, v)})
+				}
+			}
+		}
+	}
+	return res
+}
+
+// value приводит значение ключевой колонки к строке; null и отсутствие — !ok.
+func value(v any) (string, bool) {
+	switch v := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
@@ -0,0 +1,130 @@
+// Package bulkimport разбирает файлы массового импорта команд (CSV или YAML)
+// и проверяет их целиком до того, как что-либо будет записано: все найденные
+// ошибки возвращаются вместе с номерами строк.
+package bulkimport
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatYAML = "yaml"
+)
+
+// Problem — ошибка в строке файла; Line == 0, если строку определить нельзя.
+type Problem struct {
+	Line    int    `json:"line,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	s := p.Message
+	if p.Field != "" {
+		s = p.Field + " " + s
+	}
+	if p.Line > 0 {
+		s = fmt.Sprintf("line %d: %s", p.Line, s)
+	}
+	return s
+}
+
+// FormatFromContentType определяет формат по Content-Type запроса.
+func FormatFromContentType(ct string) (string, bool) {
+	ct, _, _ = strings.Cut(ct, ";")
+	switch strings.TrimSpace(strings.ToLower(ct)) {
+	case "text/csv":
+		return FormatCSV, true
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return FormatYAML, true
+	}
+	return "", false
+}
+
+// record — участник команды из файла вместе с номером строки.
+type record struct {
+	line   int
+	team   string
+	member repo.TeamMember
+}
+
+// Parse читает файл и возвращает команды в порядке первого упоминания.
+// Если есть хотя бы одна проблема, команды не возвращаются.
+func Parse(r io.Reader, format string) ([]repo.Team, []Problem) {
+	var recs []record
+	var problems []Problem
+	switch format {
+	case FormatCSV:
+		recs, problems = parseCSV(r)
+	case FormatYAML:
+		recs, problems = parseYAML(r)
+	default:
+		return nil, []Problem{{Message: fmt.Sprintf("unsupported format %q", format)}}
+	}
+	problems = append(problems, validate(recs)...)
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return group(recs), nil
+}
+
+func validate(recs []record) []Problem {
+	var res []Problem
+	seen := map[string]int{}
+	for _, rec := range recs {
+		add := func(field, msg string, args ...any) {
+			res = append(res, Problem{Line: rec.line, Field: field, Message: fmt.Sprintf(msg, args...)})
+		}
+		m := rec.member
+		if rec.team == "" {
+			add("team_name", "must not be empty")
+		}
+		if m.UserID == "" {
+			add("user_id", "must not be empty")
+		} else if first, ok := seen[m.UserID]; ok {
+			add("user_id", "%q is already listed at line %d", m.UserID, first)
+		} else {
+			seen[m.UserID] = rec.line
+		}
+		if m.Username == "" {
+			add("username", "must not be empty")
+		}
+		if m.Level != "" && !repo.ValidLevel(m.Level) {
+			add("level", "must be one of: junior, middle, senior, lead")
+		}
+		tags := map[string]bool{}
+		for _, sk := range m.Skills {
+			tag := strings.ToLower(sk.Tag)
+			switch {
+			case tag == "":
+				add("skills", "tag must not be empty")
+			case tags[tag]:
+				add("skills", "duplicate tag %q", tag)
+			case sk.Level < 1 || sk.Level > 5:
+				add("skills", "level of %q must be between 1 and 5", tag)
+			}
+			tags[tag] = true
+		}
+	}
+	return res
+}
+
+func group(recs []record) []repo.Team {
+	var teams []repo.Team
+	index := map[string]int{}
+	for _, rec := range recs {
+		i, ok := index[rec.team]
+		if !ok {
+			i = len(teams)
+			index[rec.team] = i
+			teams = append(teams, repo.Team{TeamName: rec.team, Members: []repo.TeamMember{}})
+		}
+		teams[i].Members = append(teams[i].Members, rec.member)
+	}
+	return teams
+}
@@ -0,0 +1,183 @@
+package bulkimport
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+const goodCSV = `team_name,user_id,username,is_active,level,skills
+backend,u1,Alice,true,Senior,go:3;sql:2
+backend,u2,Bob,false,,
+frontend,u3,Carol,,junior,ts:1
+`
+
+const goodYAML = `teams:
+  - team_name: backend
+    members:
+      - user_id: u1
+        username: Alice
+        level: Senior
+        skills:
+          - {tag: go, level: 3}
+          - {tag: sql, level: 2}
+      - user_id: u2
+        username: Bob
+        is_active: false
+  - team_name: frontend
+    members:
+      - user_id: u3
+        username: Carol
+        level: junior
+        skills:
+          - {tag: ts, level: 1}
+`
+
+func TestParse(t *testing.T) {
+	want := []repo.Team{
+		{TeamName: "backend", Members: []repo.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true, Level: "senior", Skills: []repo.Skill{{Tag: "go", Level: 3}, {Tag: "sql", Level: 2}}},
+			{UserID: "u2", Username: "Bob", IsActive: false},
+		}},
+		{TeamName: "frontend", Members: []repo.TeamMember{
+			{UserID: "u3", Username: "Carol", IsActive: true, Level: "junior", Skills: []repo.Skill{{Tag: "ts", Level: 1}}},
+		}},
+	}
+
+	// CSV и YAML с одними данными дают одинаковый результат
+	for format, in := range map[string]string{FormatCSV: goodCSV, FormatYAML: goodYAML} {
+		t.Run(format, func(t *testing.T) {
+			teams, problems := Parse(strings.NewReader(in), format)
+			if len(problems) > 0 {
+				t.Fatalf("problems: %v", problems)
+			}
+			if !reflect.DeepEqual(teams, want) {
+				t.Errorf("got  %+v\nwant %+v", teams, want)
+			}
+		})
+	}
+}
+
+func TestParseTeamsInFirstMentionOrder(t *testing.T) {
+	in := "team_name,user_id,username\nb,u1,A\na,u2,B\nb,u3,C\n"
+	teams, problems := Parse(strings.NewReader(in), FormatCSV)
+	if len(problems) > 0 {
+		t.Fatal(problems)
+	}
+	var got []string
+	for _, team := range teams {
+		got = append(got, team.TeamName)
+	}
+	if strings.Join(got, ",") != "b,a" || len(teams[0].Members) != 2 {
+		t.Errorf("teams %+v, want b with two members, then a", teams)
+	}
+}
+
+func TestParseProblems(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		in     string
+		want   []Problem
+	}{
+		{"unsupported format", "xml", "", []Problem{{Message: `unsupported format "xml"`}}},
+
+		{"csv empty file", FormatCSV, "", []Problem{{Message: "file is empty"}}},
+		{"csv no rows", FormatCSV, "team_name,user_id,username\n", []Problem{{Message: "file has no rows"}}},
+		{"csv unknown column", FormatCSV, "team_name,user_id,username,email\nt,u1,A,a@x\n",
+			[]Problem{{Line: 1, Field: "email", Message: "unknown column"}}},
+		{"csv duplicate column", FormatCSV, "team_name,user_id,username,user_id\nt,u1,A,u1\n",
+			[]Problem{{Line: 1, Field: "user_id", Message: "duplicate column"}}},
+		{"csv missing required column", FormatCSV, "team_name,user_id\nt,u1\n",
+			[]Problem{{Line: 1, Field: "username", Message: "column is required"}}},
+		{"csv bad is_active", FormatCSV, "team_name,user_id,username,is_active\nt,u1,A,yes\n",
+			[]Problem{{Line: 2, Field: "is_active", Message: "must be true or false"}}},
+		{"csv bad skills", FormatCSV, "team_name,user_id,username,skills\nt,u1,A,go\n",
+			[]Problem{{Line: 2, Field: "skills", Message: `must look like tag:level, got "go"`}}},
+		{"csv bad skill level", FormatCSV, "team_name,user_id,username,skills\nt,u1,A,go:x\n",
+			[]Problem{{Line: 2, Field: "skills", Message: `must look like tag:level, got "go:x"`}}},
+		{"csv broken quotes", FormatCSV, "team_name,user_id,username\nt,u1,A\nt,\"u2,B\n",
+			[]Problem{{Line: 3, Message: `extraneous or missing " in quoted-field`}}},
+
+		{"empty fields", FormatCSV, "team_name,user_id,username\n,,\n", []Problem{
+			{Line: 2, Field: "team_name", Message: "must not be empty"},
+			{Line: 2, Field: "user_id", Message: "must not be empty"},
+			{Line: 2, Field: "username", Message: "must not be empty"},
+		}},
+		{"unknown level", FormatCSV, "team_name,user_id,username,level\nt,u1,A,guru\n",
+			[]Problem{{Line: 2, Field: "level", Message: "must be one of: junior, middle, senior, lead"}}},
+		{"duplicate user across teams", FormatCSV, "team_name,user_id,username\na,u1,A\nb,u2,B\nb,u1,A\n",
+			[]Problem{{Line: 4, Field: "user_id", Message: `"u1" is already listed at line 2`}}},
+		{"empty skill tag", FormatCSV, "team_name,user_id,username,skills\nt,u1,A,:3\n",
+			[]Problem{{Line: 2, Field: "skills", Message: "tag must not be empty"}}},
+		{"duplicate skill tag", FormatCSV, "team_name,user_id,username,skills\nt,u1,A,Go:3;go:2\n",
+			[]Problem{{Line: 2, Field: "skills", Message: `duplicate tag "go"`}}},
+		{"skill level out of range", FormatCSV, "team_name,user_id,username,skills\nt,u1,A,go:6\n",
+			[]Problem{{Line: 2, Field: "skills", Message: `level of "go" must be between 1 and 5`}}},
+
+		{"yaml empty file", FormatYAML, "", []Problem{{Message: "file is empty"}}},
+		{"yaml no teams", FormatYAML, "teams: []\n", []Problem{{Field: "teams", Message: "must contain at least one team"}}},
+		{"yaml team without members", FormatYAML, "teams:\n  - team_name: t\n",
+			[]Problem{{Line: 2, Field: "members", Message: "must contain at least one member"}}},
+		{"yaml unknown member field", FormatYAML, "teams:\n  - team_name: t\n    members:\n      - user_id: u1\n        username: A\n        emali: a@x\n",
+			[]Problem{{Line: 6, Field: "emali", Message: "unknown field"}}},
+		{"yaml unknown team field", FormatYAML, "teams:\n  - team_name: t\n    lead: u1\n    members:\n      - {user_id: u1, username: A}\n",
+			[]Problem{{Line: 3, Field: "lead", Message: "unknown field"}}},
+		{"yaml member is not a mapping", FormatYAML, "teams:\n  - team_name: t\n    members:\n      - u1\n",
+			[]Problem{{Line: 4, Message: "member must be a mapping"}}},
+		{"yaml validation has member lines", FormatYAML, "teams:\n  - team_name: a\n    members:\n      - {user_id: u1, username: A}\n  - team_name: b\n    members:\n      - {user_id: u1, username: A, level: guru}\n",
+			[]Problem{
+				{Line: 7, Field: "user_id", Message: `"u1" is already listed at line 4`},
+				{Line: 7, Field: "level", Message: "must be one of: junior, middle, senior, lead"},
+			}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teams, problems := Parse(strings.NewReader(tt.in), tt.format)
+			if teams != nil {
+				t.Errorf("teams returned despite problems: %+v", teams)
+			}
+			if !reflect.DeepEqual(problems, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", problems, tt.want)
+			}
+		})
+	}
+}
+
+func TestProblemString(t *testing.T) {
+	tests := []struct {
+		p    Problem
+		want string
+	}{
+		{Problem{Message: "file is empty"}, "file is empty"},
+		{Problem{Line: 3, Field: "level", Message: "unknown"}, "line 3: level unknown"},
+		{Problem{Field: "teams", Message: "must not be empty"}, "teams must not be empty"},
+	}
+	for _, tt := range tests {
+		if got := tt.p.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestFormatFromContentType(t *testing.T) {
+	tests := []struct {
+		ct   string
+		want string
+		ok   bool
+	}{
+		{"text/csv", FormatCSV, true},
+		{"text/csv; charset=utf-8", FormatCSV, true},
+		{"application/yaml", FormatYAML, true},
+		{"Application/X-YAML", FormatYAML, true},
+		{"application/json", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := FormatFromContentType(tt.ct)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("FormatFromContentType(%q) = %q, %v; want %q, %v", tt.ct, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package bulkimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+// Колонки CSV. Первая строка — заголовок; порядок колонок произвольный.
+// skills — «tag:level» через «;», пустое значение оставляет навыки без изменений.
+// is_active по умолчанию true.
+var (
+	csvColumns  = []string{"team_name", "user_id", "username", "is_active", "level", "skills"}
+	csvRequired = []string{"team_name", "user_id", "username"}
+)
+
+func parseCSV(r io.Reader) ([]record, []Problem) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, []Problem{{Message: "file is empty"}}
+	}
+	if err != nil {
+		return nil, []Problem{csvProblem(err)}
+	}
+
+	col := map[string]int{}
+	var problems []Problem
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !contains(csvColumns, name) {
+			problems = append(problems, Problem{Line: 1, Field: name, Message: "unknown column"})
+			continue
+		}
+		if _, dup := col[name]; dup {
+			problems = append(problems, Problem{Line: 1, Field: name, Message: "duplicate column"})
+		}
+		col[name] = i
+	}
+	for _, name := range csvRequired {
+		if _, ok := col[name]; !ok {
+			problems = append(problems, Problem{Line: 1, Field: name, Message: "column is required"})
+		}
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+
+	var recs []record
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// после ошибки кавычек границы строк уже не определить
+			problems = append(problems, csvProblem(err))
+			break
+		}
+		line, _ := cr.FieldPos(0)
+		get := func(name string) string {
+			if i, ok := col[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		rec := record{line: line, team: get("team_name"), member: repo.TeamMember{
+			UserID:   get("user_id"),
+			Username: get("username"),
+			IsActive: true,
+			Level:    strings.ToLower(get("level")),
+		}}
+		if v := get("is_active"); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				problems = append(problems, Problem{Line: line, Field: "is_active", Message: "must be true or false"})
+			}
+			rec.member.IsActive = b
+		}
+		if v := get("skills"); v != "" {
+			skills, err := parseSkills(v)
+			if err != nil {
+				problems = append(problems, Problem{Line: line, Field: "skills", Message: err.Error()})
+			}
+			rec.member.Skills = skills
+		}
+		recs = append(recs, rec)
+	}
+	if len(recs) == 0 && len(problems) == 0 {
+		problems = append(problems, Problem{Message: "file has no rows"})
+	}
+	return recs, problems
+}
+
+// parseSkills разбирает «go:3;sql:2».
+func parseSkills(v string) ([]repo.Skill, error) {
+	res := []repo.Skill{}
+	for _, part := range strings.Split(v, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		tag, lvl, ok := strings.Cut(part, ":")
+		level, err := strconv.Atoi(strings.TrimSpace(lvl))
+		if !ok || err != nil {
+			return nil, fmt.Errorf("must look like tag:level, got %q", part)
+		}
+		res = append(res, repo.Skill{Tag: strings.TrimSpace(tag), Level: level})
+	}
+	return res, nil
+}
+
+func csvProblem(err error) Problem {
+	var pe *csv.ParseError
+	if errors.As(err, &pe) {
+		return Problem{Line: pe.Line, Message: pe.Err.Error()}
+	}
+	return Problem{Message: err.Error()}
+}
+
+func contains(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
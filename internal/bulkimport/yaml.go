@@ -0,0 +1,152 @@
+package bulkimport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"pr-reviewer-service/internal/storage/repo"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Формат YAML:
+//
+//	teams:
+//	  - team_name: backend
+//	    members:
+//	      - user_id: u1
+//	        username: Alice
+//	        is_active: true      # по умолчанию true
+//	        level: senior
+//	        skills:              # не задано — навыки без изменений
+//	          - {tag: go, level: 3}
+type yamlFile struct {
+	Teams []yamlTeam `yaml:"teams"`
+}
+
+type yamlTeam struct {
+	line    int
+	unknown []Problem
+
+	TeamName string       `yaml:"team_name"`
+	Members  []yamlMember `yaml:"members"`
+}
+
+type yamlMember struct {
+	line    int
+	unknown []Problem
+
+	UserID   string       `yaml:"user_id"`
+	Username string       `yaml:"username"`
+	IsActive *bool        `yaml:"is_active"`
+	Level    string       `yaml:"level"`
+	Skills   *[]yamlSkill `yaml:"skills"`
+}
+
+type yamlSkill struct {
+	Tag   string `yaml:"tag"`
+	Level int    `yaml:"level"`
+}
+
+func (t *yamlTeam) UnmarshalYAML(n *yaml.Node) error {
+	type plain yamlTeam
+	if err := expectMapping(n, "team"); err != nil {
+		return err
+	}
+	t.line, t.unknown = n.Line, unknownKeys(n, "team_name", "members")
+	return n.Decode((*plain)(t))
+}
+
+func (m *yamlMember) UnmarshalYAML(n *yaml.Node) error {
+	type plain yamlMember
+	if err := expectMapping(n, "member"); err != nil {
+		return err
+	}
+	m.line, m.unknown = n.Line, unknownKeys(n, "user_id", "username", "is_active", "level", "skills")
+	return n.Decode((*plain)(m))
+}
+
+// expectMapping заменяет сообщение декодера о внутреннем типе понятным пользователю.
+func expectMapping(n *yaml.Node, what string) error {
+	if n.Kind == yaml.MappingNode {
+		return nil
+	}
+	return &yaml.TypeError{Errors: []string{fmt.Sprintf("line %d: %s must be a mapping", n.Line, what)}}
+}
+
+// unknownKeys возвращает проблемы для ключей, которых нет в allowed: опечатка
+// в имени поля иначе молча потеряла бы значение.
+func unknownKeys(n *yaml.Node, allowed ...string) []Problem {
+	var res []Problem
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k := n.Content[i]
+		if !contains(allowed, k.Value) {
+			res = append(res, Problem{Line: k.Line, Field: k.Value, Message: "unknown field"})
+		}
+	}
+	return res
+}
+
+func parseYAML(r io.Reader) ([]record, []Problem) {
+	var f yamlFile
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, []Problem{{Message: "file is empty"}}
+		}
+		return nil, yamlProblems(err)
+	}
+	if len(f.Teams) == 0 {
+		return nil, []Problem{{Field: "teams", Message: "must contain at least one team"}}
+	}
+
+	var recs []record
+	var problems []Problem
+	for _, t := range f.Teams {
+		problems = append(problems, t.unknown...)
+		if len(t.Members) == 0 {
+			problems = append(problems, Problem{Line: t.line, Field: "members", Message: "must contain at least one member"})
+		}
+		for _, m := range t.Members {
+			problems = append(problems, m.unknown...)
+			member := repo.TeamMember{
+				UserID:   strings.TrimSpace(m.UserID),
+				Username: strings.TrimSpace(m.Username),
+				IsActive: m.IsActive == nil || *m.IsActive,
+				Level:    strings.ToLower(strings.TrimSpace(m.Level)),
+			}
+			if m.Skills != nil {
+				member.Skills = make([]repo.Skill, 0, len(*m.Skills))
+				for _, sk := range *m.Skills {
+					member.Skills = append(member.Skills, repo.Skill{Tag: strings.TrimSpace(sk.Tag), Level: sk.Level})
+				}
+			}
+			recs = append(recs, record{line: m.line, team: strings.TrimSpace(t.TeamName), member: member})
+		}
+	}
+	return recs, problems
+}
+
+// yamlProblems раскладывает ошибку декодера (в ней может быть несколько строк «line N: ...»).
+func yamlProblems(err error) []Problem {
+	var te *yaml.TypeError
+	if !errors.As(err, &te) {
+		return []Problem{{Message: err.Error()}}
+	}
+	res := make([]Problem, 0, len(te.Errors))
+	for _, e := range te.Errors {
+		p := Problem{Message: e}
+		var line int
+		if n, _ := fmt.Sscanf(e, "line %d:", &line); n == 1 {
+			p.Line = line
+			if _, rest, ok := strings.Cut(e, ": "); ok {
+				p.Message = rest
+			}
+		}
+		res = append(res, p)
+	}
+	return res
+}
@@ -12,6 +12,13 @@ type Config struct {
 	Env         string `yaml:"env" env-default:"local"`
 	StoragePath string `yaml:"storage_path" env-required:"true"`
 	HTTPServer  `yaml:"http_server"`
+	GRPCServer  `yaml:"grpc_server"`
+	Assignment  `yaml:"assignment"`
+	SLA         `yaml:"sla"`
+	Idempotency `yaml:"idempotency"`
+	GraphQL     `yaml:"graphql"`
+	SCIM        `yaml:"scim"`
+	Admin       `yaml:"admin"`
 }
 
 type HTTPServer struct {
@@ -20,6 +27,41 @@ type HTTPServer struct {
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
 }
 
+type GRPCServer struct {
+	Enabled bool   `yaml:"enabled" env:"GRPC_ENABLED" env-default:"true"`
+	Address string `yaml:"address" env:"GRPC_ADDRESS" env-default:"localhost:9090"`
+}
+
+type Assignment struct {
+	Strategy string `yaml:"strategy" env:"ASSIGNMENT_STRATEGY" env-default:"random"`
+	Mode     string `yaml:"mode" env:"ASSIGNMENT_MODE" env-default:"random"`
+	Seed     int64  `yaml:"seed" env:"ASSIGNMENT_SEED" env-default:"0"`
+}
+
+type SLA struct {
+	Enabled  bool          `yaml:"enabled" env:"SLA_ENABLED" env-default:"true"`
+	Interval time.Duration `yaml:"interval" env:"SLA_INTERVAL" env-default:"1m"`
+}
+
+type Idempotency struct {
+	TTL time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" env-default:"24h"`
+}
+
+type GraphQL struct {
+	MaxComplexity int `yaml:"max_complexity" env:"GRAPHQL_MAX_COMPLEXITY" env-default:"5000"`
+}
+
+type SCIM struct {
+	// Token — bearer-токен для identity provider; пустой выключает /scim/v2.
+	Token       string `yaml:"token" env:"SCIM_TOKEN"`
+	DefaultTeam string `yaml:"default_team" env:"SCIM_DEFAULT_TEAM" env-default:"unassigned"`
+}
+
+type Admin struct {
+	// Token — bearer-токен для /admin/export и /admin/import; пустой их выключает.
+	Token string `yaml:"token" env:"ADMIN_TOKEN"`
+}
+
 func MustLoadConfig() *Config {
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
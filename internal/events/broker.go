@@ -0,0 +1,65 @@
+package events
+
+import (
+	"sync"
+
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+// subscriberBuffer — сколько событий может отстать подписчик, прежде чем его отключат.
+const subscriberBuffer = 64
+
+type subscriber struct {
+	ch     chan repo.Event
+	filter repo.EventFilter
+}
+
+// Broker раздаёт события подписчикам внутри процесса.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[*subscriber]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{subs: map[*subscriber]struct{}{}}
+}
+
+// Subscribe возвращает канал событий, подходящих под фильтр, и функцию отписки.
+// Канал закрывается при отписке или если подписчик не успевает читать.
+func (b *Broker) Subscribe(f repo.EventFilter) (<-chan repo.Event, func()) {
+	sub := &subscriber{ch: make(chan repo.Event, subscriberBuffer), filter: f}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub.ch, func() { b.remove(sub) }
+}
+
+func (b *Broker) Publish(e repo.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		if !sub.filter.Match(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			// медленный клиент переподключится с Last-Event-ID и дочитает из журнала
+			delete(b.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+func (b *Broker) remove(sub *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
@@ -0,0 +1,125 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"pr-reviewer-service/internal/lib/logger"
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	reconnectDelay = 2 * time.Second
+	catchUpBatch   = 500
+	// seenWindow — сколько последних id помнить, чтобы не разослать событие дважды.
+	seenWindow = 10000
+)
+
+// Listener получает id новых событий через Postgres LISTEN/NOTIFY и рассылает
+// их брокеру, поэтому события любой реплики видны подписчикам всех реплик.
+// После переподключения пропущенные события дочитываются из журнала.
+type Listener struct {
+	pool   *pgxpool.Pool
+	store  *repo.Store
+	broker *Broker
+	log    *slog.Logger
+	lastID int64
+	seen   map[int64]struct{}
+}
+
+func NewListener(pool *pgxpool.Pool, store *repo.Store, broker *Broker, log *slog.Logger) *Listener {
+	return &Listener{pool: pool, store: store, broker: broker, log: log, seen: map[int64]struct{}{}}
+}
+
+func (l *Listener) Run(ctx context.Context) {
+	id, err := l.store.LastEventID(ctx)
+	if err != nil {
+		l.log.Error("events: failed to read last event id", logger.Err(err))
+	}
+	l.lastID = id
+
+	for {
+		if err := l.listen(ctx); err != nil && ctx.Err() == nil {
+			l.log.Error("events: listener stopped, reconnecting", logger.Err(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (l *Listener) listen(ctx context.Context) error {
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+repo.EventsChannel); err != nil {
+		return err
+	}
+	if err := l.catchUp(ctx); err != nil {
+		return err
+	}
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		id, err := strconv.ParseInt(n.Payload, 10, 64)
+		if err != nil {
+			continue
+		}
+		// транзакции коммитятся не в порядке id, поэтому событие читается по
+		// id из уведомления, а не «всё после последнего»
+		e, err := l.store.GetEvent(ctx, id)
+		if err == repo.ErrNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+		l.publish(e)
+	}
+}
+
+func (l *Listener) publish(e repo.Event) {
+	if _, ok := l.seen[e.ID]; ok {
+		return
+	}
+	l.broker.Publish(e)
+	l.seen[e.ID] = struct{}{}
+	if e.ID > l.lastID {
+		l.lastID = e.ID
+	}
+	if len(l.seen) > 2*seenWindow {
+		for id := range l.seen {
+			if id < l.lastID-seenWindow {
+				delete(l.seen, id)
+			}
+		}
+	}
+}
+
+func (l *Listener) catchUp(ctx context.Context) error {
+	cursor := l.lastID
+	for {
+		list, err := l.store.EventsSince(ctx, cursor, repo.EventFilter{}, catchUpBatch)
+		if err != nil {
+			return err
+		}
+		for _, e := range list {
+			l.publish(e)
+			cursor = e.ID
+		}
+		if len(list) < catchUpBatch {
+			return nil
+		}
+	}
+}
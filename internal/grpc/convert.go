@@ -0,0 +1,242 @@
+package grpcapi
+
+import (
+	"encoding/json"
+	"time"
+
+	pb "pr-reviewer-service/internal/grpc/reviewerpb"
+	"pr-reviewer-service/internal/storage/repo"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Преобразования между типами хранилища и сообщениями protobuf.
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func timeFrom(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
+
+func ints(v []int32) []int {
+	if len(v) == 0 {
+		return nil
+	}
+	res := make([]int, len(v))
+	for i, x := range v {
+		res[i] = int(x)
+	}
+	return res
+}
+
+func int32s(v []int) []int32 {
+	res := make([]int32, len(v))
+	for i, x := range v {
+		res[i] = int32(x)
+	}
+	return res
+}
+
+func teamToPB(t repo.Team) *pb.Team {
+	res := &pb.Team{TeamName: t.TeamName, Version: t.Version}
+	for _, m := range t.Members {
+		pm := &pb.TeamMember{UserId: m.UserID, Username: m.Username, IsActive: m.IsActive, Level: m.Level}
+		for _, sk := range m.Skills {
+			pm.Skills = append(pm.Skills, &pb.Skill{Tag: sk.Tag, Level: int32(sk.Level)})
+		}
+		res.Members = append(res.Members, pm)
+	}
+	return res
+}
+
+func teamFromPB(t *pb.Team) repo.Team {
+	res := repo.Team{TeamName: t.GetTeamName(), Members: []repo.TeamMember{}}
+	for _, m := range t.GetMembers() {
+		rm := repo.TeamMember{UserID: m.GetUserId(), Username: m.GetUsername(), IsActive: m.GetIsActive(), Level: m.GetLevel()}
+		for _, sk := range m.GetSkills() {
+			rm.Skills = append(rm.Skills, repo.Skill{Tag: sk.GetTag(), Level: int(sk.GetLevel())})
+		}
+		res.Members = append(res.Members, rm)
+	}
+	return res
+}
+
+func userToPB(u repo.User) *pb.User {
+	return &pb.User{
+		UserId:         u.UserID,
+		Username:       u.Username,
+		TeamName:       u.TeamName,
+		IsActive:       u.IsActive,
+		Level:          u.Level,
+		MaxOpenReviews: int32Ptr(u.MaxOpenReviews),
+		AwayUntil:      timestamp(u.AwayUntil),
+	}
+}
+
+func prToPB(pr repo.PullRequest) *pb.PullRequest {
+	return &pb.PullRequest{
+		PullRequestId:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorID,
+		Status:            pr.Status,
+		AssignedReviewers: pr.AssignedReviewers,
+		RequiredTags:      pr.RequiredTags,
+		CreatedAt:         timestamp(&pr.CreatedAt),
+		MergedAt:          timestamp(pr.MergedAt),
+		Version:           pr.Version,
+	}
+}
+
+func prShortToPB(pr repo.PullRequestShort) *pb.PullRequestShort {
+	return &pb.PullRequestShort{
+		PullRequestId:    pr.PullRequestID,
+		PullRequestName:  pr.PullRequestName,
+		AuthorId:         pr.AuthorID,
+		Status:           pr.Status,
+		AssignedAt:       timestamp(pr.AssignedAt),
+		ReviewAgeSeconds: pr.ReviewAgeSeconds,
+		ReviewAge:        pr.ReviewAge,
+	}
+}
+
+func ruleToPB(r repo.SeniorityRule) *pb.SeniorityRule {
+	return &pb.SeniorityRule{TeamName: r.TeamName, MinLevel: r.MinLevel, MinCount: int32(r.MinCount)}
+}
+
+func slaToPB(p repo.SLAPolicy) *pb.SLAPolicy {
+	return &pb.SLAPolicy{
+		TeamName:           p.TeamName,
+		ReminderAfterHours: int32Ptr(p.ReminderAfterHours),
+		EscalateAfterHours: int32Ptr(p.EscalateAfterHours),
+	}
+}
+
+func scheduleToPB(w repo.WorkSchedule) *pb.WorkSchedule {
+	return &pb.WorkSchedule{TimeZone: w.TimeZone, WorkStart: w.WorkStart, WorkEnd: w.WorkEnd, WorkDays: int32s(w.WorkDays)}
+}
+
+func scheduleFromPB(w *pb.WorkSchedule) repo.WorkSchedule {
+	return repo.WorkSchedule{TimeZone: w.TimeZone, WorkStart: w.WorkStart, WorkEnd: w.WorkEnd, WorkDays: ints(w.GetWorkDays())}
+}
+
+func policyToPB(p repo.TeamPolicy) *pb.TeamPolicy {
+	return &pb.TeamPolicy{
+		TeamName:              p.TeamName,
+		ReviewerCount:         int32Ptr(&p.ReviewerCount),
+		Strategy:              p.Strategy,
+		FallbackTeams:         p.FallbackTeams,
+		MinLevel:              p.MinLevel,
+		MinCount:              int32(p.MinCount),
+		DefaultMaxOpenReviews: int32Ptr(p.DefaultMaxOpenReviews),
+		ReminderAfterHours:    int32Ptr(p.ReminderAfterHours),
+		EscalateAfterHours:    int32Ptr(p.EscalateAfterHours),
+		AllowMergedChanges:    p.AllowMergedChanges,
+		Version:               p.Version,
+		UpdatedAt:             timestamp(p.UpdatedAt),
+	}
+}
+
+func assignmentToPB(a repo.Assignment) *pb.Assignment {
+	return &pb.Assignment{
+		AssignmentId:       a.ID,
+		PullRequestId:      a.PullRequestID,
+		Kind:               a.Kind,
+		Strategy:           a.Strategy,
+		Algorithm:          a.Algorithm,
+		Seed:               a.Seed,
+		ReplacedReviewerId: a.ReplacedReviewerID,
+		Reason:             a.Reason,
+		Reviewers:          a.Reviewers,
+		CreatedAt:          timestamp(&a.CreatedAt),
+	}
+}
+
+func explanationToPB(e repo.Explanation) *pb.Explanation {
+	res := &pb.Explanation{
+		Assignment:    assignmentToPB(e.Assignment),
+		RequiredTags:  e.Tags,
+		SeniorityRule: ruleToPB(e.Rule),
+	}
+	for _, c := range e.Candidates {
+		res.Candidates = append(res.Candidates, &pb.CandidateExplanation{
+			UserId:         c.UserID,
+			Level:          c.Level,
+			Excluded:       c.Excluded,
+			TagOverlap:     int32(c.TagOverlap),
+			SkillLevel:     int32(c.SkillLevel),
+			MeetsSeniority: c.Qualified,
+			LastPairedAt:   timestamp(c.LastPaired),
+			Rank:           c.Rank,
+			Position:       int32(c.Position),
+			Selected:       c.Selected,
+			OpenReviews:    int32(c.OpenReviews),
+			Fallback:       c.Fallback,
+		})
+	}
+	if m := e.Manual; m != nil {
+		res.Manual = &pb.ManualChange{
+			Added:               m.Added,
+			Removed:             m.Removed,
+			Override:            m.Override,
+			SeniorityOverridden: m.SeniorityOverridden,
+		}
+		if len(m.Overridden) > 0 {
+			res.Manual.Overridden = map[string]*pb.StringList{}
+			for id, reasons := range m.Overridden {
+				res.Manual.Overridden[id] = &pb.StringList{Values: reasons}
+			}
+		}
+	}
+	return res
+}
+
+// eventToPB переводит полезную нагрузку события через JSON: в ней бывают
+// срезы строк и время, которые structpb напрямую не принимает.
+func eventToPB(e repo.Event) (*pb.Event, error) {
+	res := &pb.Event{
+		Id:            e.ID,
+		Type:          e.Type,
+		TeamName:      e.TeamName,
+		UserId:        e.UserID,
+		PullRequestId: e.PullRequestID,
+		RelatedUsers:  e.RelatedUsers,
+		CreatedAt:     timestamp(&e.CreatedAt),
+	}
+	if len(e.Payload) > 0 {
+		raw, err := json.Marshal(e.Payload)
+		if err != nil {
+			return nil, err
+		}
+		res.Payload = &structpb.Struct{}
+		if err := res.Payload.UnmarshalJSON(raw); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,83 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"pr-reviewer-service/internal/lib/logger"
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain — домен в ErrorInfo; Reason в нём — тот же код, что в HTTP-ответе.
+const errorDomain = "pr-reviewer-service"
+
+// codeByRepo сопоставляет коды ошибок хранилища кодам gRPC, как statusByCode в HTTP.
+var codeByRepo = map[string]codes.Code{
+	repo.CodeNotFound:    codes.NotFound,
+	repo.CodePRExists:    codes.AlreadyExists,
+	repo.CodeUserExists:  codes.AlreadyExists,
+	repo.CodePRMerged:    codes.FailedPrecondition,
+	repo.CodeNotAssigned: codes.FailedPrecondition,
+	repo.CodeNoCandidate: codes.FailedPrecondition,
+	repo.CodeSeniority:   codes.FailedPrecondition,
+	repo.CodeIneligible:  codes.FailedPrecondition,
+	repo.CodeTooMany:     codes.FailedPrecondition,
+	repo.CodeNotEmpty:    codes.FailedPrecondition,
+
+	repo.CodeInvalidPolicy:       codes.InvalidArgument,
+	repo.CodeInvalidBackup:       codes.InvalidArgument,
+	repo.CodeIdempotencyMismatch: codes.InvalidArgument,
+
+	// устаревшая expected_version — конфликт параллельных изменений, клиент перечитывает и повторяет
+	repo.CodeVersion:               codes.Aborted,
+	repo.CodeIdempotencyInProgress: codes.Aborted,
+}
+
+func invalidArgument(msg string) error {
+	return status.Error(codes.InvalidArgument, msg)
+}
+
+// toStatus превращает ошибку обработчика в статус gRPC. Доменные ошибки отдаются
+// со своим текстом и кодом в ErrorInfo, остальные — без подробностей, с записью в лог.
+func (s *server) toStatus(method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var e *repo.Error
+	if errors.As(err, &e) {
+		code, ok := codeByRepo[e.Code]
+		if !ok {
+			code = codes.InvalidArgument
+		}
+		st := status.New(code, err.Error())
+		if withInfo, derr := st.WithDetails(&errdetails.ErrorInfo{Reason: e.Code, Domain: errorDomain}); derr == nil {
+			st = withInfo
+		}
+		return st.Err()
+	}
+
+	code, msg := codes.Internal, "internal error"
+	var connErr *pgconn.ConnectError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request canceled")
+	case errors.Is(err, context.DeadlineExceeded), pgconn.Timeout(err):
+		code, msg = codes.DeadlineExceeded, "request timed out"
+	case errors.As(err, &connErr):
+		code, msg = codes.Unavailable, "storage unavailable"
+	}
+
+	s.log.Error("grpc request failed",
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		logger.Err(err),
+	)
+	return status.Error(code, msg)
+}
@@ -0,0 +1,17 @@
+package grpcapi
+
+import (
+	"testing"
+
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+// TestCodeByRepoCoversAllCodes: код без записи в codeByRepo дошёл бы до
+// клиентов gRPC как InvalidArgument, даже если это конфликт или 404.
+func TestCodeByRepoCoversAllCodes(t *testing.T) {
+	for _, code := range repo.Codes() {
+		if _, ok := codeByRepo[code]; !ok {
+			t.Errorf("%s has no gRPC code", code)
+		}
+	}
+}
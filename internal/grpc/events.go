@@ -0,0 +1,72 @@
+package grpcapi
+
+import (
+	pb "pr-reviewer-service/internal/grpc/reviewerpb"
+	"pr-reviewer-service/internal/storage/repo"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const replayBatch = 500
+
+// StreamEvents повторяет /events/stream: подписка, дочитывание журнала после
+// last_event_id и дальше живые события без дублей.
+func (s eventServer) StreamEvents(req *pb.StreamEventsRequest, stream pb.EventService_StreamEventsServer) error {
+	ctx := stream.Context()
+	f := repo.EventFilter{TeamName: req.GetTeamName(), UserID: req.GetUserId()}
+	if req.LastEventId != nil && req.GetLastEventId() < 0 {
+		return invalidArgument("last_event_id must be non-negative")
+	}
+
+	// подписываемся до чтения журнала, чтобы не потерять события между ними
+	ch, unsubscribe := s.events.Subscribe(f)
+	defer unsubscribe()
+
+	sent := map[int64]bool{}
+	if req.LastEventId != nil {
+		lastID := req.GetLastEventId()
+		for {
+			list, err := s.store.EventsSince(ctx, lastID, f, replayBatch)
+			if err != nil {
+				return err
+			}
+			for _, e := range list {
+				if err := s.send(stream, e); err != nil {
+					return err
+				}
+				sent[e.ID] = true
+				lastID = e.ID
+			}
+			if len(list) < replayBatch {
+				break
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-ch:
+			if !ok {
+				// брокер отключил отставшего клиента: тот переподключится с last_event_id
+				return status.Error(codes.ResourceExhausted, "client is too slow, reconnect with last_event_id")
+			}
+			if sent[e.ID] {
+				continue
+			}
+			if err := s.send(stream, e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s eventServer) send(stream pb.EventService_StreamEventsServer, e repo.Event) error {
+	msg, err := eventToPB(e)
+	if err != nil {
+		return err
+	}
+	return stream.Send(msg)
+}
@@ -0,0 +1,4 @@
+// Package grpcapi — gRPC-интерфейс сервиса поверх того же repo.Store, что и HTTP.
+package grpcapi
+
+//go:generate protoc -I ../../api/proto --go_out=reviewerpb --go_opt=module=pr-reviewer-service/internal/grpc/reviewerpb --go-grpc_out=reviewerpb --go-grpc_opt=module=pr-reviewer-service/internal/grpc/reviewerpb reviewer/v1/reviewer.proto
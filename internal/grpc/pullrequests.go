@@ -0,0 +1,113 @@
+package grpcapi
+
+import (
+	"context"
+
+	pb "pr-reviewer-service/internal/grpc/reviewerpb"
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+func (s pullRequestServer) CreatePullRequest(ctx context.Context, req *pb.CreatePullRequestRequest) (*pb.CreatePullRequestResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetPullRequestName() == "" || req.GetAuthorId() == "" {
+		return nil, invalidArgument("pull_request_id, pull_request_name and author_id required")
+	}
+	pr, expl, err := s.store.CreatePR(ctx, req.GetPullRequestId(), req.GetPullRequestName(), req.GetAuthorId(), req.GetRequiredTags())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.CreatePullRequestResponse{Pr: prToPB(pr)}
+	if req.GetExplain() {
+		res.Explanation = explanationToPB(expl)
+	}
+	return res, nil
+}
+
+func (s pullRequestServer) GetPullRequest(ctx context.Context, req *pb.GetPullRequestRequest) (*pb.PullRequest, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id required")
+	}
+	pr, err := s.store.GetPR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, err
+	}
+	return prToPB(pr), nil
+}
+
+func (s pullRequestServer) MergePullRequest(ctx context.Context, req *pb.MergePullRequestRequest) (*pb.PullRequest, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id required")
+	}
+	pr, err := s.store.MergePR(ctx, req.GetPullRequestId(), req.GetExpectedVersion())
+	if err != nil {
+		return nil, err
+	}
+	return prToPB(pr), nil
+}
+
+func (s pullRequestServer) ReassignReviewer(ctx context.Context, req *pb.ReassignReviewerRequest) (*pb.ReassignResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetOldUserId() == "" {
+		return nil, invalidArgument("pull_request_id and old_user_id required")
+	}
+	newID, err := s.store.ReassignReviewer(ctx, req.GetPullRequestId(), req.GetOldUserId(), req.GetExpectedVersion())
+	if err != nil {
+		return nil, err
+	}
+	pr, err := s.store.GetPR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReassignResponse{Pr: prToPB(pr), ReplacedBy: newID}, nil
+}
+
+func (s pullRequestServer) ChangeReviewers(ctx context.Context, req *pb.ChangeReviewersRequest) (*pb.PullRequest, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id required")
+	}
+	ch := repo.ReviewerChange{
+		PullRequestID: req.GetPullRequestId(),
+		Add:           req.GetAdd(),
+		Remove:        req.GetRemove(),
+		Override:      req.GetOverride(),
+		Reason:        req.GetReason(),
+	}
+	for _, p := range req.GetReplace() {
+		ch.Remove = append(ch.Remove, p.GetOldUserId())
+		ch.Add = append(ch.Add, p.GetNewUserId())
+	}
+	if len(ch.Add) == 0 && len(ch.Remove) == 0 {
+		return nil, invalidArgument("nothing to change: add, remove or replace required")
+	}
+
+	pr, err := s.store.ChangeReviewers(ctx, ch, req.GetExpectedVersion())
+	if err != nil {
+		return nil, err
+	}
+	return prToPB(pr), nil
+}
+
+func (s pullRequestServer) ListAssignments(ctx context.Context, req *pb.GetPullRequestRequest) (*pb.ListAssignmentsResponse, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id required")
+	}
+	list, err := s.store.GetAssignments(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.ListAssignmentsResponse{PullRequestId: req.GetPullRequestId()}
+	for _, a := range list {
+		res.Assignments = append(res.Assignments, assignmentToPB(a))
+	}
+	return res, nil
+}
+
+func (s pullRequestServer) ExplainPullRequest(ctx context.Context, req *pb.GetPullRequestRequest) (*pb.Explanation, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id required")
+	}
+	expl, err := s.store.ExplainPR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, err
+	}
+	return explanationToPB(expl), nil
+}
@@ -0,0 +1,3802 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: reviewer/v1/reviewer.proto
+
+// gRPC-интерфейс сервиса назначения ревьюверов. Повторяет HTTP-маршруты
+// /team, /users, /pullRequest и /stats; поля и ошибки совпадают с openapi.json.
+//
+// Код генерируется командой `go generate ./internal/grpc/...`.
+
+package reviewerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Skill struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Level         int32                  `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Skill) Reset() {
+	*x = Skill{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Skill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Skill) ProtoMessage() {}
+
+func (x *Skill) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Skill.ProtoReflect.Descriptor instead.
+func (*Skill) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Skill) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Skill) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type TeamMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsActive      bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Level         string                 `protobuf:"bytes,4,opt,name=level,proto3" json:"level,omitempty"`
+	Skills        []*Skill               `protobuf:"bytes,5,rep,name=skills,proto3" json:"skills,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamMember) Reset() {
+	*x = TeamMember{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamMember) ProtoMessage() {}
+
+func (x *TeamMember) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamMember.ProtoReflect.Descriptor instead.
+func (*TeamMember) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TeamMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TeamMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *TeamMember) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *TeamMember) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *TeamMember) GetSkills() []*Skill {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+type Team struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members       []*TeamMember          `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Team) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Team) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Team) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type TeamNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamNameRequest) Reset() {
+	*x = TeamNameRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamNameRequest) ProtoMessage() {}
+
+func (x *TeamNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamNameRequest.ProtoReflect.Descriptor instead.
+func (*TeamNameRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TeamNameRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type AddTeamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Team  *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	// expected_version — аналог If-Match; 0 — без проверки.
+	ExpectedVersion int64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AddTeamRequest) Reset() {
+	*x = AddTeamRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamRequest) ProtoMessage() {}
+
+func (x *AddTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamRequest.ProtoReflect.Descriptor instead.
+func (*AddTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AddTeamRequest) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+func (x *AddTeamRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type GetTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamRequest) Reset() {
+	*x = GetTeamRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamRequest) ProtoMessage() {}
+
+func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type TeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamResponse) Reset() {
+	*x = TeamResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamResponse) ProtoMessage() {}
+
+func (x *TeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamResponse.ProtoReflect.Descriptor instead.
+func (*TeamResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type DeactivateTeamRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TeamName        string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// dry_run — посчитать результат и откатить изменения.
+	DryRun        bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateTeamRequest) Reset() {
+	*x = DeactivateTeamRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateTeamRequest) ProtoMessage() {}
+
+func (x *DeactivateTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateTeamRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeactivateTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *DeactivateTeamRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *DeactivateTeamRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type DeactivateTeamResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TeamName           string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	DeactivatedUsers   []string               `protobuf:"bytes,2,rep,name=deactivated_users,json=deactivatedUsers,proto3" json:"deactivated_users,omitempty"`
+	ReassignedPrsCount int32                  `protobuf:"varint,3,opt,name=reassigned_prs_count,json=reassignedPrsCount,proto3" json:"reassigned_prs_count,omitempty"`
+	ReassignFailures   map[string]string      `protobuf:"bytes,4,rep,name=reassign_failures,json=reassignFailures,proto3" json:"reassign_failures,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Version            int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	DryRun             bool                   `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DeactivateTeamResponse) Reset() {
+	*x = DeactivateTeamResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateTeamResponse) ProtoMessage() {}
+
+func (x *DeactivateTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateTeamResponse.ProtoReflect.Descriptor instead.
+func (*DeactivateTeamResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeactivateTeamResponse) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *DeactivateTeamResponse) GetDeactivatedUsers() []string {
+	if x != nil {
+		return x.DeactivatedUsers
+	}
+	return nil
+}
+
+func (x *DeactivateTeamResponse) GetReassignedPrsCount() int32 {
+	if x != nil {
+		return x.ReassignedPrsCount
+	}
+	return 0
+}
+
+func (x *DeactivateTeamResponse) GetReassignFailures() map[string]string {
+	if x != nil {
+		return x.ReassignFailures
+	}
+	return nil
+}
+
+func (x *DeactivateTeamResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DeactivateTeamResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type SeniorityRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	MinLevel      string                 `protobuf:"bytes,2,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"`
+	MinCount      int32                  `protobuf:"varint,3,opt,name=min_count,json=minCount,proto3" json:"min_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeniorityRule) Reset() {
+	*x = SeniorityRule{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeniorityRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeniorityRule) ProtoMessage() {}
+
+func (x *SeniorityRule) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeniorityRule.ProtoReflect.Descriptor instead.
+func (*SeniorityRule) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SeniorityRule) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *SeniorityRule) GetMinLevel() string {
+	if x != nil {
+		return x.MinLevel
+	}
+	return ""
+}
+
+func (x *SeniorityRule) GetMinCount() int32 {
+	if x != nil {
+		return x.MinCount
+	}
+	return 0
+}
+
+type SLAPolicy struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TeamName           string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	ReminderAfterHours *int32                 `protobuf:"varint,2,opt,name=reminder_after_hours,json=reminderAfterHours,proto3,oneof" json:"reminder_after_hours,omitempty"`
+	EscalateAfterHours *int32                 `protobuf:"varint,3,opt,name=escalate_after_hours,json=escalateAfterHours,proto3,oneof" json:"escalate_after_hours,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SLAPolicy) Reset() {
+	*x = SLAPolicy{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SLAPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SLAPolicy) ProtoMessage() {}
+
+func (x *SLAPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SLAPolicy.ProtoReflect.Descriptor instead.
+func (*SLAPolicy) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SLAPolicy) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *SLAPolicy) GetReminderAfterHours() int32 {
+	if x != nil && x.ReminderAfterHours != nil {
+		return *x.ReminderAfterHours
+	}
+	return 0
+}
+
+func (x *SLAPolicy) GetEscalateAfterHours() int32 {
+	if x != nil && x.EscalateAfterHours != nil {
+		return *x.EscalateAfterHours
+	}
+	return 0
+}
+
+type WorkSchedule struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	TimeZone  *string                `protobuf:"bytes,1,opt,name=time_zone,json=timeZone,proto3,oneof" json:"time_zone,omitempty"`
+	WorkStart *string                `protobuf:"bytes,2,opt,name=work_start,json=workStart,proto3,oneof" json:"work_start,omitempty"`
+	WorkEnd   *string                `protobuf:"bytes,3,opt,name=work_end,json=workEnd,proto3,oneof" json:"work_end,omitempty"`
+	// work_days — дни недели по ISO 8601; пустой список наследует значение команды.
+	WorkDays      []int32 `protobuf:"varint,4,rep,packed,name=work_days,json=workDays,proto3" json:"work_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkSchedule) Reset() {
+	*x = WorkSchedule{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkSchedule) ProtoMessage() {}
+
+func (x *WorkSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkSchedule.ProtoReflect.Descriptor instead.
+func (*WorkSchedule) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WorkSchedule) GetTimeZone() string {
+	if x != nil && x.TimeZone != nil {
+		return *x.TimeZone
+	}
+	return ""
+}
+
+func (x *WorkSchedule) GetWorkStart() string {
+	if x != nil && x.WorkStart != nil {
+		return *x.WorkStart
+	}
+	return ""
+}
+
+func (x *WorkSchedule) GetWorkEnd() string {
+	if x != nil && x.WorkEnd != nil {
+		return *x.WorkEnd
+	}
+	return ""
+}
+
+func (x *WorkSchedule) GetWorkDays() []int32 {
+	if x != nil {
+		return x.WorkDays
+	}
+	return nil
+}
+
+type TeamSchedule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Schedule      *WorkSchedule          `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Holidays      []string               `protobuf:"bytes,3,rep,name=holidays,proto3" json:"holidays,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamSchedule) Reset() {
+	*x = TeamSchedule{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamSchedule) ProtoMessage() {}
+
+func (x *TeamSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamSchedule.ProtoReflect.Descriptor instead.
+func (*TeamSchedule) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TeamSchedule) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamSchedule) GetSchedule() *WorkSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *TeamSchedule) GetHolidays() []string {
+	if x != nil {
+		return x.Holidays
+	}
+	return nil
+}
+
+type TeamPolicy struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	// reviewer_count не задан — значение по умолчанию (2).
+	ReviewerCount         *int32                 `protobuf:"varint,2,opt,name=reviewer_count,json=reviewerCount,proto3,oneof" json:"reviewer_count,omitempty"`
+	Strategy              *string                `protobuf:"bytes,3,opt,name=strategy,proto3,oneof" json:"strategy,omitempty"`
+	FallbackTeams         []string               `protobuf:"bytes,4,rep,name=fallback_teams,json=fallbackTeams,proto3" json:"fallback_teams,omitempty"`
+	MinLevel              string                 `protobuf:"bytes,5,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"`
+	MinCount              int32                  `protobuf:"varint,6,opt,name=min_count,json=minCount,proto3" json:"min_count,omitempty"`
+	DefaultMaxOpenReviews *int32                 `protobuf:"varint,7,opt,name=default_max_open_reviews,json=defaultMaxOpenReviews,proto3,oneof" json:"default_max_open_reviews,omitempty"`
+	ReminderAfterHours    *int32                 `protobuf:"varint,8,opt,name=reminder_after_hours,json=reminderAfterHours,proto3,oneof" json:"reminder_after_hours,omitempty"`
+	EscalateAfterHours    *int32                 `protobuf:"varint,9,opt,name=escalate_after_hours,json=escalateAfterHours,proto3,oneof" json:"escalate_after_hours,omitempty"`
+	AllowMergedChanges    bool                   `protobuf:"varint,10,opt,name=allow_merged_changes,json=allowMergedChanges,proto3" json:"allow_merged_changes,omitempty"`
+	Version               int64                  `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedAt             *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *TeamPolicy) Reset() {
+	*x = TeamPolicy{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamPolicy) ProtoMessage() {}
+
+func (x *TeamPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamPolicy.ProtoReflect.Descriptor instead.
+func (*TeamPolicy) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TeamPolicy) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamPolicy) GetReviewerCount() int32 {
+	if x != nil && x.ReviewerCount != nil {
+		return *x.ReviewerCount
+	}
+	return 0
+}
+
+func (x *TeamPolicy) GetStrategy() string {
+	if x != nil && x.Strategy != nil {
+		return *x.Strategy
+	}
+	return ""
+}
+
+func (x *TeamPolicy) GetFallbackTeams() []string {
+	if x != nil {
+		return x.FallbackTeams
+	}
+	return nil
+}
+
+func (x *TeamPolicy) GetMinLevel() string {
+	if x != nil {
+		return x.MinLevel
+	}
+	return ""
+}
+
+func (x *TeamPolicy) GetMinCount() int32 {
+	if x != nil {
+		return x.MinCount
+	}
+	return 0
+}
+
+func (x *TeamPolicy) GetDefaultMaxOpenReviews() int32 {
+	if x != nil && x.DefaultMaxOpenReviews != nil {
+		return *x.DefaultMaxOpenReviews
+	}
+	return 0
+}
+
+func (x *TeamPolicy) GetReminderAfterHours() int32 {
+	if x != nil && x.ReminderAfterHours != nil {
+		return *x.ReminderAfterHours
+	}
+	return 0
+}
+
+func (x *TeamPolicy) GetEscalateAfterHours() int32 {
+	if x != nil && x.EscalateAfterHours != nil {
+		return *x.EscalateAfterHours
+	}
+	return 0
+}
+
+func (x *TeamPolicy) GetAllowMergedChanges() bool {
+	if x != nil {
+		return x.AllowMergedChanges
+	}
+	return false
+}
+
+func (x *TeamPolicy) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TeamPolicy) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type SetTeamPolicyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// policy.version игнорируется; для проверки версии используется expected_version.
+	Policy          *TeamPolicy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	ExpectedVersion int64       `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SetTeamPolicyRequest) Reset() {
+	*x = SetTeamPolicyRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTeamPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTeamPolicyRequest) ProtoMessage() {}
+
+func (x *SetTeamPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTeamPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetTeamPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetTeamPolicyRequest) GetPolicy() *TeamPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *SetTeamPolicyRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type TeamPolicyRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Policy        *TeamPolicy            `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamPolicyRevision) Reset() {
+	*x = TeamPolicyRevision{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamPolicyRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamPolicyRevision) ProtoMessage() {}
+
+func (x *TeamPolicyRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamPolicyRevision.ProtoReflect.Descriptor instead.
+func (*TeamPolicyRevision) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TeamPolicyRevision) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TeamPolicyRevision) GetPolicy() *TeamPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *TeamPolicyRevision) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+type TeamPolicyHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	History       []*TeamPolicyRevision  `protobuf:"bytes,2,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamPolicyHistory) Reset() {
+	*x = TeamPolicyHistory{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamPolicyHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamPolicyHistory) ProtoMessage() {}
+
+func (x *TeamPolicyHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamPolicyHistory.ProtoReflect.Descriptor instead.
+func (*TeamPolicyHistory) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TeamPolicyHistory) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamPolicyHistory) GetHistory() []*TeamPolicyRevision {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+type User struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username       string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	TeamName       string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	IsActive       bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Level          string                 `protobuf:"bytes,5,opt,name=level,proto3" json:"level,omitempty"`
+	MaxOpenReviews *int32                 `protobuf:"varint,6,opt,name=max_open_reviews,json=maxOpenReviews,proto3,oneof" json:"max_open_reviews,omitempty"`
+	AwayUntil      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=away_until,json=awayUntil,proto3" json:"away_until,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *User) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *User) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *User) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *User) GetMaxOpenReviews() int32 {
+	if x != nil && x.MaxOpenReviews != nil {
+		return *x.MaxOpenReviews
+	}
+	return 0
+}
+
+func (x *User) GetAwayUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AwayUntil
+	}
+	return nil
+}
+
+type SetIsActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsActive      bool                   `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIsActiveRequest) Reset() {
+	*x = SetIsActiveRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIsActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIsActiveRequest) ProtoMessage() {}
+
+func (x *SetIsActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIsActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetIsActiveRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetIsActiveRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetIsActiveRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type SetAvailabilityRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AwayUntil      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=away_until,json=awayUntil,proto3" json:"away_until,omitempty"`
+	MaxOpenReviews *int32                 `protobuf:"varint,3,opt,name=max_open_reviews,json=maxOpenReviews,proto3,oneof" json:"max_open_reviews,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetAvailabilityRequest) Reset() {
+	*x = SetAvailabilityRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAvailabilityRequest) ProtoMessage() {}
+
+func (x *SetAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*SetAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetAvailabilityRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetAvailabilityRequest) GetAwayUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AwayUntil
+	}
+	return nil
+}
+
+func (x *SetAvailabilityRequest) GetMaxOpenReviews() int32 {
+	if x != nil && x.MaxOpenReviews != nil {
+		return *x.MaxOpenReviews
+	}
+	return 0
+}
+
+type SetScheduleRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Schedule *WorkSchedule          `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// holidays — личные праздники, добавляются к календарю команды.
+	Holidays      []string `protobuf:"bytes,3,rep,name=holidays,proto3" json:"holidays,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetScheduleRequest) Reset() {
+	*x = SetScheduleRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetScheduleRequest) ProtoMessage() {}
+
+func (x *SetScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetScheduleRequest.ProtoReflect.Descriptor instead.
+func (*SetScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetScheduleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetScheduleRequest) GetSchedule() *WorkSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *SetScheduleRequest) GetHolidays() []string {
+	if x != nil {
+		return x.Holidays
+	}
+	return nil
+}
+
+type SetScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Schedule      *WorkSchedule          `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Holidays      []string               `protobuf:"bytes,3,rep,name=holidays,proto3" json:"holidays,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetScheduleResponse) Reset() {
+	*x = SetScheduleResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetScheduleResponse) ProtoMessage() {}
+
+func (x *SetScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetScheduleResponse.ProtoReflect.Descriptor instead.
+func (*SetScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetScheduleResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetScheduleResponse) GetSchedule() *WorkSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *SetScheduleResponse) GetHolidays() []string {
+	if x != nil {
+		return x.Holidays
+	}
+	return nil
+}
+
+type GetReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewRequest) Reset() {
+	*x = GetReviewRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewRequest) ProtoMessage() {}
+
+func (x *GetReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetReviewRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type PullRequestShort struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId    string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName  string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId         string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status           string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	AssignedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	ReviewAgeSeconds *int64                 `protobuf:"varint,6,opt,name=review_age_seconds,json=reviewAgeSeconds,proto3,oneof" json:"review_age_seconds,omitempty"`
+	ReviewAge        string                 `protobuf:"bytes,7,opt,name=review_age,json=reviewAge,proto3" json:"review_age,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PullRequestShort) Reset() {
+	*x = PullRequestShort{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequestShort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequestShort) ProtoMessage() {}
+
+func (x *PullRequestShort) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequestShort.ProtoReflect.Descriptor instead.
+func (*PullRequestShort) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PullRequestShort) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *PullRequestShort) GetReviewAgeSeconds() int64 {
+	if x != nil && x.ReviewAgeSeconds != nil {
+		return *x.ReviewAgeSeconds
+	}
+	return 0
+}
+
+func (x *PullRequestShort) GetReviewAge() string {
+	if x != nil {
+		return x.ReviewAge
+	}
+	return ""
+}
+
+type GetReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PullRequests  []*PullRequestShort    `protobuf:"bytes,2,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewResponse) Reset() {
+	*x = GetReviewResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewResponse) ProtoMessage() {}
+
+func (x *GetReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewResponse.ProtoReflect.Descriptor instead.
+func (*GetReviewResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetReviewResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetReviewResponse) GetPullRequests() []*PullRequestShort {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+type DeclineReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PullRequestId string                 `protobuf:"bytes,2,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// reason: busy, no_context, conflict_of_interest или other.
+	Reason          string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Comment         string `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	ExpectedVersion int64  `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeclineReviewRequest) Reset() {
+	*x = DeclineReviewRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineReviewRequest) ProtoMessage() {}
+
+func (x *DeclineReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineReviewRequest.ProtoReflect.Descriptor instead.
+func (*DeclineReviewRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeclineReviewRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeclineReviewRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *DeclineReviewRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeclineReviewRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *DeclineReviewRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type PullRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId     string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName   string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId          string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status            string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	AssignedReviewers []string               `protobuf:"bytes,5,rep,name=assigned_reviewers,json=assignedReviewers,proto3" json:"assigned_reviewers,omitempty"`
+	RequiredTags      []string               `protobuf:"bytes,6,rep,name=required_tags,json=requiredTags,proto3" json:"required_tags,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MergedAt          *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	Version           int64                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequest.ProtoReflect.Descriptor instead.
+func (*PullRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PullRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PullRequest) GetAssignedReviewers() []string {
+	if x != nil {
+		return x.AssignedReviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetRequiredTags() []string {
+	if x != nil {
+		return x.RequiredTags
+	}
+	return nil
+}
+
+func (x *PullRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreatePullRequestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	RequiredTags    []string               `protobuf:"bytes,4,rep,name=required_tags,json=requiredTags,proto3" json:"required_tags,omitempty"`
+	// explain — вернуть объяснение выбора вместе с PR.
+	Explain       bool `protobuf:"varint,5,opt,name=explain,proto3" json:"explain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestRequest) Reset() {
+	*x = CreatePullRequestRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestRequest) ProtoMessage() {}
+
+func (x *CreatePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetRequiredTags() []string {
+	if x != nil {
+		return x.RequiredTags
+	}
+	return nil
+}
+
+func (x *CreatePullRequestRequest) GetExplain() bool {
+	if x != nil {
+		return x.Explain
+	}
+	return false
+}
+
+type CreatePullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	Explanation   *Explanation           `protobuf:"bytes,2,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestResponse) Reset() {
+	*x = CreatePullRequestResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestResponse) ProtoMessage() {}
+
+func (x *CreatePullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestResponse.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreatePullRequestResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+func (x *CreatePullRequestResponse) GetExplanation() *Explanation {
+	if x != nil {
+		return x.Explanation
+	}
+	return nil
+}
+
+type GetPullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestRequest) Reset() {
+	*x = GetPullRequestRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestRequest) ProtoMessage() {}
+
+func (x *GetPullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetPullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetPullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type MergePullRequestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MergePullRequestRequest) Reset() {
+	*x = MergePullRequestRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestRequest) ProtoMessage() {}
+
+func (x *MergePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*MergePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *MergePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *MergePullRequestRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ReassignReviewerRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	OldUserId       string                 `protobuf:"bytes,2,opt,name=old_user_id,json=oldUserId,proto3" json:"old_user_id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerRequest) Reset() {
+	*x = ReassignReviewerRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerRequest) ProtoMessage() {}
+
+func (x *ReassignReviewerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerRequest.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReassignReviewerRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetOldUserId() string {
+	if x != nil {
+		return x.OldUserId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ReassignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	ReplacedBy    string                 `protobuf:"bytes,2,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignResponse) Reset() {
+	*x = ReassignResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignResponse) ProtoMessage() {}
+
+func (x *ReassignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignResponse.ProtoReflect.Descriptor instead.
+func (*ReassignResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ReassignResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+func (x *ReassignResponse) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type ReviewerReplacement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldUserId     string                 `protobuf:"bytes,1,opt,name=old_user_id,json=oldUserId,proto3" json:"old_user_id,omitempty"`
+	NewUserId     string                 `protobuf:"bytes,2,opt,name=new_user_id,json=newUserId,proto3" json:"new_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewerReplacement) Reset() {
+	*x = ReviewerReplacement{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewerReplacement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewerReplacement) ProtoMessage() {}
+
+func (x *ReviewerReplacement) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewerReplacement.ProtoReflect.Descriptor instead.
+func (*ReviewerReplacement) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ReviewerReplacement) GetOldUserId() string {
+	if x != nil {
+		return x.OldUserId
+	}
+	return ""
+}
+
+func (x *ReviewerReplacement) GetNewUserId() string {
+	if x != nil {
+		return x.NewUserId
+	}
+	return ""
+}
+
+type ChangeReviewersRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	Add             []string               `protobuf:"bytes,2,rep,name=add,proto3" json:"add,omitempty"`
+	Remove          []string               `protobuf:"bytes,3,rep,name=remove,proto3" json:"remove,omitempty"`
+	Replace         []*ReviewerReplacement `protobuf:"bytes,4,rep,name=replace,proto3" json:"replace,omitempty"`
+	Override        bool                   `protobuf:"varint,5,opt,name=override,proto3" json:"override,omitempty"`
+	Reason          string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangeReviewersRequest) Reset() {
+	*x = ChangeReviewersRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeReviewersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeReviewersRequest) ProtoMessage() {}
+
+func (x *ChangeReviewersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeReviewersRequest.ProtoReflect.Descriptor instead.
+func (*ChangeReviewersRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ChangeReviewersRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ChangeReviewersRequest) GetAdd() []string {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *ChangeReviewersRequest) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+func (x *ChangeReviewersRequest) GetReplace() []*ReviewerReplacement {
+	if x != nil {
+		return x.Replace
+	}
+	return nil
+}
+
+func (x *ChangeReviewersRequest) GetOverride() bool {
+	if x != nil {
+		return x.Override
+	}
+	return false
+}
+
+func (x *ChangeReviewersRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ChangeReviewersRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type Assignment struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AssignmentId       int64                  `protobuf:"varint,1,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	PullRequestId      string                 `protobuf:"bytes,2,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	Kind               string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Strategy           string                 `protobuf:"bytes,4,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Algorithm          string                 `protobuf:"bytes,5,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Seed               int64                  `protobuf:"varint,6,opt,name=seed,proto3" json:"seed,omitempty"`
+	ReplacedReviewerId *string                `protobuf:"bytes,7,opt,name=replaced_reviewer_id,json=replacedReviewerId,proto3,oneof" json:"replaced_reviewer_id,omitempty"`
+	Reason             *string                `protobuf:"bytes,8,opt,name=reason,proto3,oneof" json:"reason,omitempty"`
+	Reviewers          []string               `protobuf:"bytes,9,rep,name=reviewers,proto3" json:"reviewers,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Assignment) Reset() {
+	*x = Assignment{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Assignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Assignment) ProtoMessage() {}
+
+func (x *Assignment) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Assignment.ProtoReflect.Descriptor instead.
+func (*Assignment) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Assignment) GetAssignmentId() int64 {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return 0
+}
+
+func (x *Assignment) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *Assignment) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Assignment) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *Assignment) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *Assignment) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *Assignment) GetReplacedReviewerId() string {
+	if x != nil && x.ReplacedReviewerId != nil {
+		return *x.ReplacedReviewerId
+	}
+	return ""
+}
+
+func (x *Assignment) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
+	}
+	return ""
+}
+
+func (x *Assignment) GetReviewers() []string {
+	if x != nil {
+		return x.Reviewers
+	}
+	return nil
+}
+
+func (x *Assignment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListAssignmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	Assignments   []*Assignment          `protobuf:"bytes,2,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignmentsResponse) Reset() {
+	*x = ListAssignmentsResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignmentsResponse) ProtoMessage() {}
+
+func (x *ListAssignmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAssignmentsResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListAssignmentsResponse) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ListAssignmentsResponse) GetAssignments() []*Assignment {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+type CandidateExplanation struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Level          string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	Excluded       []string               `protobuf:"bytes,3,rep,name=excluded,proto3" json:"excluded,omitempty"`
+	TagOverlap     int32                  `protobuf:"varint,4,opt,name=tag_overlap,json=tagOverlap,proto3" json:"tag_overlap,omitempty"`
+	SkillLevel     int32                  `protobuf:"varint,5,opt,name=skill_level,json=skillLevel,proto3" json:"skill_level,omitempty"`
+	MeetsSeniority bool                   `protobuf:"varint,6,opt,name=meets_seniority,json=meetsSeniority,proto3" json:"meets_seniority,omitempty"`
+	LastPairedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_paired_at,json=lastPairedAt,proto3" json:"last_paired_at,omitempty"`
+	Rank           string                 `protobuf:"bytes,8,opt,name=rank,proto3" json:"rank,omitempty"`
+	Position       int32                  `protobuf:"varint,9,opt,name=position,proto3" json:"position,omitempty"`
+	Selected       bool                   `protobuf:"varint,10,opt,name=selected,proto3" json:"selected,omitempty"`
+	OpenReviews    int32                  `protobuf:"varint,11,opt,name=open_reviews,json=openReviews,proto3" json:"open_reviews,omitempty"`
+	Fallback       bool                   `protobuf:"varint,12,opt,name=fallback,proto3" json:"fallback,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CandidateExplanation) Reset() {
+	*x = CandidateExplanation{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CandidateExplanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandidateExplanation) ProtoMessage() {}
+
+func (x *CandidateExplanation) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandidateExplanation.ProtoReflect.Descriptor instead.
+func (*CandidateExplanation) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CandidateExplanation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CandidateExplanation) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *CandidateExplanation) GetExcluded() []string {
+	if x != nil {
+		return x.Excluded
+	}
+	return nil
+}
+
+func (x *CandidateExplanation) GetTagOverlap() int32 {
+	if x != nil {
+		return x.TagOverlap
+	}
+	return 0
+}
+
+func (x *CandidateExplanation) GetSkillLevel() int32 {
+	if x != nil {
+		return x.SkillLevel
+	}
+	return 0
+}
+
+func (x *CandidateExplanation) GetMeetsSeniority() bool {
+	if x != nil {
+		return x.MeetsSeniority
+	}
+	return false
+}
+
+func (x *CandidateExplanation) GetLastPairedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastPairedAt
+	}
+	return nil
+}
+
+func (x *CandidateExplanation) GetRank() string {
+	if x != nil {
+		return x.Rank
+	}
+	return ""
+}
+
+func (x *CandidateExplanation) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *CandidateExplanation) GetSelected() bool {
+	if x != nil {
+		return x.Selected
+	}
+	return false
+}
+
+func (x *CandidateExplanation) GetOpenReviews() int32 {
+	if x != nil {
+		return x.OpenReviews
+	}
+	return 0
+}
+
+func (x *CandidateExplanation) GetFallback() bool {
+	if x != nil {
+		return x.Fallback
+	}
+	return false
+}
+
+type StringList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringList) Reset() {
+	*x = StringList{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringList) ProtoMessage() {}
+
+func (x *StringList) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringList.ProtoReflect.Descriptor instead.
+func (*StringList) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StringList) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type ManualChange struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Added               []string               `protobuf:"bytes,1,rep,name=added,proto3" json:"added,omitempty"`
+	Removed             []string               `protobuf:"bytes,2,rep,name=removed,proto3" json:"removed,omitempty"`
+	Override            bool                   `protobuf:"varint,3,opt,name=override,proto3" json:"override,omitempty"`
+	Overridden          map[string]*StringList `protobuf:"bytes,4,rep,name=overridden,proto3" json:"overridden,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	SeniorityOverridden bool                   `protobuf:"varint,5,opt,name=seniority_overridden,json=seniorityOverridden,proto3" json:"seniority_overridden,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ManualChange) Reset() {
+	*x = ManualChange{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManualChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManualChange) ProtoMessage() {}
+
+func (x *ManualChange) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManualChange.ProtoReflect.Descriptor instead.
+func (*ManualChange) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ManualChange) GetAdded() []string {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *ManualChange) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *ManualChange) GetOverride() bool {
+	if x != nil {
+		return x.Override
+	}
+	return false
+}
+
+func (x *ManualChange) GetOverridden() map[string]*StringList {
+	if x != nil {
+		return x.Overridden
+	}
+	return nil
+}
+
+func (x *ManualChange) GetSeniorityOverridden() bool {
+	if x != nil {
+		return x.SeniorityOverridden
+	}
+	return false
+}
+
+type Explanation struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Assignment    *Assignment             `protobuf:"bytes,1,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	RequiredTags  []string                `protobuf:"bytes,2,rep,name=required_tags,json=requiredTags,proto3" json:"required_tags,omitempty"`
+	SeniorityRule *SeniorityRule          `protobuf:"bytes,3,opt,name=seniority_rule,json=seniorityRule,proto3" json:"seniority_rule,omitempty"`
+	Candidates    []*CandidateExplanation `protobuf:"bytes,4,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	Manual        *ManualChange           `protobuf:"bytes,5,opt,name=manual,proto3" json:"manual,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Explanation) Reset() {
+	*x = Explanation{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Explanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Explanation) ProtoMessage() {}
+
+func (x *Explanation) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Explanation.ProtoReflect.Descriptor instead.
+func (*Explanation) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Explanation) GetAssignment() *Assignment {
+	if x != nil {
+		return x.Assignment
+	}
+	return nil
+}
+
+func (x *Explanation) GetRequiredTags() []string {
+	if x != nil {
+		return x.RequiredTags
+	}
+	return nil
+}
+
+func (x *Explanation) GetSeniorityRule() *SeniorityRule {
+	if x != nil {
+		return x.SeniorityRule
+	}
+	return nil
+}
+
+func (x *Explanation) GetCandidates() []*CandidateExplanation {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *Explanation) GetManual() *ManualChange {
+	if x != nil {
+		return x.Manual
+	}
+	return nil
+}
+
+type GetReviewerStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewerStatsRequest) Reset() {
+	*x = GetReviewerStatsRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewerStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewerStatsRequest) ProtoMessage() {}
+
+func (x *GetReviewerStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewerStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewerStatsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{41}
+}
+
+type ReviewerStat struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	UserId                     string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ReviewCount                int64                  `protobuf:"varint,2,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	OpenReviewCount            int64                  `protobuf:"varint,3,opt,name=open_review_count,json=openReviewCount,proto3" json:"open_review_count,omitempty"`
+	OldestOpenReviewAgeSeconds int64                  `protobuf:"varint,4,opt,name=oldest_open_review_age_seconds,json=oldestOpenReviewAgeSeconds,proto3" json:"oldest_open_review_age_seconds,omitempty"`
+	DeclineCount               int64                  `protobuf:"varint,5,opt,name=decline_count,json=declineCount,proto3" json:"decline_count,omitempty"`
+	DeclinesByReason           map[string]int64       `protobuf:"bytes,6,rep,name=declines_by_reason,json=declinesByReason,proto3" json:"declines_by_reason,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *ReviewerStat) Reset() {
+	*x = ReviewerStat{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewerStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewerStat) ProtoMessage() {}
+
+func (x *ReviewerStat) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewerStat.ProtoReflect.Descriptor instead.
+func (*ReviewerStat) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ReviewerStat) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReviewerStat) GetReviewCount() int64 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+func (x *ReviewerStat) GetOpenReviewCount() int64 {
+	if x != nil {
+		return x.OpenReviewCount
+	}
+	return 0
+}
+
+func (x *ReviewerStat) GetOldestOpenReviewAgeSeconds() int64 {
+	if x != nil {
+		return x.OldestOpenReviewAgeSeconds
+	}
+	return 0
+}
+
+func (x *ReviewerStat) GetDeclineCount() int64 {
+	if x != nil {
+		return x.DeclineCount
+	}
+	return 0
+}
+
+func (x *ReviewerStat) GetDeclinesByReason() map[string]int64 {
+	if x != nil {
+		return x.DeclinesByReason
+	}
+	return nil
+}
+
+type GetReviewerStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         []*ReviewerStat        `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewerStatsResponse) Reset() {
+	*x = GetReviewerStatsResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewerStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewerStatsResponse) ProtoMessage() {}
+
+func (x *GetReviewerStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewerStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetReviewerStatsResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetReviewerStatsResponse) GetStats() []*ReviewerStat {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type PairMatrixRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        []int64                `protobuf:"varint,1,rep,packed,name=counts,proto3" json:"counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PairMatrixRow) Reset() {
+	*x = PairMatrixRow{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PairMatrixRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairMatrixRow) ProtoMessage() {}
+
+func (x *PairMatrixRow) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairMatrixRow.ProtoReflect.Descriptor instead.
+func (*PairMatrixRow) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *PairMatrixRow) GetCounts() []int64 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type ReviewPair struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId       string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	ReviewerId     string                 `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	ReviewCount    int64                  `protobuf:"varint,3,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	LastAssignedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_assigned_at,json=lastAssignedAt,proto3" json:"last_assigned_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReviewPair) Reset() {
+	*x = ReviewPair{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPair) ProtoMessage() {}
+
+func (x *ReviewPair) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPair.ProtoReflect.Descriptor instead.
+func (*ReviewPair) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ReviewPair) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ReviewPair) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *ReviewPair) GetReviewCount() int64 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+func (x *ReviewPair) GetLastAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAssignedAt
+	}
+	return nil
+}
+
+type PairMatrix struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members  []string               `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	// matrix[i].counts[j] — сколько раз members[j] ревьюил PR автора members[i].
+	Matrix        []*PairMatrixRow `protobuf:"bytes,3,rep,name=matrix,proto3" json:"matrix,omitempty"`
+	Pairs         []*ReviewPair    `protobuf:"bytes,4,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PairMatrix) Reset() {
+	*x = PairMatrix{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PairMatrix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairMatrix) ProtoMessage() {}
+
+func (x *PairMatrix) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairMatrix.ProtoReflect.Descriptor instead.
+func (*PairMatrix) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *PairMatrix) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *PairMatrix) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *PairMatrix) GetMatrix() []*PairMatrixRow {
+	if x != nil {
+		return x.Matrix
+	}
+	return nil
+}
+
+func (x *PairMatrix) GetPairs() []*ReviewPair {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+type StreamEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	LastEventId   *int64                 `protobuf:"varint,3,opt,name=last_event_id,json=lastEventId,proto3,oneof" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *StreamEventsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetLastEventId() int64 {
+	if x != nil && x.LastEventId != nil {
+		return *x.LastEventId
+	}
+	return 0
+}
+
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	TeamName      string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PullRequestId string                 `protobuf:"bytes,5,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	RelatedUsers  []string               `protobuf:"bytes,6,rep,name=related_users,json=relatedUsers,proto3" json:"related_users,omitempty"`
+	Payload       *structpb.Struct       `protobuf:"bytes,7,opt,name=payload,proto3" json:"payload,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Event) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Event) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Event) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *Event) GetRelatedUsers() []string {
+	if x != nil {
+		return x.RelatedUsers
+	}
+	return nil
+}
+
+func (x *Event) GetPayload() *structpb.Struct {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Event) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_reviewer_v1_reviewer_proto protoreflect.FileDescriptor
+
+const file_reviewer_v1_reviewer_proto_rawDesc = "" +
+	"\n" +
+	"\x1areviewer/v1/reviewer.proto\x12\vreviewer.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"/\n" +
+	"\x05Skill\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\x05R\x05level\"\xa0\x01\n" +
+	"\n" +
+	"TeamMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\tR\x05level\x12*\n" +
+	"\x06skills\x18\x05 \x03(\v2\x12.reviewer.v1.SkillR\x06skills\"p\n" +
+	"\x04Team\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x121\n" +
+	"\amembers\x18\x02 \x03(\v2\x17.reviewer.v1.TeamMemberR\amembers\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\".\n" +
+	"\x0fTeamNameRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"b\n" +
+	"\x0eAddTeamRequest\x12%\n" +
+	"\x04team\x18\x01 \x01(\v2\x11.reviewer.v1.TeamR\x04team\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\"-\n" +
+	"\x0eGetTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"5\n" +
+	"\fTeamResponse\x12%\n" +
+	"\x04team\x18\x01 \x01(\v2\x11.reviewer.v1.TeamR\x04team\"x\n" +
+	"\x15DeactivateTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"\xf4\x02\n" +
+	"\x16DeactivateTeamResponse\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12+\n" +
+	"\x11deactivated_users\x18\x02 \x03(\tR\x10deactivatedUsers\x120\n" +
+	"\x14reassigned_prs_count\x18\x03 \x01(\x05R\x12reassignedPrsCount\x12f\n" +
+	"\x11reassign_failures\x18\x04 \x03(\v29.reviewer.v1.DeactivateTeamResponse.ReassignFailuresEntryR\x10reassignFailures\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\x1aC\n" +
+	"\x15ReassignFailuresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"f\n" +
+	"\rSeniorityRule\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x1b\n" +
+	"\tmin_level\x18\x02 \x01(\tR\bminLevel\x12\x1b\n" +
+	"\tmin_count\x18\x03 \x01(\x05R\bminCount\"\xc8\x01\n" +
+	"\tSLAPolicy\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x125\n" +
+	"\x14reminder_after_hours\x18\x02 \x01(\x05H\x00R\x12reminderAfterHours\x88\x01\x01\x125\n" +
+	"\x14escalate_after_hours\x18\x03 \x01(\x05H\x01R\x12escalateAfterHours\x88\x01\x01B\x17\n" +
+	"\x15_reminder_after_hoursB\x17\n" +
+	"\x15_escalate_after_hours\"\xbb\x01\n" +
+	"\fWorkSchedule\x12 \n" +
+	"\ttime_zone\x18\x01 \x01(\tH\x00R\btimeZone\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"work_start\x18\x02 \x01(\tH\x01R\tworkStart\x88\x01\x01\x12\x1e\n" +
+	"\bwork_end\x18\x03 \x01(\tH\x02R\aworkEnd\x88\x01\x01\x12\x1b\n" +
+	"\twork_days\x18\x04 \x03(\x05R\bworkDaysB\f\n" +
+	"\n" +
+	"_time_zoneB\r\n" +
+	"\v_work_startB\v\n" +
+	"\t_work_end\"~\n" +
+	"\fTeamSchedule\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x125\n" +
+	"\bschedule\x18\x02 \x01(\v2\x19.reviewer.v1.WorkScheduleR\bschedule\x12\x1a\n" +
+	"\bholidays\x18\x03 \x03(\tR\bholidays\"\xf9\x04\n" +
+	"\n" +
+	"TeamPolicy\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12*\n" +
+	"\x0ereviewer_count\x18\x02 \x01(\x05H\x00R\rreviewerCount\x88\x01\x01\x12\x1f\n" +
+	"\bstrategy\x18\x03 \x01(\tH\x01R\bstrategy\x88\x01\x01\x12%\n" +
+	"\x0efallback_teams\x18\x04 \x03(\tR\rfallbackTeams\x12\x1b\n" +
+	"\tmin_level\x18\x05 \x01(\tR\bminLevel\x12\x1b\n" +
+	"\tmin_count\x18\x06 \x01(\x05R\bminCount\x12<\n" +
+	"\x18default_max_open_reviews\x18\a \x01(\x05H\x02R\x15defaultMaxOpenReviews\x88\x01\x01\x125\n" +
+	"\x14reminder_after_hours\x18\b \x01(\x05H\x03R\x12reminderAfterHours\x88\x01\x01\x125\n" +
+	"\x14escalate_after_hours\x18\t \x01(\x05H\x04R\x12escalateAfterHours\x88\x01\x01\x120\n" +
+	"\x14allow_merged_changes\x18\n" +
+	" \x01(\bR\x12allowMergedChanges\x12\x18\n" +
+	"\aversion\x18\v \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x11\n" +
+	"\x0f_reviewer_countB\v\n" +
+	"\t_strategyB\x1b\n" +
+	"\x19_default_max_open_reviewsB\x17\n" +
+	"\x15_reminder_after_hoursB\x17\n" +
+	"\x15_escalate_after_hours\"r\n" +
+	"\x14SetTeamPolicyRequest\x12/\n" +
+	"\x06policy\x18\x01 \x01(\v2\x17.reviewer.v1.TeamPolicyR\x06policy\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\"\x9a\x01\n" +
+	"\x12TeamPolicyRevision\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\x12/\n" +
+	"\x06policy\x18\x02 \x01(\v2\x17.reviewer.v1.TeamPolicyR\x06policy\x129\n" +
+	"\n" +
+	"changed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\"k\n" +
+	"\x11TeamPolicyHistory\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x129\n" +
+	"\ahistory\x18\x02 \x03(\v2\x1f.reviewer.v1.TeamPolicyRevisionR\ahistory\"\x8a\x02\n" +
+	"\x04User\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12\x1b\n" +
+	"\tis_active\x18\x04 \x01(\bR\bisActive\x12\x14\n" +
+	"\x05level\x18\x05 \x01(\tR\x05level\x12-\n" +
+	"\x10max_open_reviews\x18\x06 \x01(\x05H\x00R\x0emaxOpenReviews\x88\x01\x01\x129\n" +
+	"\n" +
+	"away_until\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tawayUntilB\x13\n" +
+	"\x11_max_open_reviews\"J\n" +
+	"\x12SetIsActiveRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tis_active\x18\x02 \x01(\bR\bisActive\"\xb0\x01\n" +
+	"\x16SetAvailabilityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"away_until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tawayUntil\x12-\n" +
+	"\x10max_open_reviews\x18\x03 \x01(\x05H\x00R\x0emaxOpenReviews\x88\x01\x01B\x13\n" +
+	"\x11_max_open_reviews\"\x80\x01\n" +
+	"\x12SetScheduleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x125\n" +
+	"\bschedule\x18\x02 \x01(\v2\x19.reviewer.v1.WorkScheduleR\bschedule\x12\x1a\n" +
+	"\bholidays\x18\x03 \x03(\tR\bholidays\"\x81\x01\n" +
+	"\x13SetScheduleResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x125\n" +
+	"\bschedule\x18\x02 \x01(\v2\x19.reviewer.v1.WorkScheduleR\bschedule\x12\x1a\n" +
+	"\bholidays\x18\x03 \x03(\tR\bholidays\"+\n" +
+	"\x10GetReviewRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc1\x02\n" +
+	"\x10PullRequestShort\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12;\n" +
+	"\vassigned_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedAt\x121\n" +
+	"\x12review_age_seconds\x18\x06 \x01(\x03H\x00R\x10reviewAgeSeconds\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"review_age\x18\a \x01(\tR\treviewAgeB\x15\n" +
+	"\x13_review_age_seconds\"p\n" +
+	"\x11GetReviewResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12B\n" +
+	"\rpull_requests\x18\x02 \x03(\v2\x1d.reviewer.v1.PullRequestShortR\fpullRequests\"\xb4\x01\n" +
+	"\x14DeclineReviewRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
+	"\x0fpull_request_id\x18\x02 \x01(\tR\rpullRequestId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\x12)\n" +
+	"\x10expected_version\x18\x05 \x01(\x03R\x0fexpectedVersion\"\xf8\x02\n" +
+	"\vPullRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12-\n" +
+	"\x12assigned_reviewers\x18\x05 \x03(\tR\x11assignedReviewers\x12#\n" +
+	"\rrequired_tags\x18\x06 \x03(\tR\frequiredTags\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tmerged_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\x12\x18\n" +
+	"\aversion\x18\t \x01(\x03R\aversion\"\xca\x01\n" +
+	"\x18CreatePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12#\n" +
+	"\rrequired_tags\x18\x04 \x03(\tR\frequiredTags\x12\x18\n" +
+	"\aexplain\x18\x05 \x01(\bR\aexplain\"\x81\x01\n" +
+	"\x19CreatePullRequestResponse\x12(\n" +
+	"\x02pr\x18\x01 \x01(\v2\x18.reviewer.v1.PullRequestR\x02pr\x12:\n" +
+	"\vexplanation\x18\x02 \x01(\v2\x18.reviewer.v1.ExplanationR\vexplanation\"?\n" +
+	"\x15GetPullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"l\n" +
+	"\x17MergePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\"\x8c\x01\n" +
+	"\x17ReassignReviewerRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1e\n" +
+	"\vold_user_id\x18\x02 \x01(\tR\toldUserId\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x03R\x0fexpectedVersion\"]\n" +
+	"\x10ReassignResponse\x12(\n" +
+	"\x02pr\x18\x01 \x01(\v2\x18.reviewer.v1.PullRequestR\x02pr\x12\x1f\n" +
+	"\vreplaced_by\x18\x02 \x01(\tR\n" +
+	"replacedBy\"U\n" +
+	"\x13ReviewerReplacement\x12\x1e\n" +
+	"\vold_user_id\x18\x01 \x01(\tR\toldUserId\x12\x1e\n" +
+	"\vnew_user_id\x18\x02 \x01(\tR\tnewUserId\"\x85\x02\n" +
+	"\x16ChangeReviewersRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x10\n" +
+	"\x03add\x18\x02 \x03(\tR\x03add\x12\x16\n" +
+	"\x06remove\x18\x03 \x03(\tR\x06remove\x12:\n" +
+	"\areplace\x18\x04 \x03(\v2 .reviewer.v1.ReviewerReplacementR\areplace\x12\x1a\n" +
+	"\boverride\x18\x05 \x01(\bR\boverride\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12)\n" +
+	"\x10expected_version\x18\a \x01(\x03R\x0fexpectedVersion\"\x8c\x03\n" +
+	"\n" +
+	"Assignment\x12#\n" +
+	"\rassignment_id\x18\x01 \x01(\x03R\fassignmentId\x12&\n" +
+	"\x0fpull_request_id\x18\x02 \x01(\tR\rpullRequestId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x1a\n" +
+	"\bstrategy\x18\x04 \x01(\tR\bstrategy\x12\x1c\n" +
+	"\talgorithm\x18\x05 \x01(\tR\talgorithm\x12\x12\n" +
+	"\x04seed\x18\x06 \x01(\x03R\x04seed\x125\n" +
+	"\x14replaced_reviewer_id\x18\a \x01(\tH\x00R\x12replacedReviewerId\x88\x01\x01\x12\x1b\n" +
+	"\x06reason\x18\b \x01(\tH\x01R\x06reason\x88\x01\x01\x12\x1c\n" +
+	"\treviewers\x18\t \x03(\tR\treviewers\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\x17\n" +
+	"\x15_replaced_reviewer_idB\t\n" +
+	"\a_reason\"|\n" +
+	"\x17ListAssignmentsResponse\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x129\n" +
+	"\vassignments\x18\x02 \x03(\v2\x17.reviewer.v1.AssignmentR\vassignments\"\x99\x03\n" +
+	"\x14CandidateExplanation\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12\x1a\n" +
+	"\bexcluded\x18\x03 \x03(\tR\bexcluded\x12\x1f\n" +
+	"\vtag_overlap\x18\x04 \x01(\x05R\n" +
+	"tagOverlap\x12\x1f\n" +
+	"\vskill_level\x18\x05 \x01(\x05R\n" +
+	"skillLevel\x12'\n" +
+	"\x0fmeets_seniority\x18\x06 \x01(\bR\x0emeetsSeniority\x12@\n" +
+	"\x0elast_paired_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\flastPairedAt\x12\x12\n" +
+	"\x04rank\x18\b \x01(\tR\x04rank\x12\x1a\n" +
+	"\bposition\x18\t \x01(\x05R\bposition\x12\x1a\n" +
+	"\bselected\x18\n" +
+	" \x01(\bR\bselected\x12!\n" +
+	"\fopen_reviews\x18\v \x01(\x05R\vopenReviews\x12\x1a\n" +
+	"\bfallback\x18\f \x01(\bR\bfallback\"$\n" +
+	"\n" +
+	"StringList\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\xb0\x02\n" +
+	"\fManualChange\x12\x14\n" +
+	"\x05added\x18\x01 \x03(\tR\x05added\x12\x18\n" +
+	"\aremoved\x18\x02 \x03(\tR\aremoved\x12\x1a\n" +
+	"\boverride\x18\x03 \x01(\bR\boverride\x12I\n" +
+	"\n" +
+	"overridden\x18\x04 \x03(\v2).reviewer.v1.ManualChange.OverriddenEntryR\n" +
+	"overridden\x121\n" +
+	"\x14seniority_overridden\x18\x05 \x01(\bR\x13seniorityOverridden\x1aV\n" +
+	"\x0fOverriddenEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\v2\x17.reviewer.v1.StringListR\x05value:\x028\x01\"\xa4\x02\n" +
+	"\vExplanation\x127\n" +
+	"\n" +
+	"assignment\x18\x01 \x01(\v2\x17.reviewer.v1.AssignmentR\n" +
+	"assignment\x12#\n" +
+	"\rrequired_tags\x18\x02 \x03(\tR\frequiredTags\x12A\n" +
+	"\x0eseniority_rule\x18\x03 \x01(\v2\x1a.reviewer.v1.SeniorityRuleR\rseniorityRule\x12A\n" +
+	"\n" +
+	"candidates\x18\x04 \x03(\v2!.reviewer.v1.CandidateExplanationR\n" +
+	"candidates\x121\n" +
+	"\x06manual\x18\x05 \x01(\v2\x19.reviewer.v1.ManualChangeR\x06manual\"\x19\n" +
+	"\x17GetReviewerStatsRequest\"\x83\x03\n" +
+	"\fReviewerStat\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\freview_count\x18\x02 \x01(\x03R\vreviewCount\x12*\n" +
+	"\x11open_review_count\x18\x03 \x01(\x03R\x0fopenReviewCount\x12B\n" +
+	"\x1eoldest_open_review_age_seconds\x18\x04 \x01(\x03R\x1aoldestOpenReviewAgeSeconds\x12#\n" +
+	"\rdecline_count\x18\x05 \x01(\x03R\fdeclineCount\x12]\n" +
+	"\x12declines_by_reason\x18\x06 \x03(\v2/.reviewer.v1.ReviewerStat.DeclinesByReasonEntryR\x10declinesByReason\x1aC\n" +
+	"\x15DeclinesByReasonEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"K\n" +
+	"\x18GetReviewerStatsResponse\x12/\n" +
+	"\x05stats\x18\x01 \x03(\v2\x19.reviewer.v1.ReviewerStatR\x05stats\"'\n" +
+	"\rPairMatrixRow\x12\x16\n" +
+	"\x06counts\x18\x01 \x03(\x03R\x06counts\"\xb3\x01\n" +
+	"\n" +
+	"ReviewPair\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x1f\n" +
+	"\vreviewer_id\x18\x02 \x01(\tR\n" +
+	"reviewerId\x12!\n" +
+	"\freview_count\x18\x03 \x01(\x03R\vreviewCount\x12D\n" +
+	"\x10last_assigned_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0elastAssignedAt\"\xa6\x01\n" +
+	"\n" +
+	"PairMatrix\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x18\n" +
+	"\amembers\x18\x02 \x03(\tR\amembers\x122\n" +
+	"\x06matrix\x18\x03 \x03(\v2\x1a.reviewer.v1.PairMatrixRowR\x06matrix\x12-\n" +
+	"\x05pairs\x18\x04 \x03(\v2\x17.reviewer.v1.ReviewPairR\x05pairs\"\x86\x01\n" +
+	"\x13StreamEventsRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12'\n" +
+	"\rlast_event_id\x18\x03 \x01(\x03H\x00R\vlastEventId\x88\x01\x01B\x10\n" +
+	"\x0e_last_event_id\"\x9c\x02\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12&\n" +
+	"\x0fpull_request_id\x18\x05 \x01(\tR\rpullRequestId\x12#\n" +
+	"\rrelated_users\x18\x06 \x03(\tR\frelatedUsers\x121\n" +
+	"\apayload\x18\a \x01(\v2\x17.google.protobuf.StructR\apayload\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt2\x8e\a\n" +
+	"\vTeamService\x12A\n" +
+	"\aAddTeam\x12\x1b.reviewer.v1.AddTeamRequest\x1a\x19.reviewer.v1.TeamResponse\x12A\n" +
+	"\aGetTeam\x12\x1b.reviewer.v1.GetTeamRequest\x1a\x19.reviewer.v1.TeamResponse\x12Y\n" +
+	"\x0eDeactivateTeam\x12\".reviewer.v1.DeactivateTeamRequest\x1a#.reviewer.v1.DeactivateTeamResponse\x12L\n" +
+	"\x10GetSeniorityRule\x12\x1c.reviewer.v1.TeamNameRequest\x1a\x1a.reviewer.v1.SeniorityRule\x12J\n" +
+	"\x10SetSeniorityRule\x12\x1a.reviewer.v1.SeniorityRule\x1a\x1a.reviewer.v1.SeniorityRule\x12D\n" +
+	"\fGetSLAPolicy\x12\x1c.reviewer.v1.TeamNameRequest\x1a\x16.reviewer.v1.SLAPolicy\x12>\n" +
+	"\fSetSLAPolicy\x12\x16.reviewer.v1.SLAPolicy\x1a\x16.reviewer.v1.SLAPolicy\x12J\n" +
+	"\x0fGetTeamSchedule\x12\x1c.reviewer.v1.TeamNameRequest\x1a\x19.reviewer.v1.TeamSchedule\x12G\n" +
+	"\x0fSetTeamSchedule\x12\x19.reviewer.v1.TeamSchedule\x1a\x19.reviewer.v1.TeamSchedule\x12F\n" +
+	"\rGetTeamPolicy\x12\x1c.reviewer.v1.TeamNameRequest\x1a\x17.reviewer.v1.TeamPolicy\x12K\n" +
+	"\rSetTeamPolicy\x12!.reviewer.v1.SetTeamPolicyRequest\x1a\x17.reviewer.v1.TeamPolicy\x12T\n" +
+	"\x14GetTeamPolicyHistory\x12\x1c.reviewer.v1.TeamNameRequest\x1a\x1e.reviewer.v1.TeamPolicyHistory2\x8c\x03\n" +
+	"\vUserService\x12A\n" +
+	"\vSetIsActive\x12\x1f.reviewer.v1.SetIsActiveRequest\x1a\x11.reviewer.v1.User\x12I\n" +
+	"\x0fSetAvailability\x12#.reviewer.v1.SetAvailabilityRequest\x1a\x11.reviewer.v1.User\x12P\n" +
+	"\vSetSchedule\x12\x1f.reviewer.v1.SetScheduleRequest\x1a .reviewer.v1.SetScheduleResponse\x12J\n" +
+	"\tGetReview\x12\x1d.reviewer.v1.GetReviewRequest\x1a\x1e.reviewer.v1.GetReviewResponse\x12Q\n" +
+	"\rDeclineReview\x12!.reviewer.v1.DeclineReviewRequest\x1a\x1d.reviewer.v1.ReassignResponse2\xf8\x04\n" +
+	"\x12PullRequestService\x12b\n" +
+	"\x11CreatePullRequest\x12%.reviewer.v1.CreatePullRequestRequest\x1a&.reviewer.v1.CreatePullRequestResponse\x12N\n" +
+	"\x0eGetPullRequest\x12\".reviewer.v1.GetPullRequestRequest\x1a\x18.reviewer.v1.PullRequest\x12R\n" +
+	"\x10MergePullRequest\x12$.reviewer.v1.MergePullRequestRequest\x1a\x18.reviewer.v1.PullRequest\x12W\n" +
+	"\x10ReassignReviewer\x12$.reviewer.v1.ReassignReviewerRequest\x1a\x1d.reviewer.v1.ReassignResponse\x12P\n" +
+	"\x0fChangeReviewers\x12#.reviewer.v1.ChangeReviewersRequest\x1a\x18.reviewer.v1.PullRequest\x12[\n" +
+	"\x0fListAssignments\x12\".reviewer.v1.GetPullRequestRequest\x1a$.reviewer.v1.ListAssignmentsResponse\x12R\n" +
+	"\x12ExplainPullRequest\x12\".reviewer.v1.GetPullRequestRequest\x1a\x18.reviewer.v1.Explanation2\xb7\x01\n" +
+	"\fStatsService\x12_\n" +
+	"\x10GetReviewerStats\x12$.reviewer.v1.GetReviewerStatsRequest\x1a%.reviewer.v1.GetReviewerStatsResponse\x12F\n" +
+	"\rGetPairMatrix\x12\x1c.reviewer.v1.TeamNameRequest\x1a\x17.reviewer.v1.PairMatrix2V\n" +
+	"\fEventService\x12F\n" +
+	"\fStreamEvents\x12 .reviewer.v1.StreamEventsRequest\x1a\x12.reviewer.v1.Event0\x01B.Z,pr-reviewer-service/internal/grpc/reviewerpbb\x06proto3"
+
+var (
+	file_reviewer_v1_reviewer_proto_rawDescOnce sync.Once
+	file_reviewer_v1_reviewer_proto_rawDescData []byte
+)
+
+func file_reviewer_v1_reviewer_proto_rawDescGZIP() []byte {
+	file_reviewer_v1_reviewer_proto_rawDescOnce.Do(func() {
+		file_reviewer_v1_reviewer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reviewer_v1_reviewer_proto_rawDesc), len(file_reviewer_v1_reviewer_proto_rawDesc)))
+	})
+	return file_reviewer_v1_reviewer_proto_rawDescData
+}
+
+var file_reviewer_v1_reviewer_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
+var file_reviewer_v1_reviewer_proto_goTypes = []any{
+	(*Skill)(nil),                     // 0: reviewer.v1.Skill
+	(*TeamMember)(nil),                // 1: reviewer.v1.TeamMember
+	(*Team)(nil),                      // 2: reviewer.v1.Team
+	(*TeamNameRequest)(nil),           // 3: reviewer.v1.TeamNameRequest
+	(*AddTeamRequest)(nil),            // 4: reviewer.v1.AddTeamRequest
+	(*GetTeamRequest)(nil),            // 5: reviewer.v1.GetTeamRequest
+	(*TeamResponse)(nil),              // 6: reviewer.v1.TeamResponse
+	(*DeactivateTeamRequest)(nil),     // 7: reviewer.v1.DeactivateTeamRequest
+	(*DeactivateTeamResponse)(nil),    // 8: reviewer.v1.DeactivateTeamResponse
+	(*SeniorityRule)(nil),             // 9: reviewer.v1.SeniorityRule
+	(*SLAPolicy)(nil),                 // 10: reviewer.v1.SLAPolicy
+	(*WorkSchedule)(nil),              // 11: reviewer.v1.WorkSchedule
+	(*TeamSchedule)(nil),              // 12: reviewer.v1.TeamSchedule
+	(*TeamPolicy)(nil),                // 13: reviewer.v1.TeamPolicy
+	(*SetTeamPolicyRequest)(nil),      // 14: reviewer.v1.SetTeamPolicyRequest
+	(*TeamPolicyRevision)(nil),        // 15: reviewer.v1.TeamPolicyRevision
+	(*TeamPolicyHistory)(nil),         // 16: reviewer.v1.TeamPolicyHistory
+	(*User)(nil),                      // 17: reviewer.v1.User
+	(*SetIsActiveRequest)(nil),        // 18: reviewer.v1.SetIsActiveRequest
+	(*SetAvailabilityRequest)(nil),    // 19: reviewer.v1.SetAvailabilityRequest
+	(*SetScheduleRequest)(nil),        // 20: reviewer.v1.SetScheduleRequest
+	(*SetScheduleResponse)(nil),       // 21: reviewer.v1.SetScheduleResponse
+	(*GetReviewRequest)(nil),          // 22: reviewer.v1.GetReviewRequest
+	(*PullRequestShort)(nil),          // 23: reviewer.v1.PullRequestShort
+	(*GetReviewResponse)(nil),         // 24: reviewer.v1.GetReviewResponse
+	(*DeclineReviewRequest)(nil),      // 25: reviewer.v1.DeclineReviewRequest
+	(*PullRequest)(nil),               // 26: reviewer.v1.PullRequest
+	(*CreatePullRequestRequest)(nil),  // 27: reviewer.v1.CreatePullRequestRequest
+	(*CreatePullRequestResponse)(nil), // 28: reviewer.v1.CreatePullRequestResponse
+	(*GetPullRequestRequest)(nil),     // 29: reviewer.v1.GetPullRequestRequest
+	(*MergePullRequestRequest)(nil),   // 30: reviewer.v1.MergePullRequestRequest
+	(*ReassignReviewerRequest)(nil),   // 31: reviewer.v1.ReassignReviewerRequest
+	(*ReassignResponse)(nil),          // 32: reviewer.v1.ReassignResponse
+	(*ReviewerReplacement)(nil),       // 33: reviewer.v1.ReviewerReplacement
+	(*ChangeReviewersRequest)(nil),    // 34: reviewer.v1.ChangeReviewersRequest
+	(*Assignment)(nil),                // 35: reviewer.v1.Assignment
+	(*ListAssignmentsResponse)(nil),   // 36: reviewer.v1.ListAssignmentsResponse
+	(*CandidateExplanation)(nil),      // 37: reviewer.v1.CandidateExplanation
+	(*StringList)(nil),                // 38: reviewer.v1.StringList
+	(*ManualChange)(nil),              // 39: reviewer.v1.ManualChange
+	(*Explanation)(nil),               // 40: reviewer.v1.Explanation
+	(*GetReviewerStatsRequest)(nil),   // 41: reviewer.v1.GetReviewerStatsRequest
+	(*ReviewerStat)(nil),              // 42: reviewer.v1.ReviewerStat
+	(*GetReviewerStatsResponse)(nil),  // 43: reviewer.v1.GetReviewerStatsResponse
+	(*PairMatrixRow)(nil),             // 44: reviewer.v1.PairMatrixRow
+	(*ReviewPair)(nil),                // 45: reviewer.v1.ReviewPair
+	(*PairMatrix)(nil),                // 46: reviewer.v1.PairMatrix
+	(*StreamEventsRequest)(nil),       // 47: reviewer.v1.StreamEventsRequest
+	(*Event)(nil),                     // 48: reviewer.v1.Event
+	nil,                               // 49: reviewer.v1.DeactivateTeamResponse.ReassignFailuresEntry
+	nil,                               // 50: reviewer.v1.ManualChange.OverriddenEntry
+	nil,                               // 51: reviewer.v1.ReviewerStat.DeclinesByReasonEntry
+	(*timestamppb.Timestamp)(nil),     // 52: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),           // 53: google.protobuf.Struct
+}
+var file_reviewer_v1_reviewer_proto_depIdxs = []int32{
+	0,  // 0: reviewer.v1.TeamMember.skills:type_name -> reviewer.v1.Skill
+	1,  // 1: reviewer.v1.Team.members:type_name -> reviewer.v1.TeamMember
+	2,  // 2: reviewer.v1.AddTeamRequest.team:type_name -> reviewer.v1.Team
+	2,  // 3: reviewer.v1.TeamResponse.team:type_name -> reviewer.v1.Team
+	49, // 4: reviewer.v1.DeactivateTeamResponse.reassign_failures:type_name -> reviewer.v1.DeactivateTeamResponse.ReassignFailuresEntry
+	11, // 5: reviewer.v1.TeamSchedule.schedule:type_name -> reviewer.v1.WorkSchedule
+	52, // 6: reviewer.v1.TeamPolicy.updated_at:type_name -> google.protobuf.Timestamp
+	13, // 7: reviewer.v1.SetTeamPolicyRequest.policy:type_name -> reviewer.v1.TeamPolicy
+	13, // 8: reviewer.v1.TeamPolicyRevision.policy:type_name -> reviewer.v1.TeamPolicy
+	52, // 9: reviewer.v1.TeamPolicyRevision.changed_at:type_name -> google.protobuf.Timestamp
+	15, // 10: reviewer.v1.TeamPolicyHistory.history:type_name -> reviewer.v1.TeamPolicyRevision
+	52, // 11: reviewer.v1.User.away_until:type_name -> google.protobuf.Timestamp
+	52, // 12: reviewer.v1.SetAvailabilityRequest.away_until:type_name -> google.protobuf.Timestamp
+	11, // 13: reviewer.v1.SetScheduleRequest.schedule:type_name -> reviewer.v1.WorkSchedule
+	11, // 14: reviewer.v1.SetScheduleResponse.schedule:type_name -> reviewer.v1.WorkSchedule
+	52, // 15: reviewer.v1.PullRequestShort.assigned_at:type_name -> google.protobuf.Timestamp
+	23, // 16: reviewer.v1.GetReviewResponse.pull_requests:type_name -> reviewer.v1.PullRequestShort
+	52, // 17: reviewer.v1.PullRequest.created_at:type_name -> google.protobuf.Timestamp
+	52, // 18: reviewer.v1.PullRequest.merged_at:type_name -> google.protobuf.Timestamp
+	26, // 19: reviewer.v1.CreatePullRequestResponse.pr:type_name -> reviewer.v1.PullRequest
+	40, // 20: reviewer.v1.CreatePullRequestResponse.explanation:type_name -> reviewer.v1.Explanation
+	26, // 21: reviewer.v1.ReassignResponse.pr:type_name -> reviewer.v1.PullRequest
+	33, // 22: reviewer.v1.ChangeReviewersRequest.replace:type_name -> reviewer.v1.ReviewerReplacement
+	52, // 23: reviewer.v1.Assignment.created_at:type_name -> google.protobuf.Timestamp
+	35, // 24: reviewer.v1.ListAssignmentsResponse.assignments:type_name -> reviewer.v1.Assignment
+	52, // 25: reviewer.v1.CandidateExplanation.last_paired_at:type_name -> google.protobuf.Timestamp
+	50, // 26: reviewer.v1.ManualChange.overridden:type_name -> reviewer.v1.ManualChange.OverriddenEntry
+	35, // 27: reviewer.v1.Explanation.assignment:type_name -> reviewer.v1.Assignment
+	9,  // 28: reviewer.v1.Explanation.seniority_rule:type_name -> reviewer.v1.SeniorityRule
+	37, // 29: reviewer.v1.Explanation.candidates:type_name -> reviewer.v1.CandidateExplanation
+	39, // 30: reviewer.v1.Explanation.manual:type_name -> reviewer.v1.ManualChange
+	51, // 31: reviewer.v1.ReviewerStat.declines_by_reason:type_name -> reviewer.v1.ReviewerStat.DeclinesByReasonEntry
+	42, // 32: reviewer.v1.GetReviewerStatsResponse.stats:type_name -> reviewer.v1.ReviewerStat
+	52, // 33: reviewer.v1.ReviewPair.last_assigned_at:type_name -> google.protobuf.Timestamp
+	44, // 34: reviewer.v1.PairMatrix.matrix:type_name -> reviewer.v1.PairMatrixRow
+	45, // 35: reviewer.v1.PairMatrix.pairs:type_name -> reviewer.v1.ReviewPair
+	53, // 36: reviewer.v1.Event.payload:type_name -> google.protobuf.Struct
+	52, // 37: reviewer.v1.Event.created_at:type_name -> google.protobuf.Timestamp
+	38, // 38: reviewer.v1.ManualChange.OverriddenEntry.value:type_name -> reviewer.v1.StringList
+	4,  // 39: reviewer.v1.TeamService.AddTeam:input_type -> reviewer.v1.AddTeamRequest
+	5,  // 40: reviewer.v1.TeamService.GetTeam:input_type -> reviewer.v1.GetTeamRequest
+	7,  // 41: reviewer.v1.TeamService.DeactivateTeam:input_type -> reviewer.v1.DeactivateTeamRequest
+	3,  // 42: reviewer.v1.TeamService.GetSeniorityRule:input_type -> reviewer.v1.TeamNameRequest
+	9,  // 43: reviewer.v1.TeamService.SetSeniorityRule:input_type -> reviewer.v1.SeniorityRule
+	3,  // 44: reviewer.v1.TeamService.GetSLAPolicy:input_type -> reviewer.v1.TeamNameRequest
+	10, // 45: reviewer.v1.TeamService.SetSLAPolicy:input_type -> reviewer.v1.SLAPolicy
+	3,  // 46: reviewer.v1.TeamService.GetTeamSchedule:input_type -> reviewer.v1.TeamNameRequest
+	12, // 47: reviewer.v1.TeamService.SetTeamSchedule:input_type -> reviewer.v1.TeamSchedule
+	3,  // 48: reviewer.v1.TeamService.GetTeamPolicy:input_type -> reviewer.v1.TeamNameRequest
+	14, // 49: reviewer.v1.TeamService.SetTeamPolicy:input_type -> reviewer.v1.SetTeamPolicyRequest
+	3,  // 50: reviewer.v1.TeamService.GetTeamPolicyHistory:input_type -> reviewer.v1.TeamNameRequest
+	18, // 51: reviewer.v1.UserService.SetIsActive:input_type -> reviewer.v1.SetIsActiveRequest
+	19, // 52: reviewer.v1.UserService.SetAvailability:input_type -> reviewer.v1.SetAvailabilityRequest
+	20, // 53: reviewer.v1.UserService.SetSchedule:input_type -> reviewer.v1.SetScheduleRequest
+	22, // 54: reviewer.v1.UserService.GetReview:input_type -> reviewer.v1.GetReviewRequest
+	25, // 55: reviewer.v1.UserService.DeclineReview:input_type -> reviewer.v1.DeclineReviewRequest
+	27, // 56: reviewer.v1.PullRequestService.CreatePullRequest:input_type -> reviewer.v1.CreatePullRequestRequest
+	29, // 57: reviewer.v1.PullRequestService.GetPullRequest:input_type -> reviewer.v1.GetPullRequestRequest
+	30, // 58: reviewer.v1.PullRequestService.MergePullRequest:input_type -> reviewer.v1.MergePullRequestRequest
+	31, // 59: reviewer.v1.PullRequestService.ReassignReviewer:input_type -> reviewer.v1.ReassignReviewerRequest
+	34, // 60: reviewer.v1.PullRequestService.ChangeReviewers:input_type -> reviewer.v1.ChangeReviewersRequest
+	29, // 61: reviewer.v1.PullRequestService.ListAssignments:input_type -> reviewer.v1.GetPullRequestRequest
+	29, // 62: reviewer.v1.PullRequestService.ExplainPullRequest:input_type -> reviewer.v1.GetPullRequestRequest
+	41, // 63: reviewer.v1.StatsService.GetReviewerStats:input_type -> reviewer.v1.GetReviewerStatsRequest
+	3,  // 64: reviewer.v1.StatsService.GetPairMatrix:input_type -> reviewer.v1.TeamNameRequest
+	47, // 65: reviewer.v1.EventService.StreamEvents:input_type -> reviewer.v1.StreamEventsRequest
+	6,  // 66: reviewer.v1.TeamService.AddTeam:output_type -> reviewer.v1.TeamResponse
+	6,  // 67: reviewer.v1.TeamService.GetTeam:output_type -> reviewer.v1.TeamResponse
+	8,  // 68: reviewer.v1.TeamService.DeactivateTeam:output_type -> reviewer.v1.DeactivateTeamResponse
+	9,  // 69: reviewer.v1.TeamService.GetSeniorityRule:output_type -> reviewer.v1.SeniorityRule
+	9,  // 70: reviewer.v1.TeamService.SetSeniorityRule:output_type -> reviewer.v1.SeniorityRule
+	10, // 71: reviewer.v1.TeamService.GetSLAPolicy:output_type -> reviewer.v1.SLAPolicy
+	10, // 72: reviewer.v1.TeamService.SetSLAPolicy:output_type -> reviewer.v1.SLAPolicy
+	12, // 73: reviewer.v1.TeamService.GetTeamSchedule:output_type -> reviewer.v1.TeamSchedule
+	12, // 74: reviewer.v1.TeamService.SetTeamSchedule:output_type -> reviewer.v1.TeamSchedule
+	13, // 75: reviewer.v1.TeamService.GetTeamPolicy:output_type -> reviewer.v1.TeamPolicy
+	13, // 76: reviewer.v1.TeamService.SetTeamPolicy:output_type -> reviewer.v1.TeamPolicy
+	16, // 77: reviewer.v1.TeamService.GetTeamPolicyHistory:output_type -> reviewer.v1.TeamPolicyHistory
+	17, // 78: reviewer.v1.UserService.SetIsActive:output_type -> reviewer.v1.User
+	17, // 79: reviewer.v1.UserService.SetAvailability:output_type -> reviewer.v1.User
+	21, // 80: reviewer.v1.UserService.SetSchedule:output_type -> reviewer.v1.SetScheduleResponse
+	24, // 81: reviewer.v1.UserService.GetReview:output_type -> reviewer.v1.GetReviewResponse
+	32, // 82: reviewer.v1.UserService.DeclineReview:output_type -> reviewer.v1.ReassignResponse
+	28, // 83: reviewer.v1.PullRequestService.CreatePullRequest:output_type -> reviewer.v1.CreatePullRequestResponse
+	26, // 84: reviewer.v1.PullRequestService.GetPullRequest:output_type -> reviewer.v1.PullRequest
+	26, // 85: reviewer.v1.PullRequestService.MergePullRequest:output_type -> reviewer.v1.PullRequest
+	32, // 86: reviewer.v1.PullRequestService.ReassignReviewer:output_type -> reviewer.v1.ReassignResponse
+	26, // 87: reviewer.v1.PullRequestService.ChangeReviewers:output_type -> reviewer.v1.PullRequest
+	36, // 88: reviewer.v1.PullRequestService.ListAssignments:output_type -> reviewer.v1.ListAssignmentsResponse
+	40, // 89: reviewer.v1.PullRequestService.ExplainPullRequest:output_type -> reviewer.v1.Explanation
+	43, // 90: reviewer.v1.StatsService.GetReviewerStats:output_type -> reviewer.v1.GetReviewerStatsResponse
+	46, // 91: reviewer.v1.StatsService.GetPairMatrix:output_type -> reviewer.v1.PairMatrix
+	48, // 92: reviewer.v1.EventService.StreamEvents:output_type -> reviewer.v1.Event
+	66, // [66:93] is the sub-list for method output_type
+	39, // [39:66] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
+}
+
+func init() { file_reviewer_v1_reviewer_proto_init() }
+func file_reviewer_v1_reviewer_proto_init() {
+	if File_reviewer_v1_reviewer_proto != nil {
+		return
+	}
+	file_reviewer_v1_reviewer_proto_msgTypes[10].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[11].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[13].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[17].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[19].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[23].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[35].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[47].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reviewer_v1_reviewer_proto_rawDesc), len(file_reviewer_v1_reviewer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   52,
+			NumExtensions: 0,
+			NumServices:   5,
+		},
+		GoTypes:           file_reviewer_v1_reviewer_proto_goTypes,
+		DependencyIndexes: file_reviewer_v1_reviewer_proto_depIdxs,
+		MessageInfos:      file_reviewer_v1_reviewer_proto_msgTypes,
+	}.Build()
+	File_reviewer_v1_reviewer_proto = out.File
+	file_reviewer_v1_reviewer_proto_goTypes = nil
+	file_reviewer_v1_reviewer_proto_depIdxs = nil
+}
@@ -0,0 +1,93 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+
+	"pr-reviewer-service/internal/events"
+	pb "pr-reviewer-service/internal/grpc/reviewerpb"
+	"pr-reviewer-service/internal/storage/repo"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type server struct {
+	store  *repo.Store
+	events *events.Broker
+	log    *slog.Logger
+}
+
+type teamServer struct {
+	pb.UnimplementedTeamServiceServer
+	*server
+}
+
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+	*server
+}
+
+type pullRequestServer struct {
+	pb.UnimplementedPullRequestServiceServer
+	*server
+}
+
+type statsServer struct {
+	pb.UnimplementedStatsServiceServer
+	*server
+}
+
+type eventServer struct {
+	pb.UnimplementedEventServiceServer
+	*server
+}
+
+// NewServer регистрирует все сервисы и reflection (для grpcurl) на новом grpc.Server.
+func NewServer(store *repo.Store, broker *events.Broker, log *slog.Logger) *grpc.Server {
+	s := &server{store: store, events: broker, log: log}
+
+	g := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.unaryErrors),
+		grpc.ChainStreamInterceptor(s.streamErrors),
+	)
+	pb.RegisterTeamServiceServer(g, teamServer{server: s})
+	pb.RegisterUserServiceServer(g, userServer{server: s})
+	pb.RegisterPullRequestServiceServer(g, pullRequestServer{server: s})
+	pb.RegisterStatsServiceServer(g, statsServer{server: s})
+	pb.RegisterEventServiceServer(g, eventServer{server: s})
+	reflection.Register(g)
+	return g
+}
+
+// unaryErrors переводит ошибки хранилища в статусы gRPC и, как middleware.Recoverer
+// в HTTP, не даёт панике в обработчике уронить процесс.
+func (s *server) unaryErrors(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			s.log.Error("grpc panic", slog.String("method", info.FullMethod), slog.Any("panic", p), slog.String("stack", string(debug.Stack())))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	resp, err = handler(ctx, req)
+	if err != nil {
+		err = s.toStatus(info.FullMethod, err)
+	}
+	return resp, err
+}
+
+func (s *server) streamErrors(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			s.log.Error("grpc panic", slog.String("method", info.FullMethod), slog.Any("panic", p), slog.String("stack", string(debug.Stack())))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	if err = handler(srv, ss); err != nil {
+		err = s.toStatus(info.FullMethod, err)
+	}
+	return err
+}
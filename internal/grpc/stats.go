@@ -0,0 +1,49 @@
+package grpcapi
+
+import (
+	"context"
+
+	pb "pr-reviewer-service/internal/grpc/reviewerpb"
+)
+
+func (s statsServer) GetReviewerStats(ctx context.Context, _ *pb.GetReviewerStatsRequest) (*pb.GetReviewerStatsResponse, error) {
+	stats, err := s.store.GetReviewerAssignmentStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.GetReviewerStatsResponse{}
+	for _, st := range stats {
+		res.Stats = append(res.Stats, &pb.ReviewerStat{
+			UserId:                     st.UserID,
+			ReviewCount:                st.Count,
+			OpenReviewCount:            st.OpenCount,
+			OldestOpenReviewAgeSeconds: st.OldestOpenAgeSeconds,
+			DeclineCount:               st.DeclineCount,
+			DeclinesByReason:           st.DeclinesByReason,
+		})
+	}
+	return res, nil
+}
+
+func (s statsServer) GetPairMatrix(ctx context.Context, req *pb.TeamNameRequest) (*pb.PairMatrix, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name required")
+	}
+	m, err := s.store.GetPairMatrix(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.PairMatrix{TeamName: m.TeamName, Members: m.Members}
+	for _, row := range m.Matrix {
+		res.Matrix = append(res.Matrix, &pb.PairMatrixRow{Counts: row})
+	}
+	for _, p := range m.Pairs {
+		res.Pairs = append(res.Pairs, &pb.ReviewPair{
+			AuthorId:       p.AuthorID,
+			ReviewerId:     p.ReviewerID,
+			ReviewCount:    p.Count,
+			LastAssignedAt: timestamp(&p.LastAssignedAt),
+		})
+	}
+	return res, nil
+}
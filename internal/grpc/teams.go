@@ -0,0 +1,209 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	pb "pr-reviewer-service/internal/grpc/reviewerpb"
+	"pr-reviewer-service/internal/lib/worktime"
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+func (s teamServer) AddTeam(ctx context.Context, req *pb.AddTeamRequest) (*pb.TeamResponse, error) {
+	if req.GetTeam().GetTeamName() == "" {
+		return nil, invalidArgument("team.team_name required")
+	}
+	t := teamFromPB(req.GetTeam())
+	for _, m := range t.Members {
+		if m.UserID == "" {
+			return nil, invalidArgument("user_id required for every member")
+		}
+		if m.Level != "" && !repo.ValidLevel(m.Level) {
+			return nil, invalidArgument("unknown level for user " + m.UserID)
+		}
+	}
+
+	version, err := s.store.CreateTeam(ctx, t, req.GetExpectedVersion())
+	if err != nil {
+		return nil, err
+	}
+	t.Version = version
+	return &pb.TeamResponse{Team: teamToPB(t)}, nil
+}
+
+func (s teamServer) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.TeamResponse, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name required")
+	}
+	t, err := s.store.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TeamResponse{Team: teamToPB(t)}, nil
+}
+
+func (s teamServer) DeactivateTeam(ctx context.Context, req *pb.DeactivateTeamRequest) (*pb.DeactivateTeamResponse, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name required")
+	}
+	deactivate := s.store.BulkDeactivateTeam
+	if req.GetDryRun() {
+		deactivate = s.store.PreviewDeactivateTeam
+	}
+	res, err := deactivate(ctx, req.GetTeamName(), req.GetExpectedVersion())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeactivateTeamResponse{
+		TeamName:           res.TeamName,
+		DeactivatedUsers:   res.DeactivatedUsers,
+		ReassignedPrsCount: int32(res.ReassignedPRsCount),
+		ReassignFailures:   res.ReassignFailures,
+		Version:            res.Version,
+		DryRun:             res.DryRun,
+	}, nil
+}
+
+func (s teamServer) GetSeniorityRule(ctx context.Context, req *pb.TeamNameRequest) (*pb.SeniorityRule, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name required")
+	}
+	rule, err := s.store.GetSeniorityRule(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	return ruleToPB(rule), nil
+}
+
+func (s teamServer) SetSeniorityRule(ctx context.Context, req *pb.SeniorityRule) (*pb.SeniorityRule, error) {
+	rule := repo.SeniorityRule{TeamName: req.GetTeamName(), MinLevel: req.GetMinLevel(), MinCount: int(req.GetMinCount())}
+	if rule.TeamName == "" {
+		return nil, invalidArgument("team_name required")
+	}
+	if rule.MinLevel == "" {
+		rule.MinLevel = repo.LevelSenior
+	}
+	rule, err := s.store.SetSeniorityRule(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+	return ruleToPB(rule), nil
+}
+
+func (s teamServer) GetSLAPolicy(ctx context.Context, req *pb.TeamNameRequest) (*pb.SLAPolicy, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name required")
+	}
+	p, err := s.store.GetSLAPolicy(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	return slaToPB(p), nil
+}
+
+func (s teamServer) SetSLAPolicy(ctx context.Context, req *pb.SLAPolicy) (*pb.SLAPolicy, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name required")
+	}
+	p, err := s.store.SetSLAPolicy(ctx, repo.SLAPolicy{
+		TeamName:           req.GetTeamName(),
+		ReminderAfterHours: intPtr(req.ReminderAfterHours),
+		EscalateAfterHours: intPtr(req.EscalateAfterHours),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slaToPB(p), nil
+}
+
+func (s teamServer) GetTeamSchedule(ctx context.Context, req *pb.TeamNameRequest) (*pb.TeamSchedule, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name required")
+	}
+	ts, err := s.store.GetTeamSchedule(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TeamSchedule{TeamName: ts.TeamName, Schedule: scheduleToPB(ts.WorkSchedule), Holidays: ts.Holidays}, nil
+}
+
+func (s teamServer) SetTeamSchedule(ctx context.Context, req *pb.TeamSchedule) (*pb.TeamSchedule, error) {
+	ts := repo.TeamSchedule{TeamName: req.GetTeamName(), WorkSchedule: scheduleFromPB(req.GetSchedule()), Holidays: req.GetHolidays()}
+	if ts.TeamName == "" {
+		return nil, invalidArgument("team_name required")
+	}
+	if err := ts.Validate(); err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+	for _, day := range ts.Holidays {
+		if _, err := time.Parse(worktime.DateLayout, day); err != nil {
+			return nil, invalidArgument("holidays must be dates in YYYY-MM-DD format")
+		}
+	}
+
+	ts, err := s.store.SetTeamSchedule(ctx, ts)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TeamSchedule{TeamName: ts.TeamName, Schedule: scheduleToPB(ts.WorkSchedule), Holidays: ts.Holidays}, nil
+}
+
+func (s teamServer) GetTeamPolicy(ctx context.Context, req *pb.TeamNameRequest) (*pb.TeamPolicy, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name required")
+	}
+	p, err := s.store.GetTeamPolicy(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	return policyToPB(p), nil
+}
+
+func (s teamServer) SetTeamPolicy(ctx context.Context, req *pb.SetTeamPolicyRequest) (*pb.TeamPolicy, error) {
+	in := req.GetPolicy()
+	if in.GetTeamName() == "" {
+		return nil, invalidArgument("policy.team_name required")
+	}
+
+	// как и PUT /team/policy, запрос заменяет политику целиком
+	p := repo.DefaultPolicy(in.GetTeamName())
+	if in.ReviewerCount != nil {
+		p.ReviewerCount = int(in.GetReviewerCount())
+	}
+	if in.GetMinLevel() != "" {
+		p.MinLevel = in.GetMinLevel()
+	}
+	if len(in.GetFallbackTeams()) > 0 {
+		p.FallbackTeams = in.GetFallbackTeams()
+	}
+	p.Strategy = in.Strategy
+	p.MinCount = int(in.GetMinCount())
+	p.DefaultMaxOpenReviews = intPtr(in.DefaultMaxOpenReviews)
+	p.ReminderAfterHours, p.EscalateAfterHours = intPtr(in.ReminderAfterHours), intPtr(in.EscalateAfterHours)
+	p.AllowMergedChanges = in.GetAllowMergedChanges()
+
+	p, err := s.store.SetTeamPolicy(ctx, p, req.GetExpectedVersion())
+	if err != nil {
+		return nil, err
+	}
+	return policyToPB(p), nil
+}
+
+func (s teamServer) GetTeamPolicyHistory(ctx context.Context, req *pb.TeamNameRequest) (*pb.TeamPolicyHistory, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name required")
+	}
+	history, err := s.store.GetPolicyHistory(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.TeamPolicyHistory{TeamName: req.GetTeamName()}
+	for _, r := range history {
+		res.History = append(res.History, &pb.TeamPolicyRevision{
+			Version:   r.Version,
+			Policy:    policyToPB(r.Policy),
+			ChangedAt: timestamp(&r.ChangedAt),
+		})
+	}
+	return res, nil
+}
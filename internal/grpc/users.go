@@ -0,0 +1,81 @@
+package grpcapi
+
+import (
+	"context"
+
+	pb "pr-reviewer-service/internal/grpc/reviewerpb"
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+func (s userServer) SetIsActive(ctx context.Context, req *pb.SetIsActiveRequest) (*pb.User, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id required")
+	}
+	u, err := s.store.SetUserActive(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		return nil, err
+	}
+	return userToPB(u), nil
+}
+
+func (s userServer) SetAvailability(ctx context.Context, req *pb.SetAvailabilityRequest) (*pb.User, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id required")
+	}
+	if req.MaxOpenReviews != nil && req.GetMaxOpenReviews() < 0 {
+		return nil, invalidArgument("max_open_reviews must be non-negative")
+	}
+	u, err := s.store.SetAvailability(ctx, req.GetUserId(), timeFrom(req.GetAwayUntil()), intPtr(req.MaxOpenReviews))
+	if err != nil {
+		return nil, err
+	}
+	return userToPB(u), nil
+}
+
+func (s userServer) SetSchedule(ctx context.Context, req *pb.SetScheduleRequest) (*pb.SetScheduleResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id required")
+	}
+	us := repo.UserSchedule{WorkSchedule: scheduleFromPB(req.GetSchedule()), Holidays: req.GetHolidays()}
+	if err := us.Validate(); err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+	us, err := s.store.SetUserSchedule(ctx, req.GetUserId(), us)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetScheduleResponse{UserId: req.GetUserId(), Schedule: scheduleToPB(us.WorkSchedule), Holidays: us.Holidays}, nil
+}
+
+func (s userServer) GetReview(ctx context.Context, req *pb.GetReviewRequest) (*pb.GetReviewResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id required")
+	}
+	prs, err := s.store.GetPRsForReviewer(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.GetReviewResponse{UserId: req.GetUserId()}
+	for _, pr := range prs {
+		res.PullRequests = append(res.PullRequests, prShortToPB(pr))
+	}
+	return res, nil
+}
+
+func (s userServer) DeclineReview(ctx context.Context, req *pb.DeclineReviewRequest) (*pb.ReassignResponse, error) {
+	if req.GetUserId() == "" || req.GetPullRequestId() == "" {
+		return nil, invalidArgument("user_id and pull_request_id required")
+	}
+	if !repo.ValidDeclineReason(req.GetReason()) {
+		return nil, invalidArgument("unknown decline reason")
+	}
+	newID, err := s.store.DeclineReview(ctx, req.GetPullRequestId(), req.GetUserId(), req.GetReason(), req.GetComment(), req.GetExpectedVersion())
+	if err != nil {
+		return nil, err
+	}
+	pr, err := s.store.GetPR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReassignResponse{Pr: prToPB(pr), ReplacedBy: newID}, nil
+}
@@ -0,0 +1,97 @@
+package gqlapi
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// listFactor — сколько элементов в среднем ожидается в поле-списке: стоимость
+// вложенных полей списка умножается на него.
+const listFactor = 10
+
+// complexity оценивает стоимость запроса до выполнения: каждое поле стоит 1,
+// поля внутри списков — в listFactor раз дороже на каждый уровень вложенности.
+// Фрагменты раскрываются; циклические ссылки отсекаются (их отвергнет валидация).
+func complexity(schema graphql.Schema, doc *ast.Document, operationName string) int {
+	fragments := map[string]*ast.FragmentDefinition{}
+	var op *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch d := def.(type) {
+		case *ast.FragmentDefinition:
+			fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if op == nil && (operationName == "" || d.Name != nil && d.Name.Value == operationName) {
+				op = d
+			}
+		}
+	}
+	if op == nil {
+		return 0
+	}
+
+	c := costWalker{schema: schema, fragments: fragments, visiting: map[string]bool{}}
+	var root graphql.Type = schema.QueryType()
+	if op.Operation == ast.OperationTypeMutation {
+		root = schema.MutationType()
+	}
+	return c.selections(op.SelectionSet, root)
+}
+
+type costWalker struct {
+	schema    graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	visiting  map[string]bool
+}
+
+func (c *costWalker) selections(set *ast.SelectionSet, parent graphql.Type) int {
+	if set == nil {
+		return 0
+	}
+	total := 0
+	for _, sel := range set.Selections {
+		switch s := sel.(type) {
+		case *ast.Field:
+			total += c.field(s, parent)
+		case *ast.InlineFragment:
+			t := parent
+			if s.TypeCondition != nil {
+				t = c.schema.Type(s.TypeCondition.Name.Value)
+			}
+			total += c.selections(s.SelectionSet, t)
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			f, ok := c.fragments[name]
+			if !ok || c.visiting[name] {
+				continue
+			}
+			c.visiting[name] = true
+			total += c.selections(f.SelectionSet, c.schema.Type(f.TypeCondition.Name.Value))
+			c.visiting[name] = false
+		}
+	}
+	return total
+}
+
+func (c *costWalker) field(f *ast.Field, parent graphql.Type) int {
+	var fieldType graphql.Type
+	if obj, ok := parent.(*graphql.Object); ok && obj != nil {
+		if def, ok := obj.Fields()[f.Name.Value]; ok {
+			fieldType = def.Type
+		}
+	}
+
+	factor := 1
+	for {
+		switch t := fieldType.(type) {
+		case *graphql.NonNull:
+			fieldType = t.OfType
+			continue
+		case *graphql.List:
+			factor *= listFactor
+			fieldType = t.OfType
+			continue
+		}
+		break
+	}
+	return 1 + factor*c.selections(f.SelectionSet, fieldType)
+}
@@ -0,0 +1,88 @@
+package gqlapi
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestComplexity(t *testing.T) {
+	schema, err := newSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		operation string
+		want      int
+	}{
+		{"scalar fields", `{ team(team_name: "t") { team_name version } }`, "", 3},
+		{"list multiplies nested fields", `{ team(team_name: "t") { members { user_id } } }`, "", 1 + (1 + listFactor*1)},
+		{"nested lists multiply", `{ team(team_name: "t") { members { open_reviews { pull_request_id } } } }`, "",
+			1 + (1 + listFactor*(1+listFactor*1))},
+		{"root list", `{ reviewer_stats { user_id review_count } }`, "", 1 + listFactor*2},
+		{"fragment spread", `{ team(team_name: "t") { ...M } } fragment M on Team { members { user_id } }`, "", 1 + (1 + listFactor*1)},
+		{"fragment used twice", `{ a: team(team_name: "a") { ...M } b: team(team_name: "b") { ...M } } fragment M on Team { members { user_id } }`, "",
+			2 * (1 + (1 + listFactor*1))},
+		{"nested fragments", `{ team(team_name: "t") { ...T } } fragment T on Team { members { ...U } } fragment U on User { user_id username }`, "",
+			1 + (1 + listFactor*2)},
+		{"inline fragment", `{ team(team_name: "t") { ... on Team { team_name } } }`, "", 2},
+		{"cyclic fragment is cut", `{ team(team_name: "t") { ...A } } fragment A on Team { team_name ...A }`, "", 2},
+		{"named operation", `query A { team(team_name: "t") { team_name } } query B { reviewer_stats { user_id } }`, "B", 1 + listFactor*1},
+		{"unknown operation", `query A { team(team_name: "t") { team_name } }`, "C", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := complexity(schema, doc, tt.operation); got != tt.want {
+				t.Errorf("complexity = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComplexityLimit(t *testing.T) {
+	// members { open_reviews { pull_request_id } } стоит 112
+	query := `{ team(team_name: "backend") { members { open_reviews { pull_request_id } } } }`
+
+	tests := []struct {
+		name     string
+		limit    int
+		executed bool
+	}{
+		{"under limit", 200, true},
+		{"at limit", 112, true},
+		{"over limit", 111, false},
+		{"default limit", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newStubStore()
+			svc := New(store, slog.New(slog.NewTextHandler(io.Discard, nil)), tt.limit)
+			res, executed := svc.Execute(context.Background(), Request{Query: query})
+			if executed != tt.executed {
+				t.Fatalf("executed=%v, want %v: %v", executed, tt.executed, res.Errors)
+			}
+			if executed {
+				return
+			}
+			if len(store.calls) > 0 {
+				t.Errorf("rejected query reached the store: %v", store.calls)
+			}
+			if len(res.Errors) != 1 || res.Errors[0].Extensions["code"] != CodeTooComplex {
+				t.Fatalf("errors %v, want one %s", res.Errors, CodeTooComplex)
+			}
+			if got := res.Errors[0].Extensions["complexity"]; got != 112 {
+				t.Errorf("reported complexity %v, want 112", got)
+			}
+		})
+	}
+}
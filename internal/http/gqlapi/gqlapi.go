@@ -0,0 +1,161 @@
+// Package gqlapi — GraphQL-схема для дашбордов: команды, участники, открытые
+// ревью, PR и статистика ревьюверов с вложенными связями. Вложенные поля
+// загружаются пакетами на уровень запроса (см. loader), поэтому число запросов
+// к базе зависит от глубины запроса, а не от количества объектов в ответе.
+package gqlapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"pr-reviewer-service/internal/lib/logger"
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Коды в extensions.code ошибок ответа; ошибки хранилища передают свой код (NOT_FOUND и т.п.).
+const (
+	CodeInvalidQuery = "GRAPHQL_VALIDATION_FAILED"
+	CodeTooComplex   = "QUERY_TOO_COMPLEX"
+	CodeInternal     = "INTERNAL"
+	CodeTimeout      = "TIMEOUT"
+	CodeUnavailable  = "UNAVAILABLE"
+)
+
+// DefaultComplexity — предел сложности запроса, если он не задан в конфиге.
+const DefaultComplexity = 5000
+
+type Request struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables"`
+	OperationName string         `json:"operationName"`
+}
+
+// Store — операции хранилища, которые нужны резолверам; *repo.Store его реализует.
+type Store interface {
+	TeamsByName(ctx context.Context, names []string) (map[string]repo.Team, error)
+	UsersByID(ctx context.Context, ids []string) (map[string]repo.User, error)
+	UsersByTeam(ctx context.Context, teams []string) (map[string][]repo.User, error)
+	PullRequestsByID(ctx context.Context, ids []string) (map[string]repo.PullRequest, error)
+	OpenReviewsByReviewer(ctx context.Context, userIDs []string) (map[string][]repo.OpenReview, error)
+	GetReviewerAssignmentStats(ctx context.Context) ([]repo.ReviewerStat, error)
+}
+
+type Service struct {
+	schema        graphql.Schema
+	store         Store
+	log           *slog.Logger
+	maxComplexity int
+}
+
+// New собирает схему; maxComplexity <= 0 — значение по умолчанию.
+// Схема статична, поэтому ошибка её сборки — ошибка в коде, и New паникует.
+func New(store Store, log *slog.Logger, maxComplexity int) *Service {
+	schema, err := newSchema()
+	if err != nil {
+		panic(fmt.Sprintf("gqlapi: build schema: %v", err))
+	}
+	if maxComplexity <= 0 {
+		maxComplexity = DefaultComplexity
+	}
+	return &Service{schema: schema, store: store, log: log, maxComplexity: maxComplexity}
+}
+
+// Execute разбирает, проверяет и выполняет запрос. executed=false — запрос
+// отвергнут до выполнения (синтаксис, валидация, сложность).
+func (s *Service) Execute(ctx context.Context, req Request) (res *graphql.Result, executed bool) {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &graphql.Result{Errors: withCode(gqlerrors.FormatErrors(err), CodeInvalidQuery)}, false
+	}
+	if vr := graphql.ValidateDocument(&s.schema, doc, nil); !vr.IsValid {
+		return &graphql.Result{Errors: withCode(vr.Errors, CodeInvalidQuery)}, false
+	}
+	if cost := complexity(s.schema, doc, req.OperationName); cost > s.maxComplexity {
+		e := gqlerrors.NewFormattedError(fmt.Sprintf("query complexity %d exceeds limit %d", cost, s.maxComplexity))
+		e.Extensions = map[string]any{"code": CodeTooComplex, "complexity": cost, "max_complexity": s.maxComplexity}
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{e}}, false
+	}
+
+	res = graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx, newLoaders(s.store)),
+	})
+	for i := range res.Errors {
+		s.classify(ctx, &res.Errors[i])
+	}
+	return res, true
+}
+
+func withCode(errs []gqlerrors.FormattedError, code string) []gqlerrors.FormattedError {
+	for i := range errs {
+		errs[i].Extensions = map[string]any{"code": code}
+	}
+	return errs
+}
+
+// classify проставляет extensions.code ошибке выполнения. Как и в REST,
+// ожидаемые ошибки хранилища отдаются с кодом и текстом, внутренние — только
+// пишутся в лог с request id, а клиент видит «internal error».
+func (s *Service) classify(ctx context.Context, fe *gqlerrors.FormattedError) {
+	cause := rootCause(*fe)
+
+	var re *repo.Error
+	var gqlErr *gqlerrors.Error
+	var connErr *pgconn.ConnectError
+	switch {
+	case errors.As(cause, &re):
+		fe.Message, fe.Extensions = cause.Error(), map[string]any{"code": re.Code}
+		return
+	case errors.As(cause, &gqlErr):
+		// ошибки самой библиотеки: неверные переменные и т.п.
+		fe.Extensions = map[string]any{"code": CodeInvalidQuery}
+		return
+	case errors.Is(cause, context.DeadlineExceeded), pgconn.Timeout(cause):
+		fe.Message, fe.Extensions = "request timed out", map[string]any{"code": CodeTimeout}
+	case errors.As(cause, &connErr):
+		fe.Message, fe.Extensions = "storage unavailable", map[string]any{"code": CodeUnavailable}
+	default:
+		fe.Message, fe.Extensions = "internal error", map[string]any{"code": CodeInternal}
+	}
+
+	s.log.Error("graphql field failed",
+		slog.String("request_id", middleware.GetReqID(ctx)),
+		slog.Any("path", fe.Path),
+		logger.Err(cause),
+	)
+}
+
+// rootCause снимает обёртки, которыми graphql-go оборачивает ошибки резолверов.
+func rootCause(err error) error {
+	for {
+		switch e := err.(type) {
+		case gqlerrors.FormattedError:
+			if e.OriginalError() == nil {
+				return e
+			}
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			if e.OriginalError == nil {
+				return e
+			}
+			err = e.OriginalError
+		default:
+			return err
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package gqlapi
+
+import (
+	"context"
+	"sync"
+
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+// loader копит ключи, запрошенные резолверами одного уровня запроса, и загружает
+// их одним обращением к хранилищу. Резолвер регистрирует ключ и возвращает thunk;
+// graphql-go вызывает thunk'и в ширину, поэтому к моменту первого вызова все
+// соседние ключи уже собраны. Результаты кешируются на время запроса.
+type loader[V any] struct {
+	fetch func(ctx context.Context, keys []string) (map[string]V, error)
+
+	mu      sync.Mutex
+	pending []string
+	queued  map[string]bool
+	done    map[string]V
+	failed  map[string]error
+}
+
+func newLoader[V any](fetch func(ctx context.Context, keys []string) (map[string]V, error)) *loader[V] {
+	return &loader[V]{fetch: fetch, queued: map[string]bool{}, done: map[string]V{}, failed: map[string]error{}}
+}
+
+// load возвращает thunk, который отдаёт значение по ключу; ok=false — ключ не найден.
+func (l *loader[V]) load(ctx context.Context, key string) func() (V, bool, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, bool, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if len(l.pending) > 0 {
+			l.flush(ctx)
+		}
+		if err := l.failed[key]; err != nil {
+			var zero V
+			return zero, false, err
+		}
+		v, ok := l.done[key]
+		return v, ok, nil
+	}
+}
+
+// flush загружает все накопленные ключи; вызывается под l.mu.
+func (l *loader[V]) flush(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+	res, err := l.fetch(ctx, keys)
+	for _, k := range keys {
+		if err != nil {
+			l.failed[k] = err
+			continue
+		}
+		if v, ok := res[k]; ok {
+			l.done[k] = v
+		}
+	}
+}
+
+// loaders — набор загрузчиков одного GraphQL-запроса.
+type loaders struct {
+	teams       *loader[repo.Team]
+	users       *loader[repo.User]
+	members     *loader[[]repo.User]
+	pullReqs    *loader[repo.PullRequest]
+	openReviews *loader[[]repo.OpenReview]
+
+	statsOnce sync.Once
+	stats     map[string]repo.ReviewerStat
+	statsList []repo.ReviewerStat
+	statsErr  error
+	store     Store
+}
+
+func newLoaders(s Store) *loaders {
+	return &loaders{
+		teams:       newLoader(s.TeamsByName),
+		users:       newLoader(s.UsersByID),
+		members:     newLoader(s.UsersByTeam),
+		pullReqs:    newLoader(s.PullRequestsByID),
+		openReviews: newLoader(s.OpenReviewsByReviewer),
+		store:       s,
+	}
+}
+
+// reviewerStats считает статистику один раз на запрос: она агрегирует все
+// назначения, и выборка по одному пользователю не дешевле полной.
+func (l *loaders) reviewerStats(ctx context.Context) ([]repo.ReviewerStat, map[string]repo.ReviewerStat, error) {
+	l.statsOnce.Do(func() {
+		l.statsList, l.statsErr = l.store.GetReviewerAssignmentStats(ctx)
+		l.stats = make(map[string]repo.ReviewerStat, len(l.statsList))
+		for _, st := range l.statsList {
+			l.stats[st.UserID] = st
+		}
+	})
+	return l.statsList, l.stats, l.statsErr
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,220 @@
+package gqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+// stubStore отдаёт данные из памяти и запоминает ключи каждого вызова.
+type stubStore struct {
+	teams   map[string]repo.Team
+	users   map[string]repo.User
+	prs     map[string]repo.PullRequest
+	reviews map[string][]repo.OpenReview
+
+	mu    sync.Mutex
+	calls map[string][][]string
+}
+
+func (s *stubStore) record(method string, keys []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.calls == nil {
+		s.calls = map[string][][]string{}
+	}
+	keys = slices.Clone(keys)
+	sort.Strings(keys)
+	s.calls[method] = append(s.calls[method], keys)
+}
+
+func (s *stubStore) TeamsByName(_ context.Context, names []string) (map[string]repo.Team, error) {
+	s.record("TeamsByName", names)
+	return pick(s.teams, names), nil
+}
+
+func (s *stubStore) UsersByID(_ context.Context, ids []string) (map[string]repo.User, error) {
+	s.record("UsersByID", ids)
+	return pick(s.users, ids), nil
+}
+
+func (s *stubStore) UsersByTeam(_ context.Context, teams []string) (map[string][]repo.User, error) {
+	s.record("UsersByTeam", teams)
+	res := map[string][]repo.User{}
+	for _, u := range s.users {
+		if slices.Contains(teams, u.TeamName) {
+			res[u.TeamName] = append(res[u.TeamName], u)
+		}
+	}
+	for _, members := range res {
+		sort.Slice(members, func(i, j int) bool { return members[i].UserID < members[j].UserID })
+	}
+	return res, nil
+}
+
+func (s *stubStore) PullRequestsByID(_ context.Context, ids []string) (map[string]repo.PullRequest, error) {
+	s.record("PullRequestsByID", ids)
+	return pick(s.prs, ids), nil
+}
+
+func (s *stubStore) OpenReviewsByReviewer(_ context.Context, userIDs []string) (map[string][]repo.OpenReview, error) {
+	s.record("OpenReviewsByReviewer", userIDs)
+	return pick(s.reviews, userIDs), nil
+}
+
+func (s *stubStore) GetReviewerAssignmentStats(context.Context) ([]repo.ReviewerStat, error) {
+	s.record("GetReviewerAssignmentStats", nil)
+	return []repo.ReviewerStat{}, nil
+}
+
+func pick[V any](all map[string]V, keys []string) map[string]V {
+	res := map[string]V{}
+	for _, k := range keys {
+		if v, ok := all[k]; ok {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// newStubStore — команда backend из трёх человек; каждый ревьюит два PR,
+// авторы PR из другой команды.
+func newStubStore() *stubStore {
+	s := &stubStore{
+		teams:   map[string]repo.Team{"backend": {TeamName: "backend", Version: 1}},
+		users:   map[string]repo.User{},
+		prs:     map[string]repo.PullRequest{},
+		reviews: map[string][]repo.OpenReview{},
+	}
+	for i := 1; i <= 3; i++ {
+		id := fmt.Sprintf("u%d", i)
+		s.users[id] = repo.User{UserID: id, Username: id, TeamName: "backend", IsActive: true, Level: "middle"}
+		author := fmt.Sprintf("a%d", i)
+		s.users[author] = repo.User{UserID: author, Username: author, TeamName: "frontend", IsActive: true, Level: "middle"}
+	}
+	for i := 1; i <= 4; i++ {
+		id := fmt.Sprintf("pr-%d", i)
+		s.prs[id] = repo.PullRequest{
+			PullRequestID:     id,
+			PullRequestName:   id,
+			AuthorID:          fmt.Sprintf("a%d", (i-1)%3+1),
+			Status:            "OPEN",
+			AssignedReviewers: []string{"u1", "u2"},
+			RequiredTags:      []string{},
+			Version:           1,
+		}
+	}
+	for i, reviewer := range []string{"u1", "u2", "u3"} {
+		s.reviews[reviewer] = []repo.OpenReview{
+			{PullRequestID: fmt.Sprintf("pr-%d", i+1), AssignedAt: time.Now()},
+			{PullRequestID: fmt.Sprintf("pr-%d", i+2), AssignedAt: time.Now()},
+		}
+	}
+	return s
+}
+
+// TestLoadersBatchPerLevel проверяет, что каждый уровень запроса загружается
+// одним вызовом хранилища, сколько бы объектов на нём ни было: на этом держится
+// отсутствие N+1 (graphql-go должен вызывать thunk'и в ширину).
+func TestLoadersBatchPerLevel(t *testing.T) {
+	store := newStubStore()
+	svc := New(store, slog.New(slog.NewTextHandler(io.Discard, nil)), 0)
+
+	res, executed := svc.Execute(context.Background(), Request{Query: `{
+  team(team_name: "backend") {
+    members {
+      user_id
+      open_reviews {
+        pull_request {
+          pull_request_id
+          author { user_id }
+          reviewers { user_id }
+        }
+      }
+    }
+  }
+}`})
+	if !executed || len(res.Errors) > 0 {
+		t.Fatalf("executed=%v errors=%v", executed, res.Errors)
+	}
+
+	want := map[string][][]string{
+		"TeamsByName":           {{"backend"}},
+		"UsersByTeam":           {{"backend"}},
+		"OpenReviewsByReviewer": {{"u1", "u2", "u3"}},
+		"PullRequestsByID":      {{"pr-1", "pr-2", "pr-3", "pr-4"}},
+		// авторы и ревьюверы на одном уровне — один общий вызов
+		"UsersByID": {{"a1", "a2", "a3", "u1", "u2"}},
+	}
+	for method, calls := range want {
+		if got := store.calls[method]; fmt.Sprint(got) != fmt.Sprint(calls) {
+			t.Errorf("%s calls %v, want %v", method, got, calls)
+		}
+	}
+	for method := range store.calls {
+		if _, ok := want[method]; !ok {
+			t.Errorf("unexpected %s calls %v", method, store.calls[method])
+		}
+	}
+
+	var data struct {
+		Team struct {
+			Members []struct {
+				UserID      string `json:"user_id"`
+				OpenReviews []struct {
+					PullRequest struct {
+						PullRequestID string `json:"pull_request_id"`
+						Author        struct {
+							UserID string `json:"user_id"`
+						} `json:"author"`
+						Reviewers []struct {
+							UserID string `json:"user_id"`
+						} `json:"reviewers"`
+					} `json:"pull_request"`
+				} `json:"open_reviews"`
+			} `json:"members"`
+		} `json:"team"`
+	}
+	raw, _ := json.Marshal(res.Data)
+	if err := json.Unmarshal(raw, &data); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Team.Members) != 3 {
+		t.Fatalf("members %+v, want 3", data.Team.Members)
+	}
+	// результаты пакетной загрузки разложены по своим объектам
+	for _, m := range data.Team.Members {
+		for i, r := range m.OpenReviews {
+			pr := store.prs[store.reviews[m.UserID][i].PullRequestID]
+			if r.PullRequest.PullRequestID != pr.PullRequestID || r.PullRequest.Author.UserID != pr.AuthorID || len(r.PullRequest.Reviewers) != 2 {
+				t.Errorf("%s review %d: got %+v, want %s by %s", m.UserID, i, r.PullRequest, pr.PullRequestID, pr.AuthorID)
+			}
+		}
+	}
+}
+
+func TestLoaderCachesWithinRequest(t *testing.T) {
+	store := newStubStore()
+	svc := New(store, slog.New(slog.NewTextHandler(io.Discard, nil)), 0)
+
+	// u1 запрошен на разных уровнях: второй раз берётся из кеша
+	res, _ := svc.Execute(context.Background(), Request{Query: `{
+  user(user_id: "u1") { user_id }
+  pull_request(pull_request_id: "pr-1") { reviewers { user_id } }
+}`})
+	if len(res.Errors) > 0 {
+		t.Fatal(res.Errors)
+	}
+	if got, want := fmt.Sprint(store.calls["UsersByID"]), fmt.Sprint([][]string{{"u1"}, {"u2"}}); got != want {
+		t.Errorf("UsersByID calls %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,255 @@
+package gqlapi
+
+import (
+	"context"
+	"sort"
+
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Имена полей совпадают с JSON REST API (snake_case), чтобы дашборд мог
+// переиспользовать типы ответов.
+
+func newSchema() (graphql.Schema, error) {
+	var user, team, pullRequest, reviewerStat *graphql.Object
+
+	team = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Team",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"team_name": {Type: graphql.NewNonNull(graphql.String)},
+				"version":   {Type: graphql.NewNonNull(graphql.Int)},
+				"members": {
+					Type: nonNullList(user),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						t := p.Source.(repo.Team)
+						return thunk(p.Context, loadersFrom(p.Context).members, t.TeamName, func(v []repo.User) any {
+							return v
+						}, []repo.User{}), nil
+					},
+				},
+			}
+		}),
+	})
+
+	openReview := graphql.NewObject(graphql.ObjectConfig{
+		Name: "OpenReview",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"pull_request_id": {Type: graphql.NewNonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(repo.OpenReview).PullRequestID, nil
+				}},
+				"assigned_at": {Type: graphql.NewNonNull(graphql.DateTime), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(repo.OpenReview).AssignedAt, nil
+				}},
+				"pull_request": {
+					Type: pullRequest,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						id := p.Source.(repo.OpenReview).PullRequestID
+						return thunk(p.Context, loadersFrom(p.Context).pullReqs, id, asAny[repo.PullRequest], nil), nil
+					},
+				},
+			}
+		}),
+	})
+
+	user = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"user_id":          {Type: graphql.NewNonNull(graphql.String)},
+				"username":         {Type: graphql.NewNonNull(graphql.String)},
+				"team_name":        {Type: graphql.NewNonNull(graphql.String)},
+				"is_active":        {Type: graphql.NewNonNull(graphql.Boolean)},
+				"level":            {Type: graphql.NewNonNull(graphql.String)},
+				"max_open_reviews": {Type: graphql.Int},
+				"away_until":       {Type: graphql.DateTime},
+				"team": {
+					Type: team,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						u := p.Source.(repo.User)
+						return thunk(p.Context, loadersFrom(p.Context).teams, u.TeamName, asAny[repo.Team], nil), nil
+					},
+				},
+				"open_reviews": {
+					Type:        nonNullList(openReview),
+					Description: "Открытые PR, на которые назначен пользователь, старые первыми",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						u := p.Source.(repo.User)
+						return thunk(p.Context, loadersFrom(p.Context).openReviews, u.UserID, func(v []repo.OpenReview) any {
+							return v
+						}, []repo.OpenReview{}), nil
+					},
+				},
+				"stats": {
+					Type: reviewerStat,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						_, byUser, err := loadersFrom(p.Context).reviewerStats(p.Context)
+						if err != nil {
+							return nil, err
+						}
+						if st, ok := byUser[p.Source.(repo.User).UserID]; ok {
+							return st, nil
+						}
+						return nil, nil
+					},
+				},
+			}
+		}),
+	})
+
+	pullRequest = graphql.NewObject(graphql.ObjectConfig{
+		Name: "PullRequest",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"pull_request_id":    {Type: graphql.NewNonNull(graphql.String)},
+				"pull_request_name":  {Type: graphql.NewNonNull(graphql.String)},
+				"author_id":          {Type: graphql.NewNonNull(graphql.String)},
+				"status":             {Type: graphql.NewNonNull(graphql.String)},
+				"assigned_reviewers": {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+				"required_tags":      {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+				"version":            {Type: graphql.NewNonNull(graphql.Int)},
+				"created_at": {Type: graphql.NewNonNull(graphql.DateTime), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(repo.PullRequest).CreatedAt, nil
+				}},
+				"merged_at": {Type: graphql.DateTime, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(repo.PullRequest).MergedAt, nil
+				}},
+				"author": {
+					Type: user,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						pr := p.Source.(repo.PullRequest)
+						return thunk(p.Context, loadersFrom(p.Context).users, pr.AuthorID, asAny[repo.User], nil), nil
+					},
+				},
+				"reviewers": {
+					Type: nonNullList(user),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						pr := p.Source.(repo.PullRequest)
+						l := loadersFrom(p.Context).users
+						loads := make([]func() (repo.User, bool, error), 0, len(pr.AssignedReviewers))
+						for _, id := range pr.AssignedReviewers {
+							loads = append(loads, l.load(p.Context, id))
+						}
+						return func() (interface{}, error) {
+							res := make([]repo.User, 0, len(loads))
+							for _, load := range loads {
+								u, ok, err := load()
+								if err != nil {
+									return nil, err
+								}
+								if ok {
+									res = append(res, u)
+								}
+							}
+							return res, nil
+						}, nil
+					},
+				},
+			}
+		}),
+	})
+
+	declineCount := graphql.NewObject(graphql.ObjectConfig{
+		Name: "DeclineCount",
+		Fields: graphql.Fields{
+			"reason": {Type: graphql.NewNonNull(graphql.String)},
+			"count":  {Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	reviewerStat = graphql.NewObject(graphql.ObjectConfig{
+		Name: "ReviewerStat",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"user_id":                        {Type: graphql.NewNonNull(graphql.String)},
+				"review_count":                   {Type: graphql.NewNonNull(graphql.Int)},
+				"open_review_count":              {Type: graphql.NewNonNull(graphql.Int)},
+				"oldest_open_review_age_seconds": {Type: graphql.NewNonNull(graphql.Int)},
+				"decline_count":                  {Type: graphql.NewNonNull(graphql.Int)},
+				"declines_by_reason": {
+					Type: nonNullList(declineCount),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						st := p.Source.(repo.ReviewerStat)
+						res := make([]map[string]any, 0, len(st.DeclinesByReason))
+						for reason, n := range st.DeclinesByReason {
+							res = append(res, map[string]any{"reason": reason, "count": n})
+						}
+						sort.Slice(res, func(i, j int) bool { return res[i]["reason"].(string) < res[j]["reason"].(string) })
+						return res, nil
+					},
+				},
+				"user": {
+					Type: user,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						st := p.Source.(repo.ReviewerStat)
+						return thunk(p.Context, loadersFrom(p.Context).users, st.UserID, asAny[repo.User], nil), nil
+					},
+				},
+			}
+		}),
+	})
+
+	q := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"team": {
+				Type: team,
+				Args: graphql.FieldConfigArgument{"team_name": {Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					name := p.Args["team_name"].(string)
+					return thunk(p.Context, loadersFrom(p.Context).teams, name, asAny[repo.Team], nil), nil
+				},
+			},
+			"user": {
+				Type: user,
+				Args: graphql.FieldConfigArgument{"user_id": {Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id := p.Args["user_id"].(string)
+					return thunk(p.Context, loadersFrom(p.Context).users, id, asAny[repo.User], nil), nil
+				},
+			},
+			"pull_request": {
+				Type: pullRequest,
+				Args: graphql.FieldConfigArgument{"pull_request_id": {Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id := p.Args["pull_request_id"].(string)
+					return thunk(p.Context, loadersFrom(p.Context).pullReqs, id, asAny[repo.PullRequest], nil), nil
+				},
+			},
+			"reviewer_stats": {
+				Type: nonNullList(reviewerStat),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					list, _, err := loadersFrom(p.Context).reviewerStats(p.Context)
+					return list, err
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: q})
+}
+
+func nonNullList(t graphql.Type) graphql.Type {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+}
+
+func asAny[V any](v V) any { return v }
+
+// thunk откладывает загрузку ключа до пакетного запроса; missing возвращается,
+// если ключ не найден (nil — поле станет null).
+func thunk[V any](ctx context.Context, l *loader[V], key string, wrap func(V) any, missing any) func() (interface{}, error) {
+	load := l.load(ctx, key)
+	return func() (interface{}, error) {
+		v, ok, err := load()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return missing, nil
+		}
+		return wrap(v), nil
+	}
+}
@@ -0,0 +1,149 @@
+package apihandler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"pr-reviewer-service/internal/backup"
+	"pr-reviewer-service/internal/lib/logger"
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// maxSnapshotBytes ограничивает размер снимка, который принимает /admin/import.
+const maxSnapshotBytes = 512 << 20
+
+// adminOnly пускает к /admin только с bearer-токеном из конфигурации; без
+// токена административные операции выключены.
+func (h *Handlers) adminOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.opts.AdminToken == "" {
+			writeError(w, 404, "NOT_FOUND", "admin API is disabled")
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.opts.AdminToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			writeError(w, 401, "UNAUTHORIZED", "invalid or missing bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// countingWriter запоминает, успели ли уйти клиенту какие-то байты.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// ExportSnapshot отдаёт снимок состояния потоком JSON Lines. Если чтение
+// сорвалось посреди потока, статус уже не поменять: ответ обрывается без
+// итоговой строки, и такой файл не примет /admin/import.
+func (h *Handlers) ExportSnapshot(w http.ResponseWriter, r *http.Request) {
+	// общий WriteTimeout сервера оборвал бы выгрузку большой базы
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	now := time.Now()
+	w.Header().Set("Content-Type", backup.MediaType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="pr-reviewer-%s.jsonl"`, now.UTC().Format("20060102-150405")))
+
+	cw := &countingWriter{w: w}
+	rows, err := backup.Write(r.Context(), cw, h.store, now)
+	if err != nil {
+		if cw.n == 0 {
+			w.Header().Del("Content-Disposition")
+			h.fail(w, r, err, nil)
+			return
+		}
+		h.log.Error("export interrupted",
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			slog.Int("rows", rows),
+			logger.Err(err),
+		)
+		return
+	}
+	h.log.Info("export finished", slog.Int("rows", rows), slog.Int64("bytes", cw.n))
+}
+
+// ImportSnapshot восстанавливает снимок из /admin/export в пустую базу. Снимок
+// проверяется целиком до записи: при любой проблеме база не меняется, а в
+// ответе перечислены проблемы с номерами строк.
+func (h *Handlers) ImportSnapshot(w http.ResponseWriter, r *http.Request) {
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if ct != backup.MediaType && ct != "application/jsonl" {
+		writeError(w, 415, "UNSUPPORTED_MEDIA_TYPE", "Content-Type must be "+backup.MediaType)
+		return
+	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	tables, problems, err := backup.Parse(http.MaxBytesReader(w, r.Body, maxSnapshotBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, 413, "BODY_TOO_LARGE", "snapshot is too large")
+			return
+		}
+		writeError(w, 400, "BAD_REQUEST", "cannot read request body")
+		return
+	}
+	if len(problems) > 0 {
+		writeProblems(w, "INVALID_BACKUP", problems)
+		return
+	}
+
+	res, err := h.store.Restore(r.Context(), tables, dryRun)
+	if err != nil {
+		h.fail(w, r, err, nil)
+		return
+	}
+	writeJSON(w, 200, res)
+}
+
+// SimulateAssignments прогоняет исторические PR команды через выбор ревьюверов
+// с другой политикой; реальные назначения не меняются.
+func (h *Handlers) SimulateAssignments(w http.ResponseWriter, r *http.Request) {
+	var req repo.SimulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, 400, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.TeamName == "" {
+		writeError(w, 400, "BAD_REQUEST", "team_name required")
+		return
+	}
+	if req.Limit < 0 || req.Limit > repo.MaxSimulationLimit {
+		writeError(w, 400, "BAD_REQUEST", fmt.Sprintf("limit must be between 0 and %d", repo.MaxSimulationLimit))
+		return
+	}
+	if req.From != nil && req.To != nil && !req.From.Before(*req.To) {
+		writeError(w, 400, "BAD_REQUEST", "from must be before to")
+		return
+	}
+
+	res, err := h.store.Simulate(r.Context(), req)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "team not found"})
+		return
+	}
+	writeJSON(w, 200, res)
+}
@@ -0,0 +1,90 @@
+package apihandler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"pr-reviewer-service/internal/lib/logger"
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// statusByCode сопоставляет коды ошибок хранилища HTTP-статусам.
+var statusByCode = map[string]int{
+	repo.CodeNotFound:    404,
+	repo.CodePRExists:    409,
+	repo.CodeUserExists:  409,
+	repo.CodePRMerged:    409,
+	repo.CodeNotAssigned: 409,
+	repo.CodeNoCandidate: 409,
+	repo.CodeSeniority:   409,
+	repo.CodeVersion:     412,
+	repo.CodeIneligible:  409,
+	repo.CodeTooMany:     409,
+	repo.CodeNotEmpty:    409,
+
+	repo.CodeInvalidPolicy: 400,
+	repo.CodeInvalidBackup: 400,
+
+	repo.CodeIdempotencyMismatch:   422,
+	repo.CodeIdempotencyInProgress: 409,
+}
+
+// messages уточняет текст ответа для ошибок хранилища в контексте обработчика,
+// например «pr not found» вместо общего «not found».
+type messages map[*repo.Error]string
+
+// fail отвечает клиенту по ошибке хранилища. Ожидаемые ошибки (*repo.Error)
+// превращаются в 4xx со своим кодом, всё остальное — в 5xx без подробностей:
+// текст внутренней ошибки только пишется в лог вместе с request id.
+func (h *Handlers) fail(w http.ResponseWriter, r *http.Request, err error, msgs messages) {
+	var e *repo.Error
+	if errors.As(err, &e) {
+		status, ok := statusByCode[e.Code]
+		if !ok {
+			status = 400
+		}
+		// хранилище оборачивает доменные ошибки только безопасными подробностями
+		msg := err.Error()
+		for target, m := range msgs {
+			if target.Code == e.Code {
+				msg = m
+			}
+		}
+		writeError(w, status, e.Code, msg)
+		return
+	}
+
+	status, code, msg := 500, "INTERNAL", "internal error"
+	var connErr *pgconn.ConnectError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), pgconn.Timeout(err):
+		status, code, msg = 504, "TIMEOUT", "request timed out"
+	case errors.As(err, &connErr):
+		status, code, msg = 503, "UNAVAILABLE", "storage unavailable"
+	}
+
+	h.log.Error("request failed",
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.Int("status", status),
+		logger.Err(err),
+	)
+	writeError(w, status, code, msg)
+}
+
+// exposeRequestID возвращает request id клиенту в заголовке X-Request-ID;
+// writeError дублирует его в теле ошибки.
+func exposeRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := middleware.GetReqID(r.Context()); id != "" {
+			w.Header().Set(middleware.RequestIDHeader, id)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,17 @@
+package apihandler
+
+import (
+	"testing"
+
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+// TestStatusByCodeCoversAllCodes: код без записи в statusByCode молча
+// превратился бы в 400, даже если это конфликт или 404.
+func TestStatusByCodeCoversAllCodes(t *testing.T) {
+	for _, code := range repo.Codes() {
+		if _, ok := statusByCode[code]; !ok {
+			t.Errorf("%s has no HTTP status", code)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package apihandler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ETag ресурса — его версия в кавычках: "3".
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+func setETag(w http.ResponseWriter, version int64) {
+	if version > 0 {
+		w.Header().Set("ETag", etag(version))
+	}
+}
+
+var errBadIfMatch = errors.New(`If-Match must be a single ETag like "3" or *`)
+
+// ifMatch возвращает версию из заголовка If-Match; 0 — заголовка нет или передан *.
+func ifMatch(r *http.Request) (int64, error) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" || v == "*" {
+		return 0, nil
+	}
+	if len(v) < 3 || v[0] != '"' || v[len(v)-1] != '"' {
+		return 0, errBadIfMatch
+	}
+	version, err := strconv.ParseInt(v[1:len(v)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errBadIfMatch
+	}
+	return version, nil
+}
+
+// notModified отвечает 304, если клиент уже видел эту версию (If-None-Match).
+func notModified(w http.ResponseWriter, r *http.Request, version int64) bool {
+	for _, v := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "W/")
+		if v == "*" || v == etag(version) {
+			setETag(w, version)
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
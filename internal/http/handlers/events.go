@@ -0,0 +1,113 @@
+package apihandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+const (
+	sseHeartbeat   = 15 * time.Second
+	sseReplayBatch = 500
+)
+
+// StreamEvents отдаёт события в формате Server-Sent Events. При переподключении
+// клиент передаёт Last-Event-ID, и пропущенные события дочитываются из журнала.
+func (h *Handlers) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	f := repo.EventFilter{
+		TeamName: r.URL.Query().Get("team_name"),
+		UserID:   r.URL.Query().Get("user_id"),
+	}
+
+	lastID := int64(-1)
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("last_event_id")
+	}
+	if raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id < 0 {
+			writeError(w, 400, "BAD_REQUEST", "invalid Last-Event-ID")
+			return
+		}
+		lastID = id
+	}
+
+	rc := http.NewResponseController(w)
+	// общий WriteTimeout сервера оборвал бы долгий поток
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	// подписываемся до чтения журнала, чтобы не потерять события между ними
+	ch, unsubscribe := h.events.Subscribe(f)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	sent := map[int64]bool{}
+	if lastID >= 0 {
+		for {
+			list, err := h.store.EventsSince(r.Context(), lastID, f, sseReplayBatch)
+			if err != nil {
+				return
+			}
+			for _, e := range list {
+				if err := writeEvent(w, e); err != nil {
+					return
+				}
+				sent[e.ID] = true
+				lastID = e.ID
+			}
+			if len(list) < sseReplayBatch {
+				break
+			}
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case e, ok := <-ch:
+			if !ok {
+				// брокер отключил отставшего клиента: тот переподключится с Last-Event-ID
+				return
+			}
+			if sent[e.ID] {
+				continue
+			}
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, e repo.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
@@ -0,0 +1,25 @@
+package apihandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"pr-reviewer-service/internal/http/gqlapi"
+)
+
+// GraphQL выполняет запрос дашборда. Ответ — стандартный {data, errors};
+// если запрос отвергнут до выполнения (синтаксис, валидация, сложность), статус 400.
+func (h *Handlers) GraphQL(w http.ResponseWriter, r *http.Request) {
+	var req gqlapi.Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, 400, "BAD_REQUEST", "invalid json")
+		return
+	}
+
+	res, executed := h.graphql.Execute(r.Context(), req)
+	status := 200
+	if !executed {
+		status = 400
+	}
+	writeJSON(w, status, res)
+}
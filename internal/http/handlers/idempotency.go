@@ -0,0 +1,133 @@
+package apihandler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"pr-reviewer-service/internal/lib/logger"
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// idempotent выполняет изменяющий запрос с заголовком Idempotency-Key не больше
+// одного раза за TTL: повтор с тем же ключом и тем же запросом получает
+// сохранённый ответ, а с другим телом или другим путём — 422.
+func (h *Handlers) idempotent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" || !mutating(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			writeError(w, 400, "BAD_REQUEST", "Idempotency-Key is too long")
+			return
+		}
+
+		raw, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+		if err != nil {
+			writeError(w, 400, "BAD_REQUEST", "cannot read request body")
+			return
+		}
+		if len(raw) > maxBodyBytes {
+			writeError(w, 413, "BODY_TOO_LARGE", "request body too large")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(raw))
+
+		stored, err := h.store.ReserveIdempotencyKey(r.Context(), key, fingerprint(r, raw), h.opts.IdempotencyTTL)
+		if err != nil {
+			h.fail(w, r, err, nil)
+			return
+		}
+		if stored != nil {
+			for k, v := range stored.Headers {
+				w.Header().Set(k, v)
+			}
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			w.WriteHeader(stored.Status)
+			_, _ = w.Write(stored.Body)
+			return
+		}
+
+		// ответ пишется клиенту и одновременно копируется для сохранения
+		var buf bytes.Buffer
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		ww.Tee(&buf)
+
+		// запись ответа не должна зависеть от таймаута или обрыва исходного запроса
+		ctx := context.WithoutCancel(r.Context())
+		done := false
+		defer func() {
+			if done {
+				return
+			}
+			if err := h.store.ReleaseIdempotencyKey(ctx, key); err != nil {
+				h.log.Error("release idempotency key", slog.String("key", key), logger.Err(err))
+			}
+		}()
+
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = 200
+		}
+		// на 5xx ключ освобождается: повтор должен выполниться заново
+		if status >= 500 {
+			return
+		}
+		resp := repo.StoredResponse{Status: status, Headers: replayHeaders(w.Header()), Body: buf.Bytes()}
+		if err := h.store.CompleteIdempotencyKey(ctx, key, resp); err != nil {
+			h.log.Error("store idempotent response", slog.String("key", key), logger.Err(err))
+			return
+		}
+		done = true
+	})
+}
+
+func mutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// fingerprint идентифицирует запрос: метод, путь, query, If-Match и тело.
+// Повтор с другим предусловием — это другой запрос, а не повтор.
+func fingerprint(r *http.Request, body []byte) string {
+	sum := sha256.New()
+	sum.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery + "\n"))
+	if v := r.Header.Get("If-Match"); v != "" {
+		sum.Write([]byte("If-Match: " + v + "\n"))
+	}
+	sum.Write(body)
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// replayHeaders — заголовки ответа, которые имеет смысл повторить.
+// Request id и CORS относятся к конкретному запросу и не сохраняются.
+func replayHeaders(h http.Header) map[string]string {
+	res := map[string]string{}
+	for k := range h {
+		if k == middleware.RequestIDHeader || k == "Vary" || strings.HasPrefix(k, "Access-Control-") {
+			continue
+		}
+		res[k] = h.Get(k)
+	}
+	return res
+}
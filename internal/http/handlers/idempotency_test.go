@@ -0,0 +1,41 @@
+package apihandler
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	req := func(method, target, ifMatch string) string {
+		r := httptest.NewRequest(method, target, strings.NewReader(""))
+		if ifMatch != "" {
+			r.Header.Set("If-Match", ifMatch)
+		}
+		return fingerprint(r, []byte(`{"pull_request_id":"pr-1"}`))
+	}
+
+	base := req("POST", "/pullRequest/merge", `"3"`)
+	tests := []struct {
+		name string
+		fp   string
+		same bool
+	}{
+		{"same request", req("POST", "/pullRequest/merge", `"3"`), true},
+		{"other If-Match", req("POST", "/pullRequest/merge", `"4"`), false},
+		{"without If-Match", req("POST", "/pullRequest/merge", ""), false},
+		{"other path", req("POST", "/pullRequest/reassign", `"3"`), false},
+		{"other query", req("POST", "/pullRequest/merge?dry_run=true", `"3"`), false},
+		{"other method", req("PUT", "/pullRequest/merge", `"3"`), false},
+	}
+	for _, tt := range tests {
+		if got := tt.fp == base; got != tt.same {
+			t.Errorf("%s: same fingerprint = %v, want %v", tt.name, got, tt.same)
+		}
+	}
+
+	r := httptest.NewRequest("POST", "/pullRequest/merge", nil)
+	if fingerprint(r, []byte("a")) == fingerprint(r, []byte("b")) {
+		t.Error("body is not part of the fingerprint")
+	}
+}
@@ -0,0 +1,42 @@
+package apihandler
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"pr-reviewer-service/internal/bulkimport"
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+// SyncOrg принимает документ со всей организацией (тот же формат, что и у
+// /team/import) и приводит к нему команды и пользователей. С dry_run возвращает
+// план и его хеш; переданный в plan хеш гарантирует, что применится именно он.
+func (h *Handlers) SyncOrg(w http.ResponseWriter, r *http.Request) {
+	format, ok := bulkimport.FormatFromContentType(r.Header.Get("Content-Type"))
+	if !ok {
+		writeError(w, 415, "UNSUPPORTED_MEDIA_TYPE", "Content-Type must be text/csv or application/yaml")
+		return
+	}
+	var opts repo.SyncOptions
+	q := r.URL.Query()
+	opts.DryRun, _ = strconv.ParseBool(q.Get("dry_run"))
+	opts.Plan = q.Get("plan")
+
+	raw, ok := readBody(w, r, maxBodyBytes)
+	if !ok {
+		return
+	}
+	teams, problems := bulkimport.Parse(bytes.NewReader(raw), format)
+	if len(problems) > 0 {
+		writeProblems(w, "INVALID_IMPORT", problems)
+		return
+	}
+
+	res, err := h.store.SyncOrg(r.Context(), teams, opts)
+	if err != nil {
+		h.fail(w, r, err, nil)
+		return
+	}
+	writeJSON(w, 200, res)
+}
@@ -9,9 +9,10 @@ import (
 
 func (h *Handlers) CreatePR(w http.ResponseWriter, r *http.Request) {
 	var body struct {
-		PRID   string `json:"pull_request_id"`
-		Name   string `json:"pull_request_name"`
-		Author string `json:"author_id"`
+		PRID   string   `json:"pull_request_id"`
+		Name   string   `json:"pull_request_name"`
+		Author string   `json:"author_id"`
+		Tags   []string `json:"required_tags"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -19,17 +20,42 @@ func (h *Handlers) CreatePR(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.store.CreatePR(r.Context(), body.PRID, body.Name, body.Author)
-	switch err {
-	case repo.ErrNotFound:
-		writeError(w, 404, "NOT_FOUND", "author not found")
+	pr, expl, err := h.store.CreatePR(r.Context(), body.PRID, body.Name, body.Author, body.Tags)
+	if err != nil {
+		h.fail(w, r, err, messages{
+			repo.ErrNotFound:  "author not found",
+			repo.ErrSeniority: "not enough senior reviewers in team",
+		})
 		return
-	case repo.ErrPRExists:
-		writeError(w, 409, "PR_EXISTS", "pr already exists")
+	}
+
+	resp := map[string]any{"pr": pr}
+	if r.URL.Query().Get("explain") == "true" {
+		resp["explanation"] = expl
+	}
+
+	setETag(w, pr.Version)
+	writeJSON(w, 201, resp)
+}
+
+func (h *Handlers) GetPR(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("pull_request_id")
+	if id == "" {
+		writeError(w, 400, "BAD_REQUEST", "pull_request_id required")
 		return
 	}
 
-	writeJSON(w, 201, map[string]any{"pr": pr})
+	pr, err := h.store.GetPR(r.Context(), id)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "pr not found"})
+		return
+	}
+	if notModified(w, r, pr.Version) {
+		return
+	}
+
+	setETag(w, pr.Version)
+	writeJSON(w, 200, map[string]any{"pr": pr})
 }
 
 func (h *Handlers) MergePR(w http.ResponseWriter, r *http.Request) {
@@ -41,11 +67,21 @@ func (h *Handlers) MergePR(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.store.MergePR(r.Context(), body.PRID)
-	if err == repo.ErrNotFound {
-		writeError(w, 404, "NOT_FOUND", "pr not found")
+	expected, err := ifMatch(r)
+	if err != nil {
+		writeError(w, 400, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	pr, err := h.store.MergePR(r.Context(), body.PRID, expected)
+	if err != nil {
+		h.fail(w, r, err, messages{
+			repo.ErrNotFound:        "pr not found",
+			repo.ErrVersionMismatch: "someone else changed this PR",
+		})
 		return
 	}
+	setETag(w, pr.Version)
 
 	writeJSON(w, 200, map[string]any{"pr": pr})
 }
@@ -61,26 +97,120 @@ func (h *Handlers) Reassign(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	newID, err := h.store.ReassignReviewer(r.Context(), body.PRID, body.OldUser)
-	switch err {
-	case repo.ErrPRMerged:
-		writeError(w, 409, "PR_MERGED", "cannot reassign merged PR")
+	expected, err := ifMatch(r)
+	if err != nil {
+		writeError(w, 400, "BAD_REQUEST", err.Error())
 		return
-	case repo.ErrNotAssigned:
-		writeError(w, 409, "NOT_ASSIGNED", "reviewer not assigned")
-		return
-	case repo.ErrNoCandidate:
-		writeError(w, 409, "NO_CANDIDATE", "no candidate found")
-		return
-	case repo.ErrNotFound:
-		writeError(w, 404, "NOT_FOUND", "pr or user not found")
+	}
+
+	newID, err := h.store.ReassignReviewer(r.Context(), body.PRID, body.OldUser, expected)
+	if err != nil {
+		h.fail(w, r, err, messages{
+			repo.ErrPRMerged:        "cannot reassign merged PR",
+			repo.ErrSeniority:       "replacement would break team seniority rule",
+			repo.ErrNotFound:        "pr or user not found",
+			repo.ErrVersionMismatch: "someone else changed this PR",
+		})
 		return
 	}
 
-	pr, _ := h.store.MergePR(r.Context(), body.PRID) // получить полные данные PR
+	pr, err := h.store.GetPR(r.Context(), body.PRID)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "pr not found"})
+		return
+	}
+	setETag(w, pr.Version)
 
 	writeJSON(w, 200, map[string]any{
 		"pr":          pr,
 		"replaced_by": newID,
 	})
 }
+
+func (h *Handlers) ExplainPR(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("pull_request_id")
+	if id == "" {
+		writeError(w, 400, "BAD_REQUEST", "pull_request_id required")
+		return
+	}
+
+	expl, err := h.store.ExplainPR(r.Context(), id)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "no recorded assignment for pr"})
+		return
+	}
+
+	writeJSON(w, 200, expl)
+}
+
+func (h *Handlers) GetAssignments(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("pull_request_id")
+	if id == "" {
+		writeError(w, 400, "BAD_REQUEST", "pull_request_id required")
+		return
+	}
+
+	list, err := h.store.GetAssignments(r.Context(), id)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "pr not found"})
+		return
+	}
+
+	writeJSON(w, 200, map[string]any{
+		"pull_request_id": id,
+		"assignments":     list,
+	})
+}
+
+// ChangeReviewers — ручное добавление, снятие и замена ревьюверов.
+func (h *Handlers) ChangeReviewers(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		PRID    string   `json:"pull_request_id"`
+		Add     []string `json:"add"`
+		Remove  []string `json:"remove"`
+		Replace []struct {
+			OldUser string `json:"old_user_id"`
+			NewUser string `json:"new_user_id"`
+		} `json:"replace"`
+		Override bool   `json:"override"`
+		Reason   string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, 400, "BAD_REQUEST", "invalid json")
+		return
+	}
+	ch := repo.ReviewerChange{
+		PullRequestID: body.PRID,
+		Add:           body.Add,
+		Remove:        body.Remove,
+		Override:      body.Override,
+		Reason:        body.Reason,
+	}
+	for _, p := range body.Replace {
+		ch.Remove = append(ch.Remove, p.OldUser)
+		ch.Add = append(ch.Add, p.NewUser)
+	}
+	if len(ch.Add) == 0 && len(ch.Remove) == 0 {
+		writeError(w, 400, "BAD_REQUEST", "nothing to change: add, remove or replace required")
+		return
+	}
+
+	expected, err := ifMatch(r)
+	if err != nil {
+		writeError(w, 400, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	pr, err := h.store.ChangeReviewers(r.Context(), ch, expected)
+	if err != nil {
+		h.fail(w, r, err, messages{
+			repo.ErrPRMerged:        "cannot change reviewers of merged PR",
+			repo.ErrSeniority:       "change would break team seniority rule",
+			repo.ErrVersionMismatch: "someone else changed this PR",
+		})
+		return
+	}
+
+	setETag(w, pr.Version)
+	writeJSON(w, 200, map[string]any{"pr": pr})
+}
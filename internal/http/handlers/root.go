@@ -2,17 +2,51 @@ package apihandler
 
 import (
 	"encoding/json"
+	"log/slog"
 	"net/http"
+	"time"
 
+	"pr-reviewer-service/internal/events"
+	"pr-reviewer-service/internal/http/gqlapi"
+	"pr-reviewer-service/internal/http/scim"
 	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/go-chi/chi/v5/middleware"
 )
 
+type Options struct {
+	// IdempotencyTTL — сколько хранится ответ на запрос с Idempotency-Key.
+	IdempotencyTTL time.Duration
+	// GraphQLMaxComplexity — предел оценочной сложности запроса к /graphql.
+	GraphQLMaxComplexity int
+	// SCIM — токен и команда по умолчанию для провижининга из IdP.
+	SCIM scim.Options
+	// AdminToken — bearer-токен для /admin; пустой выключает эти операции.
+	AdminToken string
+}
+
 type Handlers struct {
-	store *repo.Store
+	store  *repo.Store
+	events *events.Broker
+	log    *slog.Logger
+	opts   Options
+
+	graphql *gqlapi.Service
+	scim    *scim.Service
 }
 
-func NewHandlers(s *repo.Store) *Handlers {
-	return &Handlers{store: s}
+func NewHandlers(s *repo.Store, b *events.Broker, log *slog.Logger, opts Options) *Handlers {
+	if opts.IdempotencyTTL <= 0 {
+		opts.IdempotencyTTL = 24 * time.Hour
+	}
+	return &Handlers{
+		store:   s,
+		events:  b,
+		log:     log,
+		opts:    opts,
+		graphql: gqlapi.New(s, log, opts.GraphQLMaxComplexity),
+		scim:    scim.New(s, log, opts.SCIM),
+	}
 }
 
 func writeJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -22,10 +56,12 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 }
 
 func writeError(w http.ResponseWriter, status int, code, msg string) {
-	writeJSON(w, status, map[string]any{
-		"error": map[string]string{
-			"code":    code,
-			"message": msg,
-		},
-	})
+	e := map[string]any{
+		"code":    code,
+		"message": msg,
+	}
+	if id := w.Header().Get(middleware.RequestIDHeader); id != "" {
+		e["request_id"] = id
+	}
+	writeJSON(w, status, map[string]any{"error": e})
 }
@@ -1,9 +1,13 @@
 package apihandler
 
 import (
+	"log/slog"
 	"net/http"
 	"time"
 
+	"pr-reviewer-service/internal/events"
+	"pr-reviewer-service/internal/http/openapi"
+	"pr-reviewer-service/internal/http/scim"
 	"pr-reviewer-service/internal/storage/repo"
 
 	"github.com/go-chi/chi/v5"
@@ -11,50 +15,99 @@ import (
 	"github.com/go-chi/cors"
 )
 
-func NewRouter(store *repo.Store) http.Handler {
+func NewRouter(store *repo.Store, broker *events.Broker, log *slog.Logger, opts Options) chi.Router {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
+	r.Use(exposeRequestID)
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(30 * time.Second))
 
 	corsMiddleware := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"}, // на проде укажите домены
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID", "Last-Event-ID", "Idempotency-Key", "If-Match", "If-None-Match"},
+		ExposedHeaders:   []string{"X-Request-ID", "Idempotent-Replayed", "ETag"},
 		AllowCredentials: false,
 		MaxAge:           300,
 	})
 	r.Use(corsMiddleware.Handler)
+	r.Use(validateRequests(openapi.Spec()))
 
-	h := NewHandlers(store)
+	h := NewHandlers(store, broker, log, opts)
 
-	r.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("OK"))
 	})
 
-	r.Route("/team", func(r chi.Router) {
-		r.Post("/add", h.CreateTeam)
-		r.Get("/get", h.GetTeam)
-		r.Post("/deactivate", h.BulkDeactivate)
-	})
+	r.Get("/openapi.json", h.OpenAPI)
 
-	r.Route("/users", func(r chi.Router) {
-		r.Post("/setIsActive", h.SetIsActive)
-		r.Get("/getReview", h.GetPRsForReviewer)
-	})
+	// SSE-поток живёт дольше любого таймаута запроса
+	r.Get("/events/stream", h.StreamEvents)
+
+	// GraphQL только читает данные, ключ идемпотентности ему не нужен
+	r.With(middleware.Timeout(30*time.Second)).Post("/graphql", h.GraphQL)
 
-	r.Route("/pullRequest", func(r chi.Router) {
-		r.Post("/create", h.CreatePR)
-		r.Post("/merge", h.MergePR)
-		r.Post("/reassign", h.Reassign)
+	// IdP не присылают Idempotency-Key, а повтор SCIM-запроса и так безопасен
+	r.With(middleware.Timeout(30*time.Second)).Mount(scim.BasePath, h.scim.Routes())
+
+	// снимок базы выгружается и загружается дольше таймаута обычного запроса.
+	// Импорт не проходит через idempotent: снимок больше предела тела, который
+	// тот буферизует для отпечатка, а повтор и так ничего не меняет — восстановление
+	// идёт только в пустую базу, и второй запрос получает NOT_EMPTY
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(h.adminOnly)
+		r.Get("/export", h.ExportSnapshot)
+		r.Post("/import", h.ImportSnapshot)
+		r.With(middleware.Timeout(30*time.Second), h.idempotent).Post("/simulate", h.SimulateAssignments)
 	})
 
-	r.Route("/stats", func(r chi.Router) {
-		r.Get("/reviewers", h.GetReviewerStats)
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Timeout(30 * time.Second))
+		r.Use(h.idempotent)
+
+		r.Route("/team", func(r chi.Router) {
+			r.Post("/add", h.CreateTeam)
+			r.Get("/get", h.GetTeam)
+			r.Post("/deactivate", h.BulkDeactivate)
+			r.Post("/import", h.ImportTeams)
+			r.Get("/rules", h.GetSeniorityRule)
+			r.Post("/rules", h.SetSeniorityRule)
+			r.Get("/sla", h.GetSLAPolicy)
+			r.Post("/sla", h.SetSLAPolicy)
+			r.Get("/schedule", h.GetTeamSchedule)
+			r.Post("/schedule", h.SetTeamSchedule)
+			r.Get("/policy", h.GetTeamPolicy)
+			r.Put("/policy", h.SetTeamPolicy)
+			r.Get("/policy/history", h.GetPolicyHistory)
+		})
+
+		r.Post("/org/sync", h.SyncOrg)
+
+		r.Route("/users", func(r chi.Router) {
+			r.Post("/setIsActive", h.SetIsActive)
+			r.Post("/setAvailability", h.SetAvailability)
+			r.Post("/setSchedule", h.SetSchedule)
+			r.Get("/getReview", h.GetPRsForReviewer)
+			r.Post("/declineReview", h.DeclineReview)
+		})
+
+		r.Route("/pullRequest", func(r chi.Router) {
+			r.Post("/create", h.CreatePR)
+			r.Get("/get", h.GetPR)
+			r.Post("/merge", h.MergePR)
+			r.Post("/reassign", h.Reassign)
+			r.Post("/reviewers", h.ChangeReviewers)
+			r.Get("/assignments", h.GetAssignments)
+			r.Get("/explain", h.ExplainPR)
+		})
+
+		r.Route("/stats", func(r chi.Router) {
+			r.Get("/reviewers", h.GetReviewerStats)
+			r.Get("/pairs", h.GetPairMatrix)
+		})
 	})
 
 	return r
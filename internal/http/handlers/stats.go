@@ -2,13 +2,31 @@ package apihandler
 
 import (
 	"net/http"
+
+	"pr-reviewer-service/internal/storage/repo"
 )
 
 func (h *Handlers) GetReviewerStats(w http.ResponseWriter, r *http.Request) {
 	stats, err := h.store.GetReviewerAssignmentStats(r.Context())
 	if err != nil {
-		writeError(w, 500, "INTERNAL", err.Error())
+		h.fail(w, r, err, nil)
 		return
 	}
 	writeJSON(w, 200, map[string]any{"stats": stats})
 }
+
+func (h *Handlers) GetPairMatrix(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("team_name")
+	if name == "" {
+		writeError(w, 400, "BAD_REQUEST", "team_name required")
+		return
+	}
+
+	m, err := h.store.GetPairMatrix(r.Context(), name)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "team not found"})
+		return
+	}
+
+	writeJSON(w, 200, m)
+}
@@ -9,6 +9,7 @@ import (
 func (h *Handlers) BulkDeactivate(w http.ResponseWriter, r *http.Request) {
 	var body struct {
 		TeamName string `json:"team_name"`
+		DryRun   bool   `json:"dry_run"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		writeError(w, 400, "BAD_REQUEST", "invalid json")
@@ -19,14 +20,24 @@ func (h *Handlers) BulkDeactivate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := h.store.BulkDeactivateTeam(r.Context(), body.TeamName)
-	if err == repo.ErrNotFound {
-		writeError(w, 404, "NOT_FOUND", "team not found")
+	expected, err := ifMatch(r)
+	if err != nil {
+		writeError(w, 400, "BAD_REQUEST", err.Error())
 		return
 	}
+
+	deactivate := h.store.BulkDeactivateTeam
+	if body.DryRun {
+		deactivate = h.store.PreviewDeactivateTeam
+	}
+	res, err := deactivate(r.Context(), body.TeamName, expected)
 	if err != nil {
-		writeError(w, 500, "INTERNAL", err.Error())
+		h.fail(w, r, err, messages{
+			repo.ErrNotFound:        "team not found",
+			repo.ErrVersionMismatch: "team was changed by someone else",
+		})
 		return
 	}
+	setETag(w, res.Version)
 	writeJSON(w, 200, res)
 }
@@ -0,0 +1,94 @@
+package apihandler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"pr-reviewer-service/internal/events"
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// newDBRouter собирает роутер над настоящим Postgres из PR_REVIEWER_TEST_DB;
+// без него тест пропускается.
+func newDBRouter(t *testing.T) chi.Router {
+	t.Helper()
+	dsn := os.Getenv("PR_REVIEWER_TEST_DB")
+	if dsn == "" {
+		t.Skip("PR_REVIEWER_TEST_DB is not set")
+	}
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	schema, err := os.ReadFile("../../storage/migrations/init.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range strings.Split(string(schema), ";") {
+		if q = strings.TrimSpace(q); q == "" {
+			continue
+		}
+		if _, err := pool.Exec(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewRouter(repo.New(pool, repo.Options{}), events.NewBroker(), slog.New(slog.NewTextHandler(io.Discard, nil)), Options{})
+}
+
+func TestDeactivateDryRunETag(t *testing.T) {
+	r := newDBRouter(t)
+	team := fmt.Sprintf("dryrun-%d", time.Now().UnixNano())
+
+	do := func(method, path, body, ifMatch string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do("POST", "/team/add", fmt.Sprintf(`{"team_name":%q,"members":[
+		{"user_id":"%[1]s-u1","username":"a","is_active":true},
+		{"user_id":"%[1]s-u2","username":"b","is_active":true}]}`, team), "")
+	if rec.Code != 201 {
+		t.Fatalf("create team: status %d: %s", rec.Code, rec.Body)
+	}
+
+	deactivate := fmt.Sprintf(`{"team_name":%q,"dry_run":true}`, team)
+	rec = do("POST", "/team/deactivate", deactivate, "")
+	if rec.Code != 200 {
+		t.Fatalf("dry run: status %d: %s", rec.Code, rec.Body)
+	}
+	preview := rec.Header().Get("ETag")
+
+	rec = do("GET", "/team/get?team_name="+team, "", "")
+	if got := rec.Header().Get("ETag"); got != preview {
+		t.Fatalf("dry run ETag %s, GET /team/get ETag %s", preview, got)
+	}
+
+	// ETag предпросмотра годится для настоящей деактивации
+	rec = do("POST", "/team/deactivate", fmt.Sprintf(`{"team_name":%q}`, team), preview)
+	if rec.Code != 200 {
+		t.Fatalf("deactivate with If-Match %s: status %d: %s", preview, rec.Code, rec.Body)
+	}
+	if rec.Header().Get("ETag") == preview {
+		t.Error("deactivation did not change the team version")
+	}
+}
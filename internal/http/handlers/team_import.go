@@ -0,0 +1,58 @@
+package apihandler
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"pr-reviewer-service/internal/bulkimport"
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ImportTeams принимает CSV или YAML с командами и участниками. Файл проверяется
+// целиком: при любой ошибке ничего не меняется, а в ответе перечислены все
+// найденные проблемы с номерами строк.
+func (h *Handlers) ImportTeams(w http.ResponseWriter, r *http.Request) {
+	format, ok := bulkimport.FormatFromContentType(r.Header.Get("Content-Type"))
+	if !ok {
+		writeError(w, 415, "UNSUPPORTED_MEDIA_TYPE", "Content-Type must be text/csv or application/yaml")
+		return
+	}
+	var opts repo.ImportOptions
+	q := r.URL.Query()
+	// значения уже проверены по схеме OpenAPI
+	opts.DryRun, _ = strconv.ParseBool(q.Get("dry_run"))
+	opts.DeactivateMissing, _ = strconv.ParseBool(q.Get("deactivate_missing"))
+
+	raw, ok := readBody(w, r, maxBodyBytes)
+	if !ok {
+		return
+	}
+	teams, problems := bulkimport.Parse(bytes.NewReader(raw), format)
+	if len(problems) > 0 {
+		writeProblems(w, "INVALID_IMPORT", problems)
+		return
+	}
+
+	res, err := h.store.ImportTeams(r.Context(), teams, opts)
+	if err != nil {
+		h.fail(w, r, err, nil)
+		return
+	}
+	writeJSON(w, 200, res)
+}
+
+// writeProblems отвечает 400 со всеми проблемами файла в details.
+func writeProblems(w http.ResponseWriter, code string, problems []bulkimport.Problem) {
+	e := map[string]any{
+		"code":    code,
+		"message": problems[0].String(),
+		"details": problems,
+	}
+	if id := w.Header().Get(middleware.RequestIDHeader); id != "" {
+		e["request_id"] = id
+	}
+	writeJSON(w, 400, map[string]any{"error": e})
+}
@@ -0,0 +1,100 @@
+package apihandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+func (h *Handlers) GetTeamPolicy(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("team_name")
+	if name == "" {
+		writeError(w, 400, "BAD_REQUEST", "team_name required")
+		return
+	}
+
+	p, err := h.store.GetTeamPolicy(r.Context(), name)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "team not found"})
+		return
+	}
+	if notModified(w, r, p.Version) {
+		return
+	}
+
+	setETag(w, p.Version)
+	writeJSON(w, 200, map[string]any{"policy": p})
+}
+
+// SetTeamPolicy целиком заменяет политику: незаданные поля принимают значения по умолчанию.
+func (h *Handlers) SetTeamPolicy(w http.ResponseWriter, r *http.Request) {
+	var in struct {
+		TeamName              string   `json:"team_name"`
+		ReviewerCount         *int     `json:"reviewer_count"`
+		Strategy              *string  `json:"strategy"`
+		FallbackTeams         []string `json:"fallback_teams"`
+		MinLevel              string   `json:"min_level"`
+		MinCount              int      `json:"min_count"`
+		DefaultMaxOpenReviews *int     `json:"default_max_open_reviews"`
+		ReminderAfterHours    *int     `json:"reminder_after_hours"`
+		EscalateAfterHours    *int     `json:"escalate_after_hours"`
+		AllowMergedChanges    bool     `json:"allow_merged_changes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, 400, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if in.TeamName == "" {
+		writeError(w, 400, "BAD_REQUEST", "team_name required")
+		return
+	}
+
+	p := repo.DefaultPolicy(in.TeamName)
+	if in.ReviewerCount != nil {
+		p.ReviewerCount = *in.ReviewerCount
+	}
+	if in.MinLevel != "" {
+		p.MinLevel = in.MinLevel
+	}
+	if in.FallbackTeams != nil {
+		p.FallbackTeams = in.FallbackTeams
+	}
+	p.Strategy, p.MinCount, p.DefaultMaxOpenReviews = in.Strategy, in.MinCount, in.DefaultMaxOpenReviews
+	p.ReminderAfterHours, p.EscalateAfterHours = in.ReminderAfterHours, in.EscalateAfterHours
+	p.AllowMergedChanges = in.AllowMergedChanges
+
+	expected, err := ifMatch(r)
+	if err != nil {
+		writeError(w, 400, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	p, err = h.store.SetTeamPolicy(r.Context(), p, expected)
+	if err != nil {
+		h.fail(w, r, err, messages{
+			repo.ErrNotFound:        "team not found",
+			repo.ErrVersionMismatch: "policy was changed by someone else",
+		})
+		return
+	}
+
+	setETag(w, p.Version)
+	writeJSON(w, 200, map[string]any{"policy": p})
+}
+
+func (h *Handlers) GetPolicyHistory(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("team_name")
+	if name == "" {
+		writeError(w, 400, "BAD_REQUEST", "team_name required")
+		return
+	}
+
+	history, err := h.store.GetPolicyHistory(r.Context(), name)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "team not found"})
+		return
+	}
+
+	writeJSON(w, 200, map[string]any{"team_name": name, "history": history})
+}
@@ -0,0 +1,147 @@
+package apihandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"pr-reviewer-service/internal/lib/worktime"
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+func (h *Handlers) GetSeniorityRule(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("team_name")
+	if name == "" {
+		writeError(w, 400, "BAD_REQUEST", "team_name required")
+		return
+	}
+
+	rule, err := h.store.GetSeniorityRule(r.Context(), name)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "team not found"})
+		return
+	}
+
+	writeJSON(w, 200, map[string]any{"rule": rule})
+}
+
+func (h *Handlers) GetSLAPolicy(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("team_name")
+	if name == "" {
+		writeError(w, 400, "BAD_REQUEST", "team_name required")
+		return
+	}
+
+	p, err := h.store.GetSLAPolicy(r.Context(), name)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "team not found"})
+		return
+	}
+
+	writeJSON(w, 200, map[string]any{"sla": p})
+}
+
+func (h *Handlers) SetSLAPolicy(w http.ResponseWriter, r *http.Request) {
+	var p repo.SLAPolicy
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		writeError(w, 400, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if p.TeamName == "" {
+		writeError(w, 400, "BAD_REQUEST", "team_name required")
+		return
+	}
+	if (p.ReminderAfterHours != nil && *p.ReminderAfterHours <= 0) || (p.EscalateAfterHours != nil && *p.EscalateAfterHours <= 0) {
+		writeError(w, 400, "BAD_REQUEST", "sla thresholds must be positive")
+		return
+	}
+	if p.ReminderAfterHours != nil && p.EscalateAfterHours != nil && *p.EscalateAfterHours <= *p.ReminderAfterHours {
+		writeError(w, 400, "BAD_REQUEST", "escalate_after_hours must be greater than reminder_after_hours")
+		return
+	}
+
+	p, err := h.store.SetSLAPolicy(r.Context(), p)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "team not found"})
+		return
+	}
+
+	writeJSON(w, 200, map[string]any{"sla": p})
+}
+
+func (h *Handlers) SetSeniorityRule(w http.ResponseWriter, r *http.Request) {
+	var rule repo.SeniorityRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		writeError(w, 400, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if rule.TeamName == "" {
+		writeError(w, 400, "BAD_REQUEST", "team_name required")
+		return
+	}
+	if rule.MinLevel == "" {
+		rule.MinLevel = repo.LevelSenior
+	}
+	if !repo.ValidLevel(rule.MinLevel) {
+		writeError(w, 400, "BAD_REQUEST", "unknown min_level")
+		return
+	}
+	if rule.MinCount < 0 {
+		writeError(w, 400, "BAD_REQUEST", "min_count must be non-negative")
+		return
+	}
+
+	rule, err := h.store.SetSeniorityRule(r.Context(), rule)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "team not found"})
+		return
+	}
+
+	writeJSON(w, 200, map[string]any{"rule": rule})
+}
+
+func (h *Handlers) GetTeamSchedule(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("team_name")
+	if name == "" {
+		writeError(w, 400, "BAD_REQUEST", "team_name required")
+		return
+	}
+
+	ts, err := h.store.GetTeamSchedule(r.Context(), name)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "team not found"})
+		return
+	}
+
+	writeJSON(w, 200, map[string]any{"schedule": ts})
+}
+
+func (h *Handlers) SetTeamSchedule(w http.ResponseWriter, r *http.Request) {
+	var ts repo.TeamSchedule
+	if err := json.NewDecoder(r.Body).Decode(&ts); err != nil {
+		writeError(w, 400, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if ts.TeamName == "" {
+		writeError(w, 400, "BAD_REQUEST", "team_name required")
+		return
+	}
+	if err := ts.Validate(); err != nil {
+		writeError(w, 400, "BAD_REQUEST", err.Error())
+		return
+	}
+	for _, day := range ts.Holidays {
+		if _, err := time.Parse(worktime.DateLayout, day); err != nil {
+			writeError(w, 400, "BAD_REQUEST", "holidays must be dates in YYYY-MM-DD format")
+			return
+		}
+	}
+
+	ts, err := h.store.SetTeamSchedule(r.Context(), ts)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "team not found"})
+		return
+	}
+
+	writeJSON(w, 200, map[string]any{"schedule": ts})
+}
@@ -13,13 +13,27 @@ func (h *Handlers) CreateTeam(w http.ResponseWriter, r *http.Request) {
 		writeError(w, 400, "BAD_REQUEST", "invalid json")
 		return
 	}
+	for _, m := range t.Members {
+		if m.Level != "" && !repo.ValidLevel(m.Level) {
+			writeError(w, 400, "BAD_REQUEST", "unknown level for user "+m.UserID)
+			return
+		}
+	}
 
-	err := h.store.CreateTeam(r.Context(), t)
+	expected, err := ifMatch(r)
 	if err != nil {
-		writeError(w, 400, "TEAM_EXISTS", "team already exists")
+		writeError(w, 400, "BAD_REQUEST", err.Error())
 		return
 	}
 
+	version, err := h.store.CreateTeam(r.Context(), t, expected)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrVersionMismatch: "team was changed by someone else"})
+		return
+	}
+	t.Version = version
+
+	setETag(w, version)
 	writeJSON(w, 201, map[string]any{"team": t})
 }
 
@@ -31,13 +45,14 @@ func (h *Handlers) GetTeam(w http.ResponseWriter, r *http.Request) {
 	}
 
 	team, err := h.store.GetTeam(r.Context(), name)
-	if err == repo.ErrNotFound {
-		writeError(w, 404, "NOT_FOUND", "team not found")
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "team not found"})
 		return
-	} else if err != nil {
-		writeError(w, 500, "INTERNAL", err.Error())
+	}
+	if notModified(w, r, team.Version) {
 		return
 	}
 
+	setETag(w, team.Version)
 	writeJSON(w, 200, team)
 }
@@ -3,6 +3,7 @@ package apihandler
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"pr-reviewer-service/internal/storage/repo"
 )
@@ -18,14 +19,72 @@ func (h *Handlers) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	}
 
 	user, err := h.store.SetUserActive(r.Context(), body.UserID, body.IsActive)
-	if err == repo.ErrNotFound {
-		writeError(w, 404, "NOT_FOUND", "user not found")
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "user not found"})
+		return
+	}
+
+	writeJSON(w, 200, map[string]any{"user": user})
+}
+
+func (h *Handlers) SetAvailability(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		UserID         string     `json:"user_id"`
+		AwayUntil      *time.Time `json:"away_until"`
+		MaxOpenReviews *int       `json:"max_open_reviews"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, 400, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if body.UserID == "" {
+		writeError(w, 400, "BAD_REQUEST", "user_id required")
+		return
+	}
+	if body.MaxOpenReviews != nil && *body.MaxOpenReviews < 0 {
+		writeError(w, 400, "BAD_REQUEST", "max_open_reviews must be non-negative")
+		return
+	}
+
+	user, err := h.store.SetAvailability(r.Context(), body.UserID, body.AwayUntil, body.MaxOpenReviews)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "user not found"})
 		return
 	}
 
 	writeJSON(w, 200, map[string]any{"user": user})
 }
 
+func (h *Handlers) SetSchedule(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		UserID string `json:"user_id"`
+		repo.UserSchedule
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, 400, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if body.UserID == "" {
+		writeError(w, 400, "BAD_REQUEST", "user_id required")
+		return
+	}
+	if err := body.Validate(); err != nil {
+		writeError(w, 400, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	sch, err := h.store.SetUserSchedule(r.Context(), body.UserID, body.UserSchedule)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "user not found"})
+		return
+	}
+
+	writeJSON(w, 200, map[string]any{
+		"user_id":  body.UserID,
+		"schedule": sch,
+	})
+}
+
 func (h *Handlers) GetPRsForReviewer(w http.ResponseWriter, r *http.Request) {
 	id := r.URL.Query().Get("user_id")
 	if id == "" {
@@ -35,7 +94,7 @@ func (h *Handlers) GetPRsForReviewer(w http.ResponseWriter, r *http.Request) {
 
 	prs, err := h.store.GetPRsForReviewer(r.Context(), id)
 	if err != nil {
-		writeError(w, 500, "INTERNAL", err.Error())
+		h.fail(w, r, err, nil)
 		return
 	}
 
@@ -44,3 +103,51 @@ func (h *Handlers) GetPRsForReviewer(w http.ResponseWriter, r *http.Request) {
 		"pull_requests": prs,
 	})
 }
+
+// DeclineReview — ревьювер сам отказывается от назначения и получает замену.
+func (h *Handlers) DeclineReview(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		UserID  string `json:"user_id"`
+		PRID    string `json:"pull_request_id"`
+		Reason  string `json:"reason"`
+		Comment string `json:"comment"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, 400, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if !repo.ValidDeclineReason(body.Reason) {
+		writeError(w, 400, "BAD_REQUEST", "unknown decline reason")
+		return
+	}
+
+	expected, err := ifMatch(r)
+	if err != nil {
+		writeError(w, 400, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	newID, err := h.store.DeclineReview(r.Context(), body.PRID, body.UserID, body.Reason, body.Comment, expected)
+	if err != nil {
+		h.fail(w, r, err, messages{
+			repo.ErrPRMerged:        "cannot decline review of merged PR",
+			repo.ErrNotAssigned:     "user is not a reviewer of this PR",
+			repo.ErrNoCandidate:     "no replacement reviewer available",
+			repo.ErrSeniority:       "replacement would break team seniority rule",
+			repo.ErrNotFound:        "pr not found",
+			repo.ErrVersionMismatch: "someone else changed this PR",
+		})
+		return
+	}
+
+	pr, err := h.store.GetPR(r.Context(), body.PRID)
+	if err != nil {
+		h.fail(w, r, err, messages{repo.ErrNotFound: "pr not found"})
+		return
+	}
+	setETag(w, pr.Version)
+	writeJSON(w, 200, map[string]any{
+		"pr":          pr,
+		"replaced_by": newID,
+	})
+}
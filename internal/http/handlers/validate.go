@@ -0,0 +1,87 @@
+package apihandler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sort"
+
+	"pr-reviewer-service/internal/http/openapi"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// maxBodyBytes ограничивает размер тела, которое читается для проверки.
+const maxBodyBytes = 1 << 20
+
+// validateRequests сверяет query-параметры и JSON-тело запроса с описанием
+// операции в OpenAPI. Незадокументированные пути пропускаются дальше — на них
+// ответит роутер.
+func validateRequests(doc *openapi.Document) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			op := doc.Operation(r.Method, r.URL.Path)
+			if op == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			q := r.URL.Query()
+			violations := doc.ValidateQuery(op, q.Get)
+
+			// файлы (CSV, YAML, снимки) обработчики читают сами, со своим пределом
+			if op.RequestBody != nil && op.RequestBody.Content["application/json"].Schema != nil {
+				raw, ok := readBody(w, r, maxBodyBytes)
+				if !ok {
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(raw))
+				violations = append(violations, doc.ValidateBody(op, raw)...)
+			}
+
+			if len(violations) > 0 {
+				sort.Slice(violations, func(i, j int) bool { return violations[i].Field < violations[j].Field })
+				writeValidationError(w, violations)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// readBody читает тело целиком; если оно длиннее limit, отвечает 413.
+func readBody(w http.ResponseWriter, r *http.Request, limit int64) ([]byte, bool) {
+	raw, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	if err != nil {
+		writeError(w, 400, "BAD_REQUEST", "cannot read request body")
+		return nil, false
+	}
+	if int64(len(raw)) > limit {
+		writeError(w, 413, "BODY_TOO_LARGE", "request body too large")
+		return nil, false
+	}
+	return raw, true
+}
+
+func writeValidationError(w http.ResponseWriter, violations []openapi.Violation) {
+	first := violations[0]
+	msg := first.Message
+	if first.Field != "" {
+		msg = first.Field + " " + msg
+	}
+	e := map[string]any{
+		"code":    "VALIDATION_FAILED",
+		"message": msg,
+		"details": violations,
+	}
+	if id := w.Header().Get(middleware.RequestIDHeader); id != "" {
+		e["request_id"] = id
+	}
+	writeJSON(w, 400, map[string]any{"error": e})
+}
+
+func (h *Handlers) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, _ = w.Write(openapi.JSON())
+}
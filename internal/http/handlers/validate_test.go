@@ -0,0 +1,139 @@
+package apihandler
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"pr-reviewer-service/internal/events"
+	"pr-reviewer-service/internal/http/openapi"
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// newTestRouter собирает роутер над хранилищем без пула: проверки ниже
+// отвечают до обработчиков и в базу не ходят.
+func newTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+	return NewRouter(repo.New(nil, repo.Options{}), events.NewBroker(), slog.New(slog.NewTextHandler(io.Discard, nil)), Options{
+		AdminToken: "admin-token",
+	})
+}
+
+func TestRouterMatchesContract(t *testing.T) {
+	r := newTestRouter(t)
+	doc := openapi.Spec()
+
+	registered := map[string]bool{}
+	err := chi.Walk(r, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		registered[strings.ToLower(method)+" "+route] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var problems []string
+	for key := range registered {
+		method, path, _ := strings.Cut(key, " ")
+		if doc.Operation(method, path) == nil {
+			problems = append(problems, "undocumented route "+strings.ToUpper(method)+" "+path)
+		}
+	}
+	for path, item := range doc.Paths {
+		for method := range item {
+			if !registered[method+" "+path] {
+				problems = append(problems, "documented operation without handler "+strings.ToUpper(method)+" "+path)
+			}
+			if !r.Match(chi.NewRouteContext(), strings.ToUpper(method), path) {
+				problems = append(problems, "router does not match "+strings.ToUpper(method)+" "+path)
+			}
+		}
+	}
+	sort.Strings(problems)
+	for _, p := range problems {
+		t.Error(p)
+	}
+}
+
+func TestInvalidBodiesRejected(t *testing.T) {
+	r := newTestRouter(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		field  string
+	}{
+		{"missing required field", "POST", "/pullRequest/create", `{"pull_request_id":"pr-1","pull_request_name":"x"}`, "author_id"},
+		{"empty required string", "POST", "/pullRequest/create", `{"pull_request_id":"","pull_request_name":"x","author_id":"u1"}`, "pull_request_id"},
+		{"wrong type", "POST", "/team/add", `{"team_name":5,"members":[]}`, "team_name"},
+		{"wrong item type", "POST", "/team/add", `{"team_name":"t","members":[1]}`, "members[0]"},
+		{"not an object", "POST", "/users/setIsActive", `[]`, ""},
+		{"invalid json", "POST", "/pullRequest/merge", `{`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			details := assertValidationFailed(t, rec)
+			if tt.field == "" {
+				return
+			}
+			for _, d := range details {
+				if d.Field == tt.field {
+					return
+				}
+			}
+			t.Errorf("no violation for %q in %+v", tt.field, details)
+		})
+	}
+}
+
+// TestEveryJSONOperationValidates отправляет строку вместо объекта в каждую
+// описанную операцию с JSON-телом: ни одна не должна дойти до обработчика.
+func TestEveryJSONOperationValidates(t *testing.T) {
+	r := newTestRouter(t)
+	for path, item := range openapi.Spec().Paths {
+		for method, op := range item {
+			if op.RequestBody == nil || op.RequestBody.Content["application/json"].Schema == nil {
+				continue
+			}
+			t.Run(strings.ToUpper(method)+" "+path, func(t *testing.T) {
+				req := httptest.NewRequest(strings.ToUpper(method), path, strings.NewReader(`"not an object"`))
+				req.Header.Set("Authorization", "Bearer admin-token")
+				rec := httptest.NewRecorder()
+				r.ServeHTTP(rec, req)
+				assertValidationFailed(t, rec)
+			})
+		}
+	}
+}
+
+func assertValidationFailed(t *testing.T, rec *httptest.ResponseRecorder) []openapi.Violation {
+	t.Helper()
+	if rec.Code != 400 {
+		t.Fatalf("status %d, want 400: %s", rec.Code, rec.Body)
+	}
+	var resp struct {
+		Error struct {
+			Code    string              `json:"code"`
+			Details []openapi.Violation `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error.Code != "VALIDATION_FAILED" {
+		t.Fatalf("code %q, want VALIDATION_FAILED", resp.Error.Code)
+	}
+	return resp.Error.Details
+}
@@ -0,0 +1,95 @@
+package openapi
+
+// Административные операции закрыты токеном и не принимают ключ идемпотентности:
+// восстановление и так возможно только один раз, в пустую базу.
+
+const ndjson = "application/x-ndjson"
+
+func adminOp(id, summary string, responses map[string]Response) *Operation {
+	responses["401"] = errResp("Нет или неверный bearer-токен администратора")
+	if _, ok := responses["404"]; !ok {
+		responses["404"] = errResp("Административный API выключен: не задан admin.token")
+	}
+	return op(id, "Admin", summary, responses)
+}
+
+func adminSchemas(m map[string]*Schema) {
+	m["RestoreResult"] = obj(map[string]*Schema{
+		"tables":  dict(integer()),
+		"rows":    integer(),
+		"dry_run": boolean(),
+	}, "tables", "rows", "dry_run")
+
+	fairness := obj(map[string]*Schema{
+		"assignments": integer(),
+		"reviewers":   integer(),
+		"min":         integer(),
+		"max":         integer(),
+		"mean":        number(),
+		"stddev":      number(),
+		"cv":          number(),
+		"gini":        number(),
+	}, "assignments", "reviewers", "min", "max", "mean", "stddev", "cv", "gini")
+	m["SimulationRequest"] = obj(map[string]*Schema{
+		"team_name":                id(),
+		"reviewer_count":           intRange(0, 10),
+		"strategy":                 enum("random", "least_recent"),
+		"fallback_teams":           arr(id()),
+		"min_level":                enum("junior", "middle", "senior", "lead"),
+		"min_count":                intMin(0),
+		"default_max_open_reviews": intMin(0),
+		"from":                     dateTime(),
+		"to":                       dateTime(),
+		"limit":                    intRange(0, 10000),
+		"seed":                     integer(),
+	}, "team_name")
+	m["SimulationResult"] = obj(map[string]*Schema{
+		"policy":        ref("TeamPolicy"),
+		"pull_requests": integer(),
+		"from":          dateTime(),
+		"to":            dateTime(),
+		"outcomes": obj(map[string]*Schema{
+			"full":               integer(),
+			"short":              integer(),
+			"unassigned":         integer(),
+			"rejected_seniority": integer(),
+			"used_fallback":      integer(),
+			"ran_out_rate":       number(),
+		}, "full", "short", "unassigned", "rejected_seniority", "used_fallback", "ran_out_rate"),
+		"simulated": fairness,
+		"actual":    fairness,
+		"reviewers": arr(obj(map[string]*Schema{
+			"user_id":   str(),
+			"team_name": str(),
+			"assigned":  integer(),
+			"actual":    integer(),
+			"peak_open": integer(),
+		}, "user_id", "team_name", "assigned", "actual", "peak_open")),
+	}, "policy", "pull_requests", "outcomes", "simulated", "actual", "reviewers")
+}
+
+func adminPaths(paths map[string]PathItem) {
+	paths["/admin/export"] = PathItem{
+		"get": adminOp("exportSnapshot", "Снимок всех таблиц сервиса в формате JSON Lines", map[string]Response{
+			"200": {Description: "Заголовок, строки таблиц и итоговая строка с их числом",
+				Content: map[string]MediaType{ndjson: {Schema: str()}}},
+		}),
+	}
+	paths["/admin/import"] = PathItem{
+		"post": withQuery(withRawBody(adminOp("importSnapshot", "Восстановить снимок из /admin/export в пустую базу", map[string]Response{
+			"200": jsonResp("Сколько строк загружено (с dry_run — загрузилось бы)", ref("RestoreResult")),
+			"400": errResp("Снимок не прошёл проверку; все проблемы перечислены в details"),
+			"409": errResp("База не пустая"),
+			"413": errResp("Снимок больше 512 МБ"),
+			"415": errResp("Неподдерживаемый Content-Type"),
+		}), ndjson, "application/jsonl"),
+			query("dry_run", false, boolean())),
+	}
+	paths["/admin/simulate"] = PathItem{
+		"post": withBody(adminOp("simulateAssignments", "Прогнать исторические PR команды через выбор ревьюверов с другой политикой", map[string]Response{
+			"200": jsonResp("Распределение нагрузки и справедливость в симуляции и на самом деле", ref("SimulationResult")),
+			"400": errResp("Неверные параметры или политика"),
+			"404": errResp("Команда не найдена или административный API выключен (не задан admin.token)"),
+		}), ref("SimulationRequest")),
+	}
+}
@@ -0,0 +1,152 @@
+package openapi
+
+// SCIM 2.0 описан отдельно: ответы и ошибки в формате application/scim+json
+// (RFC 7644), а не в общем формате сервиса, и ключ идемпотентности не нужен.
+
+const scimMediaType = "application/scim+json"
+
+func scimResp(desc string, s *Schema) Response {
+	return Response{Description: desc, Content: map[string]MediaType{scimMediaType: {Schema: s}}}
+}
+
+func scimOp(id, summary string, responses map[string]Response) *Operation {
+	scimErr := ref("ScimError")
+	for _, code := range []string{"400", "401", "404", "500"} {
+		if _, ok := responses[code]; !ok {
+			responses[code] = scimResp("Ошибка SCIM", scimErr)
+		}
+	}
+	return &Operation{OperationID: id, Tags: []string{"SCIM"}, Summary: summary, Responses: responses}
+}
+
+// withSCIMBody описывает тело SCIM; его проверяет сам обработчик, чтобы ошибки
+// были в формате SCIM.
+func withSCIMBody(o *Operation, s *Schema) *Operation {
+	o.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{scimMediaType: {Schema: s}}}
+	return o
+}
+
+func withPathID(o *Operation) *Operation {
+	o.Parameters = append(o.Parameters, Parameter{Name: "id", In: "path", Required: true, Schema: str()})
+	return o
+}
+
+func scimSchemas(m map[string]*Schema) {
+	meta := obj(map[string]*Schema{
+		"resourceType": str(),
+		"location":     str(),
+		"version":      str(),
+	})
+	link := obj(map[string]*Schema{"value": str(), "display": str(), "$ref": str()}, "value")
+
+	m["ScimUser"] = obj(map[string]*Schema{
+		"schemas":     arr(str()),
+		"id":          str(),
+		"userName":    str(),
+		"displayName": str(),
+		"name":        obj(map[string]*Schema{"formatted": str(), "givenName": str(), "familyName": str()}),
+		"active":      boolean(),
+		"groups":      arr(link),
+		"meta":        meta,
+	}, "userName")
+	m["ScimGroup"] = obj(map[string]*Schema{
+		"schemas":     arr(str()),
+		"id":          str(),
+		"displayName": str(),
+		"members":     arr(link),
+		"meta":        meta,
+	}, "displayName")
+	m["ScimListResponse"] = obj(map[string]*Schema{
+		"schemas":      arr(str()),
+		"totalResults": integer(),
+		"startIndex":   integer(),
+		"itemsPerPage": integer(),
+		"Resources":    arr(&Schema{Type: "object"}),
+	}, "schemas", "totalResults", "Resources")
+	m["ScimPatchOp"] = obj(map[string]*Schema{
+		"schemas": arr(str()),
+		"Operations": arr(obj(map[string]*Schema{
+			"op":    enum("add", "replace", "remove"),
+			"path":  str(),
+			"value": {},
+		}, "op")),
+	}, "schemas", "Operations")
+	m["ScimError"] = obj(map[string]*Schema{
+		"schemas":  arr(str()),
+		"status":   str(),
+		"scimType": str(),
+		"detail":   str(),
+	}, "schemas", "status")
+}
+
+func scimPaths(paths map[string]PathItem) {
+	list := scimResp("Страница ресурсов", ref("ScimListResponse"))
+	listParams := []Parameter{
+		query("filter", false, str()),
+		query("startIndex", false, intMin(1)),
+		query("count", false, intMin(0)),
+	}
+	noContent := Response{Description: "Готово"}
+
+	paths["/scim/v2/ServiceProviderConfig"] = PathItem{
+		"get": scimOp("scimServiceProviderConfig", "Возможности SCIM-сервера", map[string]Response{
+			"200": scimResp("Конфигурация", &Schema{Type: "object"}),
+		}),
+	}
+	paths["/scim/v2/ResourceTypes"] = PathItem{
+		"get": scimOp("scimResourceTypes", "Типы ресурсов: User и Group", map[string]Response{
+			"200": list,
+		}),
+	}
+
+	user := ref("ScimUser")
+	paths["/scim/v2/Users"] = PathItem{
+		"get": withQuery(scimOp("scimListUsers", "Пользователи с фильтром и постраничной выдачей", map[string]Response{
+			"200": list,
+		}), listParams...),
+		"post": withSCIMBody(scimOp("scimCreateUser", "Завести пользователя в команде по умолчанию", map[string]Response{
+			"201": scimResp("Создан", user),
+			"409": scimResp("Пользователь уже есть", ref("ScimError")),
+		}), user),
+	}
+	paths["/scim/v2/Users/{id}"] = PathItem{
+		"get": withPathID(scimOp("scimGetUser", "Пользователь", map[string]Response{
+			"200": scimResp("Пользователь", user),
+		})),
+		"put": withSCIMBody(withPathID(scimOp("scimReplaceUser", "Заменить имя и активность", map[string]Response{
+			"200": scimResp("Пользователь", user),
+		})), user),
+		"patch": withSCIMBody(withPathID(scimOp("scimPatchUser", "Изменить атрибуты; active=false выключает из ротации", map[string]Response{
+			"200": scimResp("Пользователь", user),
+		})), ref("ScimPatchOp")),
+		"delete": withPathID(scimOp("scimDeleteUser", "Выключить пользователя (он остаётся в истории)", map[string]Response{
+			"204": noContent,
+		})),
+	}
+
+	group := ref("ScimGroup")
+	members := []Parameter{query("attributes", false, str()), query("excludedAttributes", false, str())}
+	paths["/scim/v2/Groups"] = PathItem{
+		"get": withQuery(scimOp("scimListGroups", "Команды с фильтром и постраничной выдачей", map[string]Response{
+			"200": list,
+		}), append(listParams, members...)...),
+		"post": withSCIMBody(scimOp("scimCreateGroup", "Создать команду и перевести в неё участников", map[string]Response{
+			"201": scimResp("Создана", group),
+			"409": scimResp("Команда уже есть", ref("ScimError")),
+		}), group),
+	}
+	paths["/scim/v2/Groups/{id}"] = PathItem{
+		"get": withQuery(withPathID(scimOp("scimGetGroup", "Команда", map[string]Response{
+			"200": scimResp("Команда", group),
+		})), members...),
+		"put": withSCIMBody(withPathID(scimOp("scimReplaceGroup", "Заменить состав команды", map[string]Response{
+			"200": scimResp("Команда", group),
+		})), group),
+		"patch": withSCIMBody(withPathID(scimOp("scimPatchGroup", "Добавить или исключить участников", map[string]Response{
+			"200": scimResp("Команда", group),
+		})), ref("ScimPatchOp")),
+		"delete": withPathID(scimOp("scimDeleteGroup", "Перевести участников в команду по умолчанию", map[string]Response{
+			"204": noContent,
+		})),
+	}
+}
@@ -0,0 +1,638 @@
+package openapi
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Конструкторы схем. Описание контракта ниже собирается из них, чтобы
+// документ и проверка запросов использовали одни и те же определения.
+
+func ref(name string) *Schema { return &Schema{Ref: "#/components/schemas/" + name} }
+
+func str() *Schema { return &Schema{Type: "string"} }
+
+// id — обязательная непустая строка-идентификатор.
+func id() *Schema {
+	n := 1
+	return &Schema{Type: "string", MinLength: &n}
+}
+
+func enum(values ...string) *Schema { return &Schema{Type: "string", Enum: values} }
+
+func dateTime() *Schema { return &Schema{Type: "string", Format: "date-time"} }
+
+func date() *Schema { return &Schema{Type: "string", Format: "date"} }
+
+func integer() *Schema { return &Schema{Type: "integer"} }
+
+func number() *Schema { return &Schema{Type: "number"} }
+
+func intRange(min, max float64) *Schema {
+	return &Schema{Type: "integer", Minimum: &min, Maximum: &max}
+}
+
+func intMin(min float64) *Schema { return &Schema{Type: "integer", Minimum: &min} }
+
+func boolean() *Schema { return &Schema{Type: "boolean"} }
+
+func arr(items *Schema) *Schema { return &Schema{Type: "array", Items: items} }
+
+func dict(values *Schema) *Schema { return &Schema{Type: "object", AdditionalProperties: values} }
+
+func nullable(s *Schema) *Schema {
+	c := *s
+	c.Nullable = true
+	return &c
+}
+
+func obj(props map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: "object", Properties: props, Required: required}
+}
+
+func query(name string, required bool, s *Schema) Parameter {
+	return Parameter{Name: name, In: "query", Required: required, Schema: s}
+}
+
+func body(s *Schema) *RequestBody {
+	return &RequestBody{Required: true, Content: map[string]MediaType{"application/json": {Schema: s}}}
+}
+
+func jsonResp(desc string, s *Schema) Response {
+	return Response{Description: desc, Content: map[string]MediaType{"application/json": {Schema: s}}}
+}
+
+func errResp(desc string) Response { return jsonResp(desc, ref("ErrorResponse")) }
+
+func op(id, tag, summary string, responses map[string]Response) *Operation {
+	if _, ok := responses["400"]; !ok {
+		responses["400"] = errResp("Некорректный запрос")
+	}
+	responses["500"] = errResp("Внутренняя ошибка; подробности только в логе сервиса по request_id")
+	return &Operation{OperationID: id, Tags: []string{tag}, Summary: summary, Responses: responses}
+}
+
+func withQuery(o *Operation, params ...Parameter) *Operation {
+	o.Parameters = append(o.Parameters, params...)
+	return o
+}
+
+// withIfMatch помечает операцию, поддерживающую оптимистическую блокировку.
+func withIfMatch(o *Operation) *Operation {
+	o.Parameters = append(o.Parameters, Parameter{
+		Name:        "If-Match",
+		In:          "header",
+		Description: "ETag (версия), которую видел клиент; при расхождении — 412",
+		Schema:      &Schema{Type: "string"},
+	})
+	o.Responses["412"] = errResp("Объект изменён после чтения")
+	return o
+}
+
+// withETag помечает чтение, которое отдаёт ETag и понимает If-None-Match.
+func withETag(o *Operation) *Operation {
+	o.Parameters = append(o.Parameters, Parameter{Name: "If-None-Match", In: "header", Schema: &Schema{Type: "string"}})
+	o.Responses["304"] = Response{Description: "Версия не изменилась"}
+	return o
+}
+
+func withBody(o *Operation, s *Schema) *Operation {
+	o.RequestBody = body(s)
+	return o
+}
+
+// withRawBody описывает тело не в JSON (файл); валидатор такие тела не проверяет.
+func withRawBody(o *Operation, mediaTypes ...string) *Operation {
+	content := map[string]MediaType{}
+	for _, mt := range mediaTypes {
+		content[mt] = MediaType{Schema: str()}
+	}
+	o.RequestBody = &RequestBody{Required: true, Content: content}
+	return o
+}
+
+var (
+	specOnce sync.Once
+	spec     *Document
+	specJSON []byte
+)
+
+// Spec возвращает описание API сервиса.
+func Spec() *Document {
+	specOnce.Do(func() {
+		spec = build()
+		specJSON, _ = json.Marshal(spec)
+	})
+	return spec
+}
+
+// JSON возвращает документ в сериализованном виде.
+func JSON() []byte {
+	Spec()
+	return specJSON
+}
+
+func schemas() map[string]*Schema {
+	levels := enum("junior", "middle", "senior", "lead")
+	workSchedule := map[string]*Schema{
+		"time_zone":  nullable(str()),
+		"work_start": nullable(str()),
+		"work_end":   nullable(str()),
+		"work_days":  nullable(arr(intRange(1, 7))),
+	}
+	importTeam := obj(map[string]*Schema{
+		"team_name": str(),
+		"created":   boolean(),
+		"changed":   boolean(),
+		"version":   integer(),
+	})
+	importChange := obj(map[string]*Schema{
+		"action":    enum("create", "update", "move", "deactivate"),
+		"user_id":   str(),
+		"team_name": str(),
+		"from_team": str(),
+		"fields":    arr(str()),
+	}, "action", "user_id", "team_name")
+
+	teamSchedule := map[string]*Schema{"team_name": id(), "holidays": arr(date())}
+	userSchedule := map[string]*Schema{"holidays": arr(date())}
+	for k, v := range workSchedule {
+		teamSchedule[k] = v
+		userSchedule[k] = v
+	}
+
+	return map[string]*Schema{
+		"ErrorResponse": obj(map[string]*Schema{
+			"error": obj(map[string]*Schema{
+				"code":       str(),
+				"message":    str(),
+				"request_id": str(),
+				"details":    arr(ref("Violation")),
+			}, "code", "message"),
+		}, "error"),
+		// line — только у ошибок файла импорта
+		"Violation": obj(map[string]*Schema{"field": str(), "message": str(), "line": integer()}, "message"),
+		"Skill":     obj(map[string]*Schema{"tag": id(), "level": intRange(1, 5)}, "tag", "level"),
+		"TeamMember": obj(map[string]*Schema{
+			"user_id":   id(),
+			"username":  str(),
+			"is_active": boolean(),
+			"level":     levels,
+			"skills":    nullable(arr(ref("Skill"))),
+		}, "user_id", "username", "is_active"),
+		"Team": obj(map[string]*Schema{
+			"team_name": id(),
+			"members":   arr(ref("TeamMember")),
+			"version":   integer(),
+		}, "team_name", "members"),
+		"User": obj(map[string]*Schema{
+			"user_id":          str(),
+			"username":         str(),
+			"team_name":        str(),
+			"is_active":        boolean(),
+			"level":            levels,
+			"max_open_reviews": nullable(integer()),
+			"away_until":       nullable(dateTime()),
+		}, "user_id", "username", "team_name", "is_active"),
+		"PullRequest": obj(map[string]*Schema{
+			"pull_request_id":    str(),
+			"pull_request_name":  str(),
+			"author_id":          str(),
+			"status":             enum("OPEN", "MERGED"),
+			"assigned_reviewers": arr(str()),
+			"required_tags":      arr(str()),
+			"createdAt":          dateTime(),
+			"mergedAt":           nullable(dateTime()),
+			"version":            integer(),
+		}, "pull_request_id", "pull_request_name", "author_id", "status", "assigned_reviewers"),
+		"PullRequestShort": obj(map[string]*Schema{
+			"pull_request_id":    str(),
+			"pull_request_name":  str(),
+			"author_id":          str(),
+			"status":             enum("OPEN", "MERGED"),
+			"assigned_at":        dateTime(),
+			"review_age_seconds": integer(),
+			"review_age":         str(),
+		}, "pull_request_id", "pull_request_name", "author_id", "status"),
+		"SeniorityRule": obj(map[string]*Schema{
+			"team_name": id(),
+			"min_level": levels,
+			"min_count": intMin(0),
+		}, "team_name"),
+		"SLAPolicy": obj(map[string]*Schema{
+			"team_name":            id(),
+			"reminder_after_hours": nullable(intMin(1)),
+			"escalate_after_hours": nullable(intMin(1)),
+		}, "team_name"),
+		"TeamPolicy": obj(map[string]*Schema{
+			"team_name":                id(),
+			"reviewer_count":           intRange(0, 10),
+			"strategy":                 nullable(enum("random", "least_recent")),
+			"fallback_teams":           arr(id()),
+			"min_level":                levels,
+			"min_count":                intMin(0),
+			"default_max_open_reviews": nullable(intMin(0)),
+			"reminder_after_hours":     nullable(intMin(1)),
+			"escalate_after_hours":     nullable(intMin(1)),
+			"allow_merged_changes":     boolean(),
+			"version":                  integer(),
+			"updated_at":               dateTime(),
+		}, "team_name"),
+		"PolicyRevision": obj(map[string]*Schema{
+			"version":    integer(),
+			"policy":     ref("TeamPolicy"),
+			"changed_at": dateTime(),
+		}, "version", "policy", "changed_at"),
+		"WorkSchedule": obj(workSchedule),
+		"TeamSchedule": obj(teamSchedule, "team_name"),
+		"UserSchedule": obj(userSchedule),
+		"Assignment": obj(map[string]*Schema{
+			"assignment_id":        integer(),
+			"pull_request_id":      str(),
+			"kind":                 enum("create", "reassign", "escalation", "manual", "decline"),
+			"strategy":             str(),
+			"algorithm":            str(),
+			"seed":                 integer(),
+			"replaced_reviewer_id": str(),
+			"reason":               str(),
+			"reviewers":            arr(str()),
+			"created_at":           dateTime(),
+		}, "assignment_id", "pull_request_id", "kind", "reviewers", "created_at"),
+		"CandidateExplanation": obj(map[string]*Schema{
+			"user_id":         str(),
+			"level":           levels,
+			"excluded":        arr(str()),
+			"tag_overlap":     integer(),
+			"skill_level":     integer(),
+			"meets_seniority": boolean(),
+			"last_paired_at":  dateTime(),
+			"rank":            str(),
+			"position":        integer(),
+			"selected":        boolean(),
+			"open_reviews":    integer(),
+			"fallback":        boolean(),
+		}, "user_id", "selected"),
+		"Explanation": obj(map[string]*Schema{
+			"assignment_id":   integer(),
+			"pull_request_id": str(),
+			"kind":            str(),
+			"strategy":        str(),
+			"algorithm":       str(),
+			"seed":            integer(),
+			"reviewers":       arr(str()),
+			"created_at":      dateTime(),
+			"required_tags":   arr(str()),
+			"seniority_rule":  ref("SeniorityRule"),
+			"candidates":      arr(ref("CandidateExplanation")),
+			"manual": obj(map[string]*Schema{
+				"added":                arr(str()),
+				"removed":              arr(str()),
+				"override":             boolean(),
+				"overridden":           dict(arr(str())),
+				"seniority_overridden": boolean(),
+			}),
+		}, "assignment_id", "pull_request_id", "candidates"),
+		"ReviewerStat": obj(map[string]*Schema{
+			"user_id":                        str(),
+			"review_count":                   integer(),
+			"open_review_count":              integer(),
+			"oldest_open_review_age_seconds": integer(),
+			"decline_count":                  integer(),
+			"declines_by_reason":             dict(integer()),
+		}, "user_id", "review_count"),
+		"PairMatrix": obj(map[string]*Schema{
+			"team_name": str(),
+			"members":   arr(str()),
+			"matrix":    arr(arr(integer())),
+			"pairs": arr(obj(map[string]*Schema{
+				"author_id":        str(),
+				"reviewer_id":      str(),
+				"review_count":     integer(),
+				"last_assigned_at": dateTime(),
+			})),
+		}, "team_name", "members", "matrix"),
+		"ImportResult": obj(map[string]*Schema{
+			"teams":     arr(importTeam),
+			"changes":   arr(importChange),
+			"unchanged": integer(),
+			"dry_run":   boolean(),
+		}, "teams", "changes", "unchanged", "dry_run"),
+		"SyncResult": obj(map[string]*Schema{
+			"teams":                arr(importTeam),
+			"changes":              arr(importChange),
+			"unchanged":            integer(),
+			"dry_run":              boolean(),
+			"plan":                 str(),
+			"reassigned_prs_count": integer(),
+			"reassign_failures":    dict(str()),
+		}, "teams", "changes", "unchanged", "dry_run", "plan"),
+		"BulkDeactivateResult": obj(map[string]*Schema{
+			"team_name":            str(),
+			"deactivated_users":    arr(str()),
+			"reassigned_prs_count": integer(),
+			"reassign_failures":    dict(str()),
+			"version":              integer(),
+			"dry_run":              boolean(),
+		}, "team_name", "deactivated_users"),
+		"Event": obj(map[string]*Schema{
+			"id":              integer(),
+			"type":            str(),
+			"team_name":       str(),
+			"user_id":         str(),
+			"pull_request_id": str(),
+			"related_users":   arr(str()),
+			"payload":         &Schema{Type: "object"},
+			"created_at":      dateTime(),
+		}, "id", "type", "created_at"),
+	}
+}
+
+func build() *Document {
+	teamName := query("team_name", true, id())
+	prID := query("pull_request_id", true, id())
+	notFound := errResp("Не найдено")
+	conflict := errResp("Конфликт состояния")
+
+	paths := map[string]PathItem{
+		"/health": {
+			"get": &Operation{OperationID: "health", Tags: []string{"Service"}, Summary: "Проверка живости",
+				Responses: map[string]Response{"200": {Description: "OK"}}},
+		},
+		"/openapi.json": {
+			"get": &Operation{OperationID: "openapi", Tags: []string{"Service"}, Summary: "Этот документ",
+				Responses: map[string]Response{"200": jsonResp("Документ OpenAPI", &Schema{Type: "object"})}},
+		},
+		"/events/stream": {
+			"get": withQuery(op("streamEvents", "Events", "Поток событий (Server-Sent Events)", map[string]Response{
+				"200": {Description: "Поток событий", Content: map[string]MediaType{"text/event-stream": {Schema: ref("Event")}}},
+			}),
+				query("team_name", false, str()),
+				query("user_id", false, str()),
+				query("last_event_id", false, intMin(0)),
+			),
+		},
+
+		"/team/add": {
+			"post": withIfMatch(withBody(op("createTeam", "Teams", "Создать команду с участниками", map[string]Response{
+				"201": jsonResp("Команда создана", obj(map[string]*Schema{"team": ref("Team")})),
+			}), ref("Team"))),
+		},
+		"/team/get": {
+			"get": withETag(withQuery(op("getTeam", "Teams", "Получить команду", map[string]Response{
+				"200": jsonResp("Команда", ref("Team")),
+				"404": notFound,
+			}), teamName)),
+		},
+		"/team/deactivate": {
+			"post": withIfMatch(withBody(op("deactivateTeam", "Teams", "Деактивировать всех участников команды", map[string]Response{
+				"200": jsonResp("Результат", ref("BulkDeactivateResult")),
+				"404": notFound,
+			}), obj(map[string]*Schema{"team_name": id(), "dry_run": boolean()}, "team_name"))),
+		},
+		"/team/import": {
+			"post": withQuery(withRawBody(op("importTeams", "Teams", "Массовый импорт команд и участников из CSV или YAML", map[string]Response{
+				"200": jsonResp("План изменений (и результат, если не dry_run)", ref("ImportResult")),
+				"400": errResp("Файл не прошёл проверку; все проблемы перечислены в details"),
+				"415": errResp("Неподдерживаемый Content-Type"),
+			}), "text/csv", "application/yaml"),
+				query("dry_run", false, boolean()),
+				query("deactivate_missing", false, boolean())),
+		},
+		"/org/sync": {
+			"post": withQuery(withRawBody(op("syncOrg", "Teams", "Привести команды и пользователей к документу со всей организацией", map[string]Response{
+				"200": jsonResp("План (с dry_run) или результат синхронизации", ref("SyncResult")),
+				"400": errResp("Документ не прошёл проверку; все проблемы перечислены в details"),
+				"412": errResp("Состояние изменилось после построения плана plan"),
+				"415": errResp("Неподдерживаемый Content-Type"),
+			}), "text/csv", "application/yaml"),
+				query("dry_run", false, boolean()),
+				query("plan", false, str())),
+		},
+		"/team/rules": {
+			"get": withQuery(op("getSeniorityRule", "Teams", "Правило старшинства ревьюверов", map[string]Response{
+				"200": jsonResp("Правило", obj(map[string]*Schema{"rule": ref("SeniorityRule")})),
+				"404": notFound,
+			}), teamName),
+			"post": withBody(op("setSeniorityRule", "Teams", "Задать правило старшинства", map[string]Response{
+				"200": jsonResp("Правило", obj(map[string]*Schema{"rule": ref("SeniorityRule")})),
+				"404": notFound,
+			}), ref("SeniorityRule")),
+		},
+		"/team/sla": {
+			"get": withQuery(op("getSLAPolicy", "Teams", "SLA ревью команды", map[string]Response{
+				"200": jsonResp("Политика", obj(map[string]*Schema{"sla": ref("SLAPolicy")})),
+				"404": notFound,
+			}), teamName),
+			"post": withBody(op("setSLAPolicy", "Teams", "Задать SLA ревью", map[string]Response{
+				"200": jsonResp("Политика", obj(map[string]*Schema{"sla": ref("SLAPolicy")})),
+				"404": notFound,
+			}), ref("SLAPolicy")),
+		},
+		"/team/policy": {
+			"get": withETag(withQuery(op("getTeamPolicy", "Teams", "Политика назначения ревьюверов команды", map[string]Response{
+				"200": jsonResp("Политика", obj(map[string]*Schema{"policy": ref("TeamPolicy")})),
+				"404": notFound,
+			}), teamName)),
+			"put": withIfMatch(withBody(op("setTeamPolicy", "Teams", "Заменить политику назначения ревьюверов", map[string]Response{
+				"200": jsonResp("Политика", obj(map[string]*Schema{"policy": ref("TeamPolicy")})),
+				"404": notFound,
+			}), ref("TeamPolicy"))),
+		},
+		"/team/policy/history": {
+			"get": withQuery(op("getTeamPolicyHistory", "Teams", "История изменений политики команды", map[string]Response{
+				"200": jsonResp("История", obj(map[string]*Schema{
+					"team_name": str(),
+					"history":   arr(ref("PolicyRevision")),
+				})),
+				"404": notFound,
+			}), teamName),
+		},
+		"/team/schedule": {
+			"get": withQuery(op("getTeamSchedule", "Teams", "Рабочее время и праздники команды", map[string]Response{
+				"200": jsonResp("Расписание", obj(map[string]*Schema{"schedule": ref("TeamSchedule")})),
+				"404": notFound,
+			}), teamName),
+			"post": withBody(op("setTeamSchedule", "Teams", "Задать рабочее время и праздники", map[string]Response{
+				"200": jsonResp("Расписание", obj(map[string]*Schema{"schedule": ref("TeamSchedule")})),
+				"404": notFound,
+			}), ref("TeamSchedule")),
+		},
+
+		"/users/setIsActive": {
+			"post": withBody(op("setIsActive", "Users", "Изменить активность пользователя", map[string]Response{
+				"200": jsonResp("Пользователь", obj(map[string]*Schema{"user": ref("User")})),
+				"404": notFound,
+			}), obj(map[string]*Schema{"user_id": id(), "is_active": boolean()}, "user_id", "is_active")),
+		},
+		"/users/setAvailability": {
+			"post": withBody(op("setAvailability", "Users", "Отсутствие и лимит открытых ревью", map[string]Response{
+				"200": jsonResp("Пользователь", obj(map[string]*Schema{"user": ref("User")})),
+				"404": notFound,
+			}), obj(map[string]*Schema{
+				"user_id":          id(),
+				"away_until":       nullable(dateTime()),
+				"max_open_reviews": nullable(intMin(0)),
+			}, "user_id")),
+		},
+		"/users/setSchedule": {
+			"post": withBody(op("setUserSchedule", "Users", "Рабочее время и праздники пользователя", map[string]Response{
+				"200": jsonResp("Расписание", obj(map[string]*Schema{"user_id": str(), "schedule": ref("UserSchedule")})),
+				"404": notFound,
+			}), obj(map[string]*Schema{
+				"user_id":    id(),
+				"time_zone":  nullable(str()),
+				"work_start": nullable(str()),
+				"work_end":   nullable(str()),
+				"work_days":  nullable(arr(intRange(1, 7))),
+				"holidays":   nullable(arr(date())),
+			}, "user_id")),
+		},
+		"/users/declineReview": {
+			"post": withIfMatch(withBody(op("declineReview", "Users", "Отказаться от ревью с автоматической заменой", map[string]Response{
+				"200": jsonResp("PR и новый ревьювер", obj(map[string]*Schema{"pr": ref("PullRequest"), "replaced_by": str()})),
+				"404": notFound,
+				"409": conflict,
+			}), obj(map[string]*Schema{
+				"user_id":         id(),
+				"pull_request_id": id(),
+				"reason":          enum("busy", "no_context", "conflict_of_interest", "other"),
+				"comment":         str(),
+			}, "user_id", "pull_request_id", "reason"))),
+		},
+		"/users/getReview": {
+			"get": withQuery(op("getReview", "Users", "PR, где пользователь назначен ревьювером", map[string]Response{
+				"200": jsonResp("Список PR", obj(map[string]*Schema{
+					"user_id":       str(),
+					"pull_requests": arr(ref("PullRequestShort")),
+				})),
+			}), query("user_id", true, id())),
+		},
+
+		"/pullRequest/create": {
+			"post": withQuery(withBody(op("createPullRequest", "PullRequests", "Создать PR и назначить ревьюверов", map[string]Response{
+				"201": jsonResp("PR создан", obj(map[string]*Schema{"pr": ref("PullRequest"), "explanation": ref("Explanation")})),
+				"404": notFound,
+				"409": conflict,
+			}), obj(map[string]*Schema{
+				"pull_request_id":   id(),
+				"pull_request_name": id(),
+				"author_id":         id(),
+				"required_tags":     nullable(arr(id())),
+			}, "pull_request_id", "pull_request_name", "author_id")),
+				query("explain", false, boolean())),
+		},
+		"/pullRequest/merge": {
+			"post": withIfMatch(withBody(op("mergePullRequest", "PullRequests", "Пометить PR как MERGED", map[string]Response{
+				"200": jsonResp("PR", obj(map[string]*Schema{"pr": ref("PullRequest")})),
+				"404": notFound,
+			}), obj(map[string]*Schema{"pull_request_id": id()}, "pull_request_id"))),
+		},
+		"/pullRequest/get": {
+			"get": withETag(withQuery(op("getPullRequest", "PullRequests", "Получить PR", map[string]Response{
+				"200": jsonResp("PR", obj(map[string]*Schema{"pr": ref("PullRequest")})),
+				"404": notFound,
+			}), prID)),
+		},
+		"/pullRequest/reassign": {
+			"post": withIfMatch(withBody(op("reassignReviewer", "PullRequests", "Переназначить ревьювера", map[string]Response{
+				"200": jsonResp("PR и новый ревьювер", obj(map[string]*Schema{"pr": ref("PullRequest"), "replaced_by": str()})),
+				"404": notFound,
+				"409": conflict,
+			}), obj(map[string]*Schema{"pull_request_id": id(), "old_user_id": id()}, "pull_request_id", "old_user_id"))),
+		},
+		"/pullRequest/reviewers": {
+			"post": withIfMatch(withBody(op("changeReviewers", "PullRequests", "Вручную добавить, снять или заменить ревьюверов", map[string]Response{
+				"200": jsonResp("PR", obj(map[string]*Schema{"pr": ref("PullRequest")})),
+				"404": notFound,
+				"409": conflict,
+			}), obj(map[string]*Schema{
+				"pull_request_id": id(),
+				"add":             nullable(arr(id())),
+				"remove":          nullable(arr(id())),
+				"replace": nullable(arr(obj(map[string]*Schema{
+					"old_user_id": id(),
+					"new_user_id": id(),
+				}, "old_user_id", "new_user_id"))),
+				"override": boolean(),
+				"reason":   str(),
+			}, "pull_request_id"))),
+		},
+		"/pullRequest/assignments": {
+			"get": withQuery(op("getAssignments", "PullRequests", "История назначений PR", map[string]Response{
+				"200": jsonResp("История", obj(map[string]*Schema{
+					"pull_request_id": str(),
+					"assignments":     arr(ref("Assignment")),
+				})),
+				"404": notFound,
+			}), prID),
+		},
+		"/pullRequest/explain": {
+			"get": withQuery(op("explainPullRequest", "PullRequests", "Объяснение последнего назначения", map[string]Response{
+				"200": jsonResp("Объяснение", ref("Explanation")),
+				"404": notFound,
+			}), prID),
+		},
+
+		"/stats/reviewers": {
+			"get": op("reviewerStats", "Stats", "Статистика назначений по ревьюверам", map[string]Response{
+				"200": jsonResp("Статистика", obj(map[string]*Schema{"stats": arr(ref("ReviewerStat"))})),
+			}),
+		},
+		"/stats/pairs": {
+			"get": withQuery(op("pairMatrix", "Stats", "Матрица пар автор–ревьювер", map[string]Response{
+				"200": jsonResp("Матрица", ref("PairMatrix")),
+				"404": notFound,
+			}), teamName),
+		},
+	}
+
+	// все изменяющие операции принимают ключ идемпотентности
+	for _, item := range paths {
+		for _, method := range []string{"post", "put"} {
+			o, ok := item[method]
+			if !ok {
+				continue
+			}
+			o.Parameters = append(o.Parameters, Parameter{
+				Name:        "Idempotency-Key",
+				In:          "header",
+				Description: "Повтор запроса с тем же ключом возвращает сохранённый ответ",
+				Schema:      &Schema{Type: "string"},
+			})
+			o.Responses["422"] = errResp("Ключ идемпотентности уже использован с другим запросом")
+		}
+	}
+
+	components := schemas()
+	scimPaths(paths)
+	scimSchemas(components)
+	adminPaths(paths)
+	adminSchemas(components)
+
+	// GraphQL только читает данные и добавляется после ключей идемпотентности
+	gqlResult := obj(map[string]*Schema{
+		"data": nullable(&Schema{Type: "object"}),
+		"errors": arr(obj(map[string]*Schema{
+			"message":    str(),
+			"path":       arr(str()),
+			"extensions": obj(map[string]*Schema{"code": str()}),
+		})),
+	})
+	paths["/graphql"] = PathItem{
+		"post": withBody(op("graphql", "GraphQL", "Запрос к GraphQL-схеме дашборда", map[string]Response{
+			"200": jsonResp("Результат выполнения; ошибки отдельных полей — в errors", gqlResult),
+			"400": jsonResp("Запрос отвергнут: синтаксис, валидация или превышена сложность", gqlResult),
+		}), obj(map[string]*Schema{
+			"query":         id(),
+			"variables":     nullable(&Schema{Type: "object"}),
+			"operationName": nullable(str()),
+		}, "query")),
+	}
+
+	return &Document{
+		OpenAPI:    "3.0.3",
+		Info:       Info{Title: "PR Reviewer Assignment Service", Version: "1.0.0"},
+		Paths:      paths,
+		Components: Components{Schemas: components},
+	}
+}
@@ -0,0 +1,204 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Violation — одно несоответствие запроса схеме.
+type Violation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidateQuery проверяет обязательные query-параметры и их типы.
+func (d *Document) ValidateQuery(op *Operation, get func(string) string) []Violation {
+	var res []Violation
+	for _, p := range op.Parameters {
+		if p.In != "query" {
+			continue
+		}
+		v := get(p.Name)
+		if v == "" {
+			if p.Required {
+				res = append(res, Violation{Field: p.Name, Message: "is required"})
+			}
+			continue
+		}
+		s := d.resolve(p.Schema)
+		if s == nil {
+			continue
+		}
+		var value any = v
+		switch s.Type {
+		case "integer":
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				res = append(res, Violation{Field: p.Name, Message: "must be an integer"})
+				continue
+			}
+			value = json.Number(strconv.FormatInt(n, 10))
+		case "boolean":
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				res = append(res, Violation{Field: p.Name, Message: "must be a boolean"})
+				continue
+			}
+			value = b
+		}
+		res = append(res, d.validate(s, value, p.Name)...)
+	}
+	return res
+}
+
+// ValidateBody разбирает JSON-тело и сверяет его со схемой операции.
+func (d *Document) ValidateBody(op *Operation, body []byte) []Violation {
+	if op.RequestBody == nil {
+		return nil
+	}
+	mt, ok := op.RequestBody.Content["application/json"]
+	if !ok || mt.Schema == nil {
+		return nil
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		if op.RequestBody.Required {
+			return []Violation{{Field: "", Message: "request body is required"}}
+		}
+		return nil
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(body)))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return []Violation{{Field: "", Message: "invalid json"}}
+	}
+	return d.validate(mt.Schema, value, "")
+}
+
+func (d *Document) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	return s
+}
+
+func (d *Document) validate(s *Schema, v any, path string) []Violation {
+	s = d.resolve(s)
+	if s == nil {
+		return nil
+	}
+	fail := func(format string, args ...any) []Violation {
+		return []Violation{{Field: path, Message: fmt.Sprintf(format, args...)}}
+	}
+
+	if v == nil {
+		if s.Nullable {
+			return nil
+		}
+		return fail("must not be null")
+	}
+
+	switch s.Type {
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return fail("must be a string")
+		}
+		if s.MinLength != nil && len([]rune(strings.TrimSpace(str))) < *s.MinLength {
+			if *s.MinLength == 1 {
+				return fail("must not be empty")
+			}
+			return fail("must be at least %d characters", *s.MinLength)
+		}
+		if len(s.Enum) > 0 && !contains(s.Enum, str) {
+			return fail("must be one of: %s", strings.Join(s.Enum, ", "))
+		}
+		switch s.Format {
+		case "date-time":
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				return fail("must be an RFC 3339 date-time")
+			}
+		case "date":
+			if _, err := time.Parse("2006-01-02", str); err != nil {
+				return fail("must be a date in YYYY-MM-DD format")
+			}
+		}
+	case "integer", "number":
+		n, ok := v.(json.Number)
+		if !ok {
+			return fail("must be a %s", s.Type)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return fail("must be a %s", s.Type)
+		}
+		if s.Type == "integer" {
+			if _, err := n.Int64(); err != nil {
+				return fail("must be an integer")
+			}
+		}
+		if s.Minimum != nil && f < *s.Minimum {
+			return fail("must be >= %v", *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			return fail("must be <= %v", *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fail("must be a boolean")
+		}
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return fail("must be an array")
+		}
+		if s.MinItems != nil && len(arr) < *s.MinItems {
+			return fail("must contain at least %d items", *s.MinItems)
+		}
+		var res []Violation
+		for i, item := range arr {
+			res = append(res, d.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return res
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return fail("must be an object")
+		}
+		var res []Violation
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				res = append(res, Violation{Field: join(path, name), Message: "is required"})
+			}
+		}
+		for name, val := range obj {
+			if ps, ok := s.Properties[name]; ok {
+				res = append(res, d.validate(ps, val, join(path, name))...)
+			} else if s.AdditionalProperties != nil {
+				res = append(res, d.validate(s.AdditionalProperties, val, join(path, name))...)
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func contains(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,279 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Фильтры SCIM (RFC 7644, 3.4.2.2): сравнения eq, ne, co, sw, ew, gt, ge, lt, le,
+// проверка pr, логические and/or/not и скобки. Ресурсы фильтруются в памяти:
+// атрибуты ресурса передаются картой с именами в нижнем регистре. Строки
+// сравниваются без учёта регистра — в нашей схеме нет caseExact-атрибутов.
+
+type attrs map[string]any
+
+type filter interface {
+	match(a attrs) bool
+}
+
+type (
+	andFilter struct{ left, right filter }
+	orFilter  struct{ left, right filter }
+	notFilter struct{ inner filter }
+	cmpFilter struct {
+		attr  string
+		op    string
+		value any // string, bool или nil
+	}
+)
+
+func (f andFilter) match(a attrs) bool { return f.left.match(a) && f.right.match(a) }
+func (f orFilter) match(a attrs) bool  { return f.left.match(a) || f.right.match(a) }
+func (f notFilter) match(a attrs) bool { return !f.inner.match(a) }
+
+func (f cmpFilter) match(a attrs) bool {
+	v, ok := a[f.attr]
+	if f.op == "pr" {
+		return ok && v != nil && v != ""
+	}
+	present := ok && v != nil
+	if f.value == nil || !present {
+		// сравнение с null — проверка отсутствия значения
+		return (f.op == "ne") == (present || f.value != nil)
+	}
+	switch want := f.value.(type) {
+	case bool:
+		got, isBool := v.(bool)
+		switch f.op {
+		case "eq":
+			return isBool && got == want
+		case "ne":
+			return !isBool || got != want
+		}
+		return false
+	case string:
+		got, isStr := v.(string)
+		if !isStr {
+			return f.op == "ne"
+		}
+		got, want = strings.ToLower(got), strings.ToLower(want)
+		switch f.op {
+		case "eq":
+			return got == want
+		case "ne":
+			return got != want
+		case "co":
+			return strings.Contains(got, want)
+		case "sw":
+			return strings.HasPrefix(got, want)
+		case "ew":
+			return strings.HasSuffix(got, want)
+		case "gt":
+			return got > want
+		case "ge":
+			return got >= want
+		case "lt":
+			return got < want
+		case "le":
+			return got <= want
+		}
+	}
+	return f.op == "ne"
+}
+
+var filterOps = map[string]bool{"eq": true, "ne": true, "co": true, "sw": true, "ew": true, "gt": true, "ge": true, "lt": true, "le": true, "pr": true}
+
+// parseFilter разбирает выражение; known — допустимые атрибуты в нижнем регистре.
+// Имена атрибутов можно указывать с URN схемы, как делают некоторые IdP.
+func parseFilter(s string, known ...string) (filter, error) {
+	toks, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{toks: toks, known: known}
+	f, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.toks) {
+		return nil, fmt.Errorf("unexpected %q", p.toks[p.pos].text)
+	}
+	return f, nil
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+func tokenize(s string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(' || c == ')':
+			toks = append(toks, token{text: string(c)})
+			i++
+		case c == '"':
+			j := i + 1
+			for j < len(s) && s[j] != '"' {
+				if s[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			var v string
+			if err := json.Unmarshal([]byte(s[i:j+1]), &v); err != nil {
+				return nil, fmt.Errorf("invalid string %s", s[i:j+1])
+			}
+			toks = append(toks, token{text: v, quoted: true})
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t()\"", rune(s[j])) {
+				j++
+			}
+			toks = append(toks, token{text: s[i:j]})
+			i = j
+		}
+	}
+	return toks, nil
+}
+
+type filterParser struct {
+	toks  []token
+	pos   int
+	known []string
+}
+
+func (p *filterParser) peekWord(word string) bool {
+	return p.pos < len(p.toks) && !p.toks[p.pos].quoted && strings.EqualFold(p.toks[p.pos].text, word)
+}
+
+func (p *filterParser) or() (filter, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekWord("or") {
+		p.pos++
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = orFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) and() (filter, error) {
+	left, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekWord("and") {
+		p.pos++
+		right, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		left = andFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) factor() (filter, error) {
+	if p.peekWord("not") {
+		p.pos++
+		if !p.peekWord("(") {
+			return nil, fmt.Errorf("expected ( after not")
+		}
+		inner, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return notFilter{inner}, nil
+	}
+	if p.peekWord("(") {
+		p.pos++
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peekWord(")") {
+			return nil, fmt.Errorf("expected )")
+		}
+		p.pos++
+		return inner, nil
+	}
+	return p.comparison()
+}
+
+func (p *filterParser) comparison() (filter, error) {
+	if p.pos+1 >= len(p.toks) {
+		return nil, fmt.Errorf("incomplete expression")
+	}
+	attrTok, opTok := p.toks[p.pos], p.toks[p.pos+1]
+	if attrTok.quoted || opTok.quoted {
+		return nil, fmt.Errorf("expected attribute and operator")
+	}
+	attr := attrName(attrTok.text)
+	if !contains(p.known, attr) {
+		return nil, fmt.Errorf("unsupported attribute %q", attrTok.text)
+	}
+	op := strings.ToLower(opTok.text)
+	if !filterOps[op] {
+		return nil, fmt.Errorf("unknown operator %q", opTok.text)
+	}
+	p.pos += 2
+	if op == "pr" {
+		return cmpFilter{attr: attr, op: op}, nil
+	}
+
+	if p.pos >= len(p.toks) {
+		return nil, fmt.Errorf("missing value for %s", attrTok.text)
+	}
+	v := p.toks[p.pos]
+	p.pos++
+	f := cmpFilter{attr: attr, op: op, value: v.text}
+	if !v.quoted {
+		switch strings.ToLower(v.text) {
+		case "true":
+			f.value = true
+		case "false":
+			f.value = false
+		case "null":
+			f.value = nil
+		}
+	}
+	if _, isStr := f.value.(string); !isStr && op != "eq" && op != "ne" {
+		return nil, fmt.Errorf("operator %s needs a string value", op)
+	}
+	return f, nil
+}
+
+// attrName приводит «urn:...:User:userName» и «userName» к «username».
+func attrName(s string) string {
+	s = strings.ToLower(s)
+	if strings.HasPrefix(s, "urn:") {
+		if i := strings.LastIndex(s, ":"); i >= 0 {
+			s = s[i+1:]
+		}
+	}
+	return s
+}
+
+func contains(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
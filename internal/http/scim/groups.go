@@ -0,0 +1,380 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var groupFilterAttrs = []string{"id", "displayname"}
+
+type groupResource struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id"`
+	DisplayName string       `json:"displayName"`
+	Members     *[]memberRef `json:"members,omitempty"`
+	Meta        meta         `json:"meta"`
+}
+
+type memberRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type groupInput struct {
+	DisplayName string      `json:"displayName"`
+	Members     []memberRef `json:"members"`
+}
+
+// toGroup собирает ресурс; members == nil — участники исключены из ответа.
+func toGroup(r *http.Request, t repo.Team, members []repo.User) groupResource {
+	g := groupResource{
+		Schemas:     []string{SchemaGroup},
+		ID:          t.TeamName,
+		DisplayName: t.TeamName,
+		Meta:        meta{ResourceType: "Group", Location: location(r, "Groups", t.TeamName), Version: fmt.Sprintf(`W/"%d"`, t.Version)},
+	}
+	if members != nil {
+		refs := make([]memberRef, 0, len(members))
+		for _, u := range members {
+			refs = append(refs, memberRef{Value: u.UserID, Display: u.Username, Ref: location(r, "Users", u.UserID)})
+		}
+		g.Members = &refs
+	}
+	return g
+}
+
+// withMembers — нужны ли участники в ответе: IdP часто просят их не отдавать,
+// потому что у больших групп это самая тяжёлая часть ответа.
+func withMembers(r *http.Request) bool {
+	q := r.URL.Query()
+	for _, a := range strings.Split(q.Get("excludedAttributes"), ",") {
+		if attrName(strings.TrimSpace(a)) == "members" {
+			return false
+		}
+	}
+	if v := q.Get("attributes"); v != "" {
+		for _, a := range strings.Split(v, ",") {
+			if attrName(strings.TrimSpace(a)) == "members" {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func (s *Service) listGroups(w http.ResponseWriter, r *http.Request) {
+	p, err := parsePage(r)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	var f filter
+	if expr := r.URL.Query().Get("filter"); expr != "" {
+		if f, err = parseFilter(expr, groupFilterAttrs...); err != nil {
+			s.fail(w, r, badRequest("invalidFilter", "%v", err))
+			return
+		}
+	}
+
+	teams, err := s.store.ListTeams(r.Context())
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	var matched []repo.Team
+	for _, t := range teams {
+		if f == nil || f.match(attrs{"id": t.TeamName, "displayname": t.TeamName}) {
+			matched = append(matched, t)
+		}
+	}
+
+	// участники загружаются одним запросом и только для отдаваемой страницы
+	from := min(p.start-1, len(matched))
+	to := min(from+p.count, len(matched))
+	var members map[string][]repo.User
+	if withMembers(r) {
+		names := make([]string, 0, to-from)
+		for _, t := range matched[from:to] {
+			names = append(names, t.TeamName)
+		}
+		if members, err = s.store.UsersByTeam(r.Context(), names); err != nil {
+			s.fail(w, r, err)
+			return
+		}
+	}
+	res := make([]groupResource, len(matched))
+	for i, t := range matched {
+		var m []repo.User
+		if members != nil {
+			m = append([]repo.User{}, members[t.TeamName]...)
+		}
+		res[i] = toGroup(r, t, m)
+	}
+	listResponse(w, p, res)
+}
+
+func (s *Service) loadGroup(ctx context.Context, r *http.Request, name string, members bool) (groupResource, []repo.User, error) {
+	teams, err := s.store.TeamsByName(ctx, []string{name})
+	if err != nil {
+		return groupResource{}, nil, err
+	}
+	t, ok := teams[name]
+	if !ok {
+		return groupResource{}, nil, &Error{Status: 404, Detail: "group " + name + " not found"}
+	}
+	var users []repo.User
+	if members {
+		byTeam, err := s.store.UsersByTeam(ctx, []string{name})
+		if err != nil {
+			return groupResource{}, nil, err
+		}
+		users = append([]repo.User{}, byTeam[name]...)
+	}
+	return toGroup(r, t, users), users, nil
+}
+
+func (s *Service) getGroup(w http.ResponseWriter, r *http.Request) {
+	g, _, err := s.loadGroup(r.Context(), r, chi.URLParam(r, "id"), withMembers(r))
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	writeJSON(w, 200, g)
+}
+
+func (s *Service) createGroup(w http.ResponseWriter, r *http.Request) {
+	var in groupInput
+	if err := decode(r, &in); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	name := strings.TrimSpace(in.DisplayName)
+	if name == "" {
+		s.fail(w, r, badRequest("invalidValue", "displayName is required"))
+		return
+	}
+	created, err := s.store.EnsureTeam(r.Context(), name)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	if !created {
+		s.fail(w, r, &Error{Status: 409, ScimType: "uniqueness", Detail: "group " + name + " already exists"})
+		return
+	}
+	if err := s.setMembers(r.Context(), name, nil, memberIDs(in.Members)); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+
+	g, _, err := s.loadGroup(r.Context(), r, name, true)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	w.Header().Set("Location", g.Meta.Location)
+	writeJSON(w, 201, g)
+}
+
+func (s *Service) replaceGroup(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "id")
+	var in groupInput
+	if err := decode(r, &in); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	if v := strings.TrimSpace(in.DisplayName); v != "" && v != name {
+		s.fail(w, r, badRequest("mutability", "displayName is the team name and cannot be changed"))
+		return
+	}
+	_, current, err := s.loadGroup(r.Context(), r, name, true)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	if err := s.setMembers(r.Context(), name, current, memberIDs(in.Members)); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	g, _, err := s.loadGroup(r.Context(), r, name, true)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	writeJSON(w, 200, g)
+}
+
+func (s *Service) patchGroup(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "id")
+	var req patchRequest
+	if err := decode(r, &req); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	if err := req.validate(); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	_, current, err := s.loadGroup(r.Context(), r, name, true)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+
+	// операции применяются по очереди к желаемому составу, а в базу уходит только итоговая разница
+	want := make([]string, 0, len(current))
+	display := map[string]string{}
+	for _, u := range current {
+		want = append(want, u.UserID)
+		display[u.UserID] = u.Username
+	}
+	for _, op := range req.Operations {
+		if want, err = applyGroupOp(name, want, display, op); err != nil {
+			s.fail(w, r, err)
+			return
+		}
+	}
+	if err := s.setMembers(r.Context(), name, current, want); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+
+	g, _, err := s.loadGroup(r.Context(), r, name, true)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	writeJSON(w, 200, g)
+}
+
+func applyGroupOp(name string, want []string, display map[string]string, op patchOp) ([]string, error) {
+	kind := strings.ToLower(op.Op)
+	path := strings.TrimSpace(op.Path)
+
+	if path == "" {
+		values, err := valueMap(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		for attr, raw := range values {
+			sub := patchOp{Op: op.Op, Path: attr, Value: raw}
+			if want, err = applyGroupOp(name, want, display, sub); err != nil {
+				return nil, err
+			}
+		}
+		return want, nil
+	}
+
+	lower := attrName(path)
+	switch {
+	case lower == "displayname" || lower == "id":
+		if v, _ := parseString(op.Value); kind == "remove" || v != name {
+			return nil, badRequest("mutability", "%s is the team name and cannot be changed", path)
+		}
+		return want, nil
+
+	case lower == "members":
+		var refs []memberRef
+		if len(op.Value) > 0 && string(op.Value) != "null" {
+			if err := json.Unmarshal(op.Value, &refs); err != nil {
+				return nil, badRequest("invalidValue", "members must be a list of {value}")
+			}
+		}
+		ids := memberIDs(refs)
+		switch kind {
+		case "add":
+			for _, id := range ids {
+				if !slices.Contains(want, id) {
+					want = append(want, id)
+				}
+			}
+		case "replace":
+			want = ids
+		case "remove":
+			if len(refs) == 0 {
+				return []string{}, nil
+			}
+			want = slices.DeleteFunc(want, func(id string) bool { return slices.Contains(ids, id) })
+		}
+		return want, nil
+
+	case strings.HasPrefix(lower, "members[") && strings.HasSuffix(lower, "]"):
+		if kind != "remove" {
+			return nil, badRequest("invalidPath", "only remove supports a members filter")
+		}
+		f, err := parseFilter(path[len("members["):len(path)-1], "value", "display")
+		if err != nil {
+			return nil, badRequest("invalidPath", "%v", err)
+		}
+		return slices.DeleteFunc(want, func(id string) bool {
+			return f.match(attrs{"value": id, "display": display[id]})
+		}), nil
+	}
+	return nil, badRequest("invalidPath", "unsupported path %q", path)
+}
+
+// setMembers приводит состав команды к want: новые участники переводятся из
+// своих команд, а исключённые — в команду по умолчанию.
+func (s *Service) setMembers(ctx context.Context, name string, current []repo.User, want []string) error {
+	var removed []string
+	for _, u := range current {
+		if !slices.Contains(want, u.UserID) {
+			removed = append(removed, u.UserID)
+		}
+	}
+	if len(want) > 0 {
+		if err := s.store.MoveUsers(ctx, want, "", name); err != nil {
+			if errors.Is(err, repo.ErrNotFound) {
+				return badRequest("invalidValue", "members reference unknown users")
+			}
+			return err
+		}
+	}
+	if len(removed) > 0 && name != s.opts.DefaultTeam {
+		if err := s.store.MoveUsers(ctx, removed, name, s.opts.DefaultTeam); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteGroup переводит участников в команду по умолчанию. Сама команда
+// остаётся: на неё ссылаются политика, правила и история.
+func (s *Service) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "id")
+	if name == s.opts.DefaultTeam {
+		s.fail(w, r, badRequest("mutability", "the default team cannot be deleted"))
+		return
+	}
+	_, current, err := s.loadGroup(r.Context(), r, name, true)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	if err := s.setMembers(r.Context(), name, current, nil); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func memberIDs(members []memberRef) []string {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		if v := strings.TrimSpace(m.Value); v != "" && !slices.Contains(ids, v) {
+			ids = append(ids, v)
+		}
+	}
+	return ids
+}
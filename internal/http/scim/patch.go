@@ -0,0 +1,84 @@
+package scim
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type patchRequest struct {
+	Schemas    []string  `json:"schemas"`
+	Operations []patchOp `json:"Operations"`
+}
+
+type patchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+func (p patchRequest) validate() error {
+	if !contains(p.Schemas, schemaPatch) {
+		return badRequest("invalidSyntax", "schemas must contain %s", schemaPatch)
+	}
+	if len(p.Operations) == 0 {
+		return badRequest("invalidSyntax", "Operations must not be empty")
+	}
+	for _, op := range p.Operations {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace", "remove":
+		default:
+			return badRequest("invalidSyntax", "unknown op %q", op.Op)
+		}
+	}
+	return nil
+}
+
+// parseBool принимает и true, и "True": некоторые IdP присылают булевы значения строками.
+func parseBool(raw json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		switch strings.ToLower(s) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, badRequest("invalidValue", "expected a boolean, got %s", raw)
+}
+
+func parseString(raw json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", badRequest("invalidValue", "expected a string, got %s", raw)
+	}
+	return strings.TrimSpace(s), nil
+}
+
+// valueMap разбирает значение операции без path: объект «атрибут → значение».
+// Ключи приводятся к нижнему регистру, вложенный name раскладывается в name.formatted.
+func valueMap(raw json.RawMessage) (map[string]json.RawMessage, error) {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, badRequest("invalidValue", "value must be an object when path is omitted")
+	}
+	res := make(map[string]json.RawMessage, len(m))
+	for k, v := range m {
+		k = attrName(k)
+		if k == "name" {
+			var name map[string]json.RawMessage
+			if json.Unmarshal(v, &name) == nil {
+				for nk, nv := range name {
+					res["name."+strings.ToLower(nk)] = nv
+				}
+			}
+			continue
+		}
+		res[k] = v
+	}
+	return res, nil
+}
@@ -0,0 +1,275 @@
+// Package scim — провижининг пользователей и команд из identity provider по
+// SCIM 2.0 (RFC 7643, 7644) поверх таблиц users и teams. User — пользователь
+// сервиса (id и userName — это user_id), Group — команда (id и displayName —
+// имя команды). Пользователь всегда состоит ровно в одной команде: добавление
+// в группу переводит его туда, а исключение — в команду по умолчанию.
+// active=false выключает пользователя тем же SetUserActive, что и API, поэтому
+// offboarding в IdP сразу убирает человека из ротации ревьюверов.
+package scim
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"pr-reviewer-service/internal/lib/logger"
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// BasePath — префикс, под которым монтируется Routes.
+const BasePath = "/scim/v2"
+
+const (
+	SchemaUser  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup = "urn:ietf:params:scim:schemas:core:2.0:Group"
+
+	schemaList         = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+	schemaPatch        = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaSPConfig     = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaResourceType = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+)
+
+const (
+	contentType  = "application/scim+json"
+	defaultCount = 100
+	maxResults   = 1000
+	maxBodyBytes = 1 << 20
+	// DefaultTeam — команда по умолчанию, если она не задана в Options.
+	DefaultTeam = "unassigned"
+)
+
+// Store — операции хранилища, которые нужны SCIM; *repo.Store его реализует.
+type Store interface {
+	ListUsers(ctx context.Context) ([]repo.User, error)
+	UsersByID(ctx context.Context, ids []string) (map[string]repo.User, error)
+	UsersByTeam(ctx context.Context, teams []string) (map[string][]repo.User, error)
+	ListTeams(ctx context.Context) ([]repo.Team, error)
+	TeamsByName(ctx context.Context, names []string) (map[string]repo.Team, error)
+	AddUser(ctx context.Context, u repo.User) (repo.User, error)
+	RenameUser(ctx context.Context, userID, username string) (repo.User, error)
+	SetUserActive(ctx context.Context, userID string, isActive bool) (repo.User, error)
+	EnsureTeam(ctx context.Context, teamName string) (bool, error)
+	MoveUsers(ctx context.Context, ids []string, from, to string) error
+}
+
+type Options struct {
+	// Token — bearer-токен, с которым приходит IdP; пустой токен выключает SCIM.
+	Token string
+	// DefaultTeam — команда для пользователей, не состоящих ни в одной группе.
+	DefaultTeam string
+}
+
+type Service struct {
+	store Store
+	log   *slog.Logger
+	opts  Options
+}
+
+func New(store Store, log *slog.Logger, opts Options) *Service {
+	if opts.DefaultTeam == "" {
+		opts.DefaultTeam = DefaultTeam
+	}
+	return &Service{store: store, log: log, opts: opts}
+}
+
+// Routes возвращает обработчики относительно BasePath.
+func (s *Service) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Use(s.authenticate)
+
+	r.Get("/ServiceProviderConfig", s.serviceProviderConfig)
+	r.Get("/ResourceTypes", s.resourceTypes)
+
+	r.Get("/Users", s.listUsers)
+	r.Post("/Users", s.createUser)
+	r.Get("/Users/{id}", s.getUser)
+	r.Put("/Users/{id}", s.replaceUser)
+	r.Patch("/Users/{id}", s.patchUser)
+	r.Delete("/Users/{id}", s.deleteUser)
+
+	r.Get("/Groups", s.listGroups)
+	r.Post("/Groups", s.createGroup)
+	r.Get("/Groups/{id}", s.getGroup)
+	r.Put("/Groups/{id}", s.replaceGroup)
+	r.Patch("/Groups/{id}", s.patchGroup)
+	r.Delete("/Groups/{id}", s.deleteGroup)
+	return r
+}
+
+func (s *Service) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.opts.Token == "" {
+			writeError(w, &Error{Status: 404, Detail: "SCIM provisioning is disabled"})
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+			writeError(w, &Error{Status: 401, Detail: "invalid or missing bearer token"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Error — ответ об ошибке в формате SCIM; ScimType — уточнение из RFC 7644, 3.12.
+type Error struct {
+	Status   int
+	ScimType string
+	Detail   string
+}
+
+func (e *Error) Error() string { return e.Detail }
+
+func badRequest(scimType, format string, args ...any) *Error {
+	return &Error{Status: 400, ScimType: scimType, Detail: fmt.Sprintf(format, args...)}
+}
+
+func writeError(w http.ResponseWriter, e *Error) {
+	body := map[string]any{
+		"schemas": []string{schemaError},
+		"status":  strconv.Itoa(e.Status),
+		"detail":  e.Detail,
+	}
+	if e.ScimType != "" {
+		body["scimType"] = e.ScimType
+	}
+	writeJSON(w, e.Status, body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// fail отвечает по ошибке обработчика или хранилища; внутренние ошибки
+// пишутся в лог и скрываются от клиента, как и в остальном API.
+func (s *Service) fail(w http.ResponseWriter, r *http.Request, err error) {
+	var se *Error
+	if errors.As(err, &se) {
+		writeError(w, se)
+		return
+	}
+	var re *repo.Error
+	if errors.As(err, &re) {
+		switch re.Code {
+		case repo.CodeNotFound:
+			writeError(w, &Error{Status: 404, Detail: re.Message})
+		case repo.CodeUserExists:
+			writeError(w, &Error{Status: 409, ScimType: "uniqueness", Detail: re.Message})
+		case repo.CodeVersion:
+			writeError(w, &Error{Status: 412, Detail: re.Message})
+		default:
+			writeError(w, &Error{Status: 400, ScimType: "invalidValue", Detail: re.Message})
+		}
+		return
+	}
+	s.log.Error("scim request failed", logger.Err(err), slog.String("request_id", middleware.GetReqID(r.Context())))
+	writeError(w, &Error{Status: 500, Detail: "internal error"})
+}
+
+// decode читает тело запроса; IdP присылают и application/scim+json, и application/json.
+func decode(r *http.Request, v any) error {
+	raw, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes))
+	if err != nil {
+		return badRequest("invalidSyntax", "cannot read request body")
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return badRequest("invalidSyntax", "invalid json: %v", err)
+	}
+	return nil
+}
+
+// page — параметры постраничной выдачи: startIndex нумеруется с 1.
+type page struct {
+	start, count int
+}
+
+func parsePage(r *http.Request) (page, error) {
+	p := page{start: 1, count: defaultCount}
+	q := r.URL.Query()
+	if v := q.Get("startIndex"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return p, badRequest("invalidValue", "startIndex must be an integer")
+		}
+		p.start = max(n, 1)
+	}
+	if v := q.Get("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return p, badRequest("invalidValue", "count must be an integer")
+		}
+		p.count = min(max(n, 0), maxResults)
+	}
+	return p, nil
+}
+
+// listResponse отдаёт страницу из уже отфильтрованных ресурсов.
+func listResponse[T any](w http.ResponseWriter, p page, all []T) {
+	from := min(p.start-1, len(all))
+	to := min(from+p.count, len(all))
+	writeJSON(w, 200, map[string]any{
+		"schemas":      []string{schemaList},
+		"totalResults": len(all),
+		"startIndex":   p.start,
+		"itemsPerPage": to - from,
+		"Resources":    all[from:to],
+	})
+}
+
+// location строит абсолютный адрес ресурса для meta.location.
+func location(r *http.Request, kind, id string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if p := r.Header.Get("X-Forwarded-Proto"); p != "" {
+		scheme = p
+	}
+	return scheme + "://" + r.Host + BasePath + "/" + kind + "/" + id
+}
+
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location"`
+	Version      string `json:"version,omitempty"`
+}
+
+func (s *Service) serviceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, 200, map[string]any{
+		"schemas":        []string{schemaSPConfig},
+		"patch":          map[string]any{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": maxResults},
+		"changePassword": map[string]any{"supported": false},
+		"sort":           map[string]any{"supported": false},
+		"etag":           map[string]any{"supported": false},
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer token",
+			"description": "Static token from the service configuration (scim.token)",
+			"primary":     true,
+		}},
+	})
+}
+
+func (s *Service) resourceTypes(w http.ResponseWriter, r *http.Request) {
+	types := []map[string]any{
+		{"schemas": []string{schemaResourceType}, "id": "User", "name": "User", "endpoint": "/Users", "schema": SchemaUser},
+		{"schemas": []string{schemaResourceType}, "id": "Group", "name": "Group", "endpoint": "/Groups", "schema": SchemaGroup},
+	}
+	listResponse(w, page{start: 1, count: len(types)}, types)
+}
@@ -0,0 +1,307 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// memStore — Store в памяти с той же семантикой, что и *repo.Store: версии
+// команд растут при изменении состава, неизвестный пользователь — ErrNotFound.
+type memStore struct {
+	mu    sync.Mutex
+	users map[string]repo.User
+	teams map[string]int64
+}
+
+func newMemStore() *memStore {
+	return &memStore{users: map[string]repo.User{}, teams: map[string]int64{}}
+}
+
+func (m *memStore) ListUsers(context.Context) ([]repo.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := []repo.User{}
+	for _, u := range m.users {
+		res = append(res, u)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].UserID < res[j].UserID })
+	return res, nil
+}
+
+func (m *memStore) UsersByID(_ context.Context, ids []string) (map[string]repo.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := map[string]repo.User{}
+	for _, id := range ids {
+		if u, ok := m.users[id]; ok {
+			res[id] = u
+		}
+	}
+	return res, nil
+}
+
+func (m *memStore) UsersByTeam(ctx context.Context, teams []string) (map[string][]repo.User, error) {
+	all, _ := m.ListUsers(ctx)
+	res := map[string][]repo.User{}
+	for _, u := range all {
+		if slices.Contains(teams, u.TeamName) {
+			res[u.TeamName] = append(res[u.TeamName], u)
+		}
+	}
+	return res, nil
+}
+
+func (m *memStore) ListTeams(context.Context) ([]repo.Team, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := []repo.Team{}
+	for name, v := range m.teams {
+		res = append(res, repo.Team{TeamName: name, Version: v})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].TeamName < res[j].TeamName })
+	return res, nil
+}
+
+func (m *memStore) TeamsByName(_ context.Context, names []string) (map[string]repo.Team, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := map[string]repo.Team{}
+	for _, name := range names {
+		if v, ok := m.teams[name]; ok {
+			res[name] = repo.Team{TeamName: name, Version: v}
+		}
+	}
+	return res, nil
+}
+
+func (m *memStore) AddUser(_ context.Context, u repo.User) (repo.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.users[u.UserID]; ok {
+		return repo.User{}, repo.ErrUserExists
+	}
+	if u.Level == "" {
+		u.Level = "middle"
+	}
+	m.teams[u.TeamName]++
+	m.users[u.UserID] = u
+	return u, nil
+}
+
+func (m *memStore) RenameUser(_ context.Context, userID, username string) (repo.User, error) {
+	return m.update(userID, func(u *repo.User) bool {
+		changed := u.Username != username
+		u.Username = username
+		return changed
+	})
+}
+
+func (m *memStore) SetUserActive(_ context.Context, userID string, isActive bool) (repo.User, error) {
+	return m.update(userID, func(u *repo.User) bool {
+		changed := u.IsActive != isActive
+		u.IsActive = isActive
+		return changed
+	})
+}
+
+func (m *memStore) update(userID string, change func(*repo.User) bool) (repo.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u, ok := m.users[userID]
+	if !ok {
+		return repo.User{}, repo.ErrNotFound
+	}
+	if change(&u) {
+		m.teams[u.TeamName]++
+	}
+	m.users[userID] = u
+	return u, nil
+}
+
+func (m *memStore) EnsureTeam(_ context.Context, teamName string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.teams[teamName]; ok {
+		return false, nil
+	}
+	m.teams[teamName] = 1
+	return true, nil
+}
+
+func (m *memStore) MoveUsers(_ context.Context, ids []string, from, to string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		if _, ok := m.users[id]; !ok {
+			return repo.ErrNotFound
+		}
+	}
+	if _, ok := m.teams[to]; !ok {
+		m.teams[to] = 1
+	}
+	for _, id := range ids {
+		u := m.users[id]
+		if u.TeamName == to || (from != "" && u.TeamName != from) {
+			continue
+		}
+		m.teams[u.TeamName]++
+		m.teams[to]++
+		u.TeamName = to
+		m.users[id] = u
+	}
+	return nil
+}
+
+type fixture struct {
+	Request struct {
+		Method string          `json:"method"`
+		Path   string          `json:"path"`
+		Body   json.RawMessage `json:"body"`
+	} `json:"request"`
+	Response struct {
+		Status int `json:"status"`
+		Body   any `json:"body"`
+	} `json:"response"`
+}
+
+// TestFixtures прогоняет записанные запросы IdP из api/scim/fixtures по
+// порядку на одном пустом хранилище: каждый шаг видит результат предыдущих.
+func TestFixtures(t *testing.T) {
+	files, err := filepath.Glob("../../../api/scim/fixtures/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no fixtures found")
+	}
+	sort.Strings(files)
+
+	r := chi.NewRouter()
+	r.Mount(BasePath, New(newMemStore(), slog.New(slog.NewTextHandler(io.Discard, nil)), Options{Token: "scim-token"}).Routes())
+
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fx fixture
+		if err := json.Unmarshal(raw, &fx); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+
+		// шаги зависят друг от друга, поэтому после первой ошибки дальше не идём
+		ok := t.Run(strings.TrimSuffix(filepath.Base(file), ".json"), func(t *testing.T) {
+			var body io.Reader
+			if len(fx.Request.Body) > 0 {
+				body = strings.NewReader(string(fx.Request.Body))
+			}
+			req := httptest.NewRequest(fx.Request.Method, fx.Request.Path, body)
+			req.Header.Set("Authorization", "Bearer scim-token")
+			req.Header.Set("Content-Type", contentType)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != fx.Response.Status {
+				t.Fatalf("status %d, want %d: %s", rec.Code, fx.Response.Status, rec.Body)
+			}
+			if fx.Response.Body == nil {
+				return
+			}
+			var got any
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("invalid json response: %v", err)
+			}
+			if err := matchSubset(got, fx.Response.Body, "body"); err != nil {
+				t.Errorf("%v\nresponse: %s", err, rec.Body)
+			}
+		})
+		if !ok {
+			return
+		}
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		status int
+	}{
+		{"disabled", "", "Bearer x", 404},
+		{"missing token", "scim-token", "", 401},
+		{"wrong token", "scim-token", "Bearer other", 401},
+		{"valid token", "scim-token", "Bearer scim-token", 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(newMemStore(), slog.New(slog.NewTextHandler(io.Discard, nil)), Options{Token: tt.token})
+			req := httptest.NewRequest("GET", "/ServiceProviderConfig", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			s.Routes().ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("status %d, want %d", rec.Code, tt.status)
+			}
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("no WWW-Authenticate header on 401")
+			}
+		})
+	}
+}
+
+// matchSubset проверяет, что got содержит все поля want. Объекты сравниваются по
+// перечисленным в want ключам, массивы — поэлементно и с той же длиной.
+func matchSubset(got, want any, path string) error {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: got %v, want object", path, got)
+		}
+		for k, wv := range w {
+			gv, ok := g[k]
+			if !ok {
+				return fmt.Errorf("%s.%s: missing", path, k)
+			}
+			if err := matchSubset(gv, wv, path+"."+k); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		g, ok := got.([]any)
+		if !ok || len(g) != len(w) {
+			return fmt.Errorf("%s: got %v, want %d items", path, got, len(w))
+		}
+		for i := range w {
+			if err := matchSubset(g[i], w[i], fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		if got != want {
+			return fmt.Errorf("%s: got %v, want %v", path, got, want)
+		}
+		return nil
+	}
+}
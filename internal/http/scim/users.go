@@ -0,0 +1,305 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"pr-reviewer-service/internal/storage/repo"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var userFilterAttrs = []string{"id", "username", "displayname", "name.formatted", "active"}
+
+type userResource struct {
+	Schemas     []string   `json:"schemas"`
+	ID          string     `json:"id"`
+	UserName    string     `json:"userName"`
+	DisplayName string     `json:"displayName"`
+	Name        userName   `json:"name"`
+	Active      bool       `json:"active"`
+	Groups      []groupRef `json:"groups"`
+	Meta        meta       `json:"meta"`
+}
+
+type userName struct {
+	Formatted string `json:"formatted"`
+}
+
+type groupRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display"`
+	Ref     string `json:"$ref"`
+}
+
+func toUser(r *http.Request, u repo.User) userResource {
+	return userResource{
+		Schemas:     []string{SchemaUser},
+		ID:          u.UserID,
+		UserName:    u.UserID,
+		DisplayName: u.Username,
+		Name:        userName{Formatted: u.Username},
+		Active:      u.IsActive,
+		Groups:      []groupRef{{Value: u.TeamName, Display: u.TeamName, Ref: location(r, "Groups", u.TeamName)}},
+		Meta:        meta{ResourceType: "User", Location: location(r, "Users", u.UserID)},
+	}
+}
+
+func userAttrs(u repo.User) attrs {
+	return attrs{
+		"id":             u.UserID,
+		"username":       u.UserID,
+		"displayname":    u.Username,
+		"name.formatted": u.Username,
+		"active":         u.IsActive,
+	}
+}
+
+// userInput — тело POST и PUT. Атрибуты, которых в сервисе нет (emails,
+// title и т.п.), IdP присылают всегда; они молча игнорируются.
+type userInput struct {
+	UserName    string          `json:"userName"`
+	DisplayName string          `json:"displayName"`
+	Name        *nameInput      `json:"name"`
+	Active      json.RawMessage `json:"active"`
+}
+
+type nameInput struct {
+	Formatted  string `json:"formatted"`
+	GivenName  string `json:"givenName"`
+	FamilyName string `json:"familyName"`
+}
+
+// username выбирает отображаемое имя: displayName, затем name, затем userName.
+func (in userInput) username() string {
+	if v := strings.TrimSpace(in.DisplayName); v != "" {
+		return v
+	}
+	if in.Name != nil {
+		if v := strings.TrimSpace(in.Name.Formatted); v != "" {
+			return v
+		}
+		if v := strings.TrimSpace(in.Name.GivenName + " " + in.Name.FamilyName); v != "" {
+			return v
+		}
+	}
+	return strings.TrimSpace(in.UserName)
+}
+
+func (in userInput) active() (bool, error) {
+	if len(in.Active) == 0 || string(in.Active) == "null" {
+		return true, nil
+	}
+	return parseBool(in.Active)
+}
+
+func (s *Service) listUsers(w http.ResponseWriter, r *http.Request) {
+	p, err := parsePage(r)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	var f filter
+	if expr := r.URL.Query().Get("filter"); expr != "" {
+		if f, err = parseFilter(expr, userFilterAttrs...); err != nil {
+			s.fail(w, r, badRequest("invalidFilter", "%v", err))
+			return
+		}
+	}
+
+	// организация — сотни или тысячи пользователей, поэтому фильтр применяется в памяти
+	users, err := s.store.ListUsers(r.Context())
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	res := []userResource{}
+	for _, u := range users {
+		if f == nil || f.match(userAttrs(u)) {
+			res = append(res, toUser(r, u))
+		}
+	}
+	listResponse(w, p, res)
+}
+
+func (s *Service) findUser(ctx context.Context, id string) (repo.User, error) {
+	users, err := s.store.UsersByID(ctx, []string{id})
+	if err != nil {
+		return repo.User{}, err
+	}
+	u, ok := users[id]
+	if !ok {
+		return repo.User{}, &Error{Status: 404, Detail: "user " + id + " not found"}
+	}
+	return u, nil
+}
+
+func (s *Service) getUser(w http.ResponseWriter, r *http.Request) {
+	u, err := s.findUser(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	writeJSON(w, 200, toUser(r, u))
+}
+
+// createUser заводит пользователя в команде по умолчанию: в группу его
+// добавит следующий запрос IdP к /Groups.
+func (s *Service) createUser(w http.ResponseWriter, r *http.Request) {
+	var in userInput
+	if err := decode(r, &in); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	id := strings.TrimSpace(in.UserName)
+	if id == "" {
+		s.fail(w, r, badRequest("invalidValue", "userName is required"))
+		return
+	}
+	active, err := in.active()
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+
+	u, err := s.store.AddUser(r.Context(), repo.User{UserID: id, Username: in.username(), TeamName: s.opts.DefaultTeam, IsActive: active})
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	res := toUser(r, u)
+	w.Header().Set("Location", res.Meta.Location)
+	writeJSON(w, 201, res)
+}
+
+func (s *Service) replaceUser(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var in userInput
+	if err := decode(r, &in); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	if v := strings.TrimSpace(in.UserName); v != "" && v != id {
+		s.fail(w, r, badRequest("mutability", "userName is immutable"))
+		return
+	}
+	active, err := in.active()
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	if in.UserName == "" {
+		in.UserName = id
+	}
+
+	cur, err := s.findUser(r.Context(), id)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	u, err := s.updateUser(r.Context(), cur, in.username(), active)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	writeJSON(w, 200, toUser(r, u))
+}
+
+func (s *Service) patchUser(w http.ResponseWriter, r *http.Request) {
+	var req patchRequest
+	if err := decode(r, &req); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	if err := req.validate(); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	cur, err := s.findUser(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+
+	username, active := cur.Username, cur.IsActive
+	for _, op := range req.Operations {
+		values := map[string]json.RawMessage{}
+		if op.Path == "" {
+			if values, err = valueMap(op.Value); err != nil {
+				s.fail(w, r, err)
+				return
+			}
+		} else {
+			values[attrName(op.Path)] = op.Value
+		}
+		remove := strings.EqualFold(op.Op, "remove")
+		for attr, raw := range values {
+			switch attr {
+			case "active":
+				if remove {
+					s.fail(w, r, badRequest("mutability", "active cannot be removed"))
+					return
+				}
+				if active, err = parseBool(raw); err != nil {
+					s.fail(w, r, err)
+					return
+				}
+			case "displayname", "name.formatted":
+				if remove {
+					username = cur.UserID
+					continue
+				}
+				if username, err = parseString(raw); err != nil {
+					s.fail(w, r, err)
+					return
+				}
+				if username == "" {
+					username = cur.UserID
+				}
+			case "username", "id":
+				if v, _ := parseString(raw); remove || v != cur.UserID {
+					s.fail(w, r, badRequest("mutability", "%s is immutable", attr))
+					return
+				}
+			}
+			// остальные атрибуты (emails, title, ...) сервис не хранит
+		}
+	}
+
+	u, err := s.updateUser(r.Context(), cur, username, active)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	writeJSON(w, 200, toUser(r, u))
+}
+
+// deleteUser не удаляет пользователя — на него ссылаются PR и история
+// назначений, — а выключает его. Повторный DELETE безопасен.
+func (s *Service) deleteUser(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.store.SetUserActive(r.Context(), chi.URLParam(r, "id"), false); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// updateUser меняет только то, что действительно изменилось: повтор того же
+// запроса от IdP не увеличивает версии команд и не публикует событий.
+func (s *Service) updateUser(ctx context.Context, cur repo.User, username string, active bool) (repo.User, error) {
+	u := cur
+	var err error
+	if username != cur.Username {
+		if u, err = s.store.RenameUser(ctx, cur.UserID, username); err != nil {
+			return repo.User{}, err
+		}
+	}
+	if active != cur.IsActive {
+		if u, err = s.store.SetUserActive(ctx, cur.UserID, active); err != nil {
+			return repo.User{}, err
+		}
+	}
+	return u, nil
+}
@@ -0,0 +1,13 @@
+// Package cliutil — общие мелочи утилит из cmd/.
+package cliutil
+
+import "os"
+
+// EnvOr возвращает значение переменной окружения key или def, если она не
+// задана или пуста. Используется для значений флагов по умолчанию.
+func EnvOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,77 @@
+package worktime
+
+import (
+	"fmt"
+	"time"
+)
+
+const DateLayout = "2006-01-02"
+
+// maxDays ограничивает перебор дней, чтобы очень старые назначения не считались бесконечно.
+const maxDays = 3660
+
+// Schedule — рабочее время в конкретном часовом поясе: интервал [Start, End)
+// от полуночи в рабочие дни недели, кроме праздников.
+type Schedule struct {
+	Location *time.Location
+	Start    time.Duration
+	End      time.Duration
+	Days     [7]bool // индекс — time.Weekday
+	Holidays map[string]bool
+}
+
+// Default — пн–пт с 09:00 до 18:00 UTC.
+func Default() Schedule {
+	s := Schedule{Location: time.UTC, Start: 9 * time.Hour, End: 18 * time.Hour}
+	for d := time.Monday; d <= time.Friday; d++ {
+		s.Days[d] = true
+	}
+	return s
+}
+
+// ParseClock разбирает время суток в формате "HH:MM".
+func ParseClock(v string) (time.Duration, error) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: want HH:MM", v)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Between возвращает рабочее время между from и to.
+func (s Schedule) Between(from, to time.Time) time.Duration {
+	if !to.After(from) || s.End <= s.Start {
+		return 0
+	}
+	loc := s.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	from, to = from.In(loc), to.In(loc)
+
+	var total time.Duration
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	for i := 0; i < maxDays && day.Before(to); i++ {
+		if s.Days[day.Weekday()] && !s.Holidays[day.Format(DateLayout)] {
+			start := clock(day, s.Start)
+			end := clock(day, s.End)
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(to) {
+				end = to
+			}
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return total
+}
+
+// clock возвращает момент d от начала дня day по местным часам (с учётом перехода на летнее время).
+func clock(day time.Time, d time.Duration) time.Time {
+	h, m := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	return time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location())
+}
@@ -0,0 +1,108 @@
+package worktime
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestBetween(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+	newYork := mustLoad(t, "America/New_York")
+	moscow := mustLoad(t, "Europe/Moscow")
+
+	at := func(loc *time.Location, s string) time.Time {
+		v, err := time.ParseInLocation("2006-01-02 15:04", s, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	in := func(loc *time.Location) Schedule {
+		s := Default()
+		s.Location = loc
+		return s
+	}
+	// allDays — каждый день с полуночи до 06:00: в это окно попадает переход часов
+	allDays := func(loc *time.Location) Schedule {
+		s := Schedule{Location: loc, Start: 0, End: 6 * time.Hour}
+		for d := range s.Days {
+			s.Days[d] = true
+		}
+		return s
+	}
+	holiday := Default()
+	holiday.Holidays = map[string]bool{"2026-03-09": true}
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		from, to time.Time
+		want     time.Duration
+	}{
+		{"same working day", Default(), at(time.UTC, "2026-03-03 10:00"), at(time.UTC, "2026-03-03 12:30"), 150 * time.Minute},
+		{"friday 17:00 to monday 10:00", Default(), at(time.UTC, "2026-03-06 17:00"), at(time.UTC, "2026-03-09 10:00"), 2 * time.Hour},
+		{"full week", Default(), at(time.UTC, "2026-03-02 00:00"), at(time.UTC, "2026-03-09 00:00"), 45 * time.Hour},
+		{"before and after hours", Default(), at(time.UTC, "2026-03-03 07:00"), at(time.UTC, "2026-03-03 20:00"), 9 * time.Hour},
+
+		{"starts on saturday", Default(), at(time.UTC, "2026-03-07 12:00"), at(time.UTC, "2026-03-09 11:00"), 2 * time.Hour},
+		{"ends on sunday", Default(), at(time.UTC, "2026-03-06 17:30"), at(time.UTC, "2026-03-08 15:00"), 30 * time.Minute},
+		{"whole weekend", Default(), at(time.UTC, "2026-03-07 09:00"), at(time.UTC, "2026-03-08 18:00"), 0},
+		{"starts after hours", Default(), at(time.UTC, "2026-03-06 19:00"), at(time.UTC, "2026-03-09 09:30"), 30 * time.Minute},
+		{"starts on a holiday", holiday, at(time.UTC, "2026-03-09 10:00"), at(time.UTC, "2026-03-10 10:00"), time.Hour},
+		{"ends on a holiday", holiday, at(time.UTC, "2026-03-06 17:00"), at(time.UTC, "2026-03-09 17:00"), time.Hour},
+
+		{"user's time zone", in(moscow), at(time.UTC, "2026-03-02 07:00"), at(time.UTC, "2026-03-02 10:00"), 3 * time.Hour},
+		{"same instants in UTC", Default(), at(time.UTC, "2026-03-02 07:00"), at(time.UTC, "2026-03-02 10:00"), time.Hour},
+
+		// 8 марта 2026 в Нью-Йорке переводят часы: рабочий день остаётся 09:00–18:00 по местным часам
+		{"weekend across DST start", in(newYork), at(newYork, "2026-03-06 17:00"), at(newYork, "2026-03-09 10:00"), 2 * time.Hour},
+		// 29 марта 2026 в Берлине 02:00 -> 03:00: в окне 00:00–06:00 воскресенья пять часов
+		{"day with DST start", allDays(berlin), at(berlin, "2026-03-28 00:00"), at(berlin, "2026-03-30 00:00"), 11 * time.Hour},
+		// 25 октября 2026 в Берлине 03:00 -> 02:00: в том же окне семь часов
+		{"day with DST end", allDays(berlin), at(berlin, "2026-10-24 00:00"), at(berlin, "2026-10-26 00:00"), 13 * time.Hour},
+
+		{"to before from", Default(), at(time.UTC, "2026-03-03 12:00"), at(time.UTC, "2026-03-03 10:00"), 0},
+		{"empty working hours", Schedule{Location: time.UTC, Start: 9 * time.Hour, End: 9 * time.Hour, Days: Default().Days},
+			at(time.UTC, "2026-03-03 08:00"), at(time.UTC, "2026-03-03 20:00"), 0},
+		{"nil location is UTC", Schedule{Start: 9 * time.Hour, End: 18 * time.Hour, Days: Default().Days},
+			at(time.UTC, "2026-03-03 08:00"), at(time.UTC, "2026-03-03 10:00"), time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.Between(tt.from, tt.to); got != tt.want {
+				t.Errorf("Between(%s, %s) = %s, want %s", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"09:00", 9 * time.Hour, false},
+		{"18:30", 18*time.Hour + 30*time.Minute, false},
+		{"00:00", 0, false},
+		{"24:00", 0, true},
+		{"9", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseClock(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseClock(%q) = %s, %v; want %s, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
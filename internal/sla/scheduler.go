@@ -0,0 +1,55 @@
+package sla
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"pr-reviewer-service/internal/lib/logger"
+	"pr-reviewer-service/internal/storage/repo"
+)
+
+// Scheduler периодически проверяет SLA ревью. Несколько реплик могут работать
+// одновременно: за один тик проверку выполняет только одна из них.
+type Scheduler struct {
+	store    *repo.Store
+	log      *slog.Logger
+	interval time.Duration
+}
+
+func NewScheduler(store *repo.Store, log *slog.Logger, interval time.Duration) *Scheduler {
+	return &Scheduler{store: store, log: log, interval: interval}
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	rep, err := s.store.ProcessSLA(ctx, time.Now())
+	if err != nil {
+		s.log.Error("sla check failed", logger.Err(err))
+		return
+	}
+	if rep.Skipped {
+		s.log.Debug("sla check skipped: running on another replica")
+		return
+	}
+	if rep.Reminded > 0 || rep.Escalated > 0 || rep.Failed > 0 {
+		s.log.Info("sla check done",
+			slog.Int("reminded", rep.Reminded),
+			slog.Int("escalated", rep.Escalated),
+			slog.Int("failed", rep.Failed),
+		)
+	}
+}
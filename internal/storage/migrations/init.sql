@@ -26,3 +26,126 @@ CREATE TABLE IF NOT EXISTS pr_reviewers (
 );
 CREATE INDEX IF NOT EXISTS idx_pr_reviewers_by_reviewer ON pr_reviewers(reviewer_id);
 CREATE INDEX IF NOT EXISTS idx_users_by_team ON users(team_name);
+
+CREATE TABLE IF NOT EXISTS user_skills (
+  user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+  tag TEXT NOT NULL,
+  level INT NOT NULL DEFAULT 1 CHECK (level BETWEEN 1 AND 5),
+  PRIMARY KEY (user_id, tag)
+);
+CREATE INDEX IF NOT EXISTS idx_user_skills_by_tag ON user_skills(tag);
+
+CREATE TABLE IF NOT EXISTS pull_request_tags (
+  pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+  tag TEXT NOT NULL,
+  PRIMARY KEY (pull_request_id, tag)
+);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS level TEXT NOT NULL DEFAULT 'middle' CHECK (level IN ('junior','middle','senior','lead'));
+
+CREATE TABLE IF NOT EXISTS team_policies (
+  team_name TEXT PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+  min_level TEXT NOT NULL DEFAULT 'senior' CHECK (min_level IN ('junior','middle','senior','lead')),
+  min_level_count INT NOT NULL DEFAULT 0 CHECK (min_level_count >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS pr_assignments (
+  id BIGSERIAL PRIMARY KEY,
+  pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+  kind TEXT NOT NULL,
+  strategy TEXT NOT NULL,
+  algorithm TEXT NOT NULL,
+  seed BIGINT NOT NULL,
+  replaced_reviewer_id TEXT NULL,
+  reviewers TEXT[] NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_pr_assignments_by_pr ON pr_assignments(pull_request_id, id);
+
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS assignment_id BIGINT NULL REFERENCES pr_assignments(id) ON DELETE SET NULL;
+
+ALTER TABLE pr_assignments ADD COLUMN IF NOT EXISTS details JSONB NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_open_reviews INT NULL CHECK (max_open_reviews >= 0);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS away_until TIMESTAMPTZ NULL;
+
+ALTER TABLE pr_assignments ADD COLUMN IF NOT EXISTS reason TEXT NULL;
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS sla_reminder_hours INT NULL CHECK (sla_reminder_hours > 0);
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS sla_escalate_hours INT NULL CHECK (sla_escalate_hours > 0);
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS reminded_at TIMESTAMPTZ NULL;
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS escalated_at TIMESTAMPTZ NULL;
+
+CREATE TABLE IF NOT EXISTS events (
+  id BIGSERIAL PRIMARY KEY,
+  type TEXT NOT NULL,
+  team_name TEXT NULL,
+  user_id TEXT NULL,
+  pull_request_id TEXT NULL,
+  payload JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_events_by_team ON events(team_name, id);
+CREATE INDEX IF NOT EXISTS idx_events_by_user ON events(user_id, id);
+
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS time_zone TEXT NULL;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS work_start TEXT NULL;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS work_end TEXT NULL;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS work_days INT[] NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS time_zone TEXT NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS work_start TEXT NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS work_end TEXT NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS work_days INT[] NULL;
+
+CREATE TABLE IF NOT EXISTS team_holidays (
+  team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+  day DATE NOT NULL,
+  PRIMARY KEY (team_name, day)
+);
+
+CREATE TABLE IF NOT EXISTS user_holidays (
+  user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+  day DATE NOT NULL,
+  PRIMARY KEY (user_id, day)
+);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS related_users TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+  key TEXT PRIMARY KEY,
+  fingerprint TEXT NOT NULL,
+  status INT NULL,
+  headers JSONB NULL,
+  body BYTEA NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires ON idempotency_keys(expires_at);
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS review_declines (
+  id BIGSERIAL PRIMARY KEY,
+  pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+  reason TEXT NOT NULL,
+  comment TEXT NULL,
+  replaced_by TEXT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_review_declines_by_user ON review_declines(user_id);
+
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS reviewer_count INT NOT NULL DEFAULT 2 CHECK (reviewer_count BETWEEN 0 AND 10);
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS strategy TEXT NULL;
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS fallback_teams TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS default_max_open_reviews INT NULL CHECK (default_max_open_reviews >= 0);
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS allow_merged_changes BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE TABLE IF NOT EXISTS team_policy_history (
+  team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+  version BIGINT NOT NULL,
+  policy JSONB NOT NULL,
+  changed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (team_name, version)
+);
@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	AssignmentCreate     = "create"
+	AssignmentReassign   = "reassign"
+	AssignmentEscalation = "escalation"
+	AssignmentManual     = "manual"
+	AssignmentDecline    = "decline"
+)
+
+// Assignment — одно решение о назначении ревьюверов вместе с параметрами,
+// по которым его можно пересчитать.
+type Assignment struct {
+	ID                 int64     `json:"assignment_id"`
+	PullRequestID      string    `json:"pull_request_id"`
+	Kind               string    `json:"kind"`
+	Strategy           string    `json:"strategy"`
+	Algorithm          string    `json:"algorithm"`
+	Seed               int64     `json:"seed"`
+	ReplacedReviewerID *string   `json:"replaced_reviewer_id,omitempty"`
+	Reason             *string   `json:"reason,omitempty"`
+	Reviewers          []string  `json:"reviewers"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type assignmentRecord struct {
+	Kind string
+	// Algorithm — для ручных изменений, где алгоритм выбора не применялся.
+	Algorithm string
+	Replaced  string
+	Reason    string
+	Reviewers []string
+	Details   assignmentDetails
+}
+
+// recordAssignment записывает назначение в журнал и возвращает сохранённую запись.
+func recordAssignment(ctx context.Context, q querier, sel selector, rec assignmentRecord) (Assignment, error) {
+	a := Assignment{
+		PullRequestID: sel.prID,
+		Kind:          rec.Kind,
+		Strategy:      sel.strategy,
+		Algorithm:     rec.Algorithm,
+		Seed:          sel.seed,
+		Reviewers:     rec.Reviewers,
+	}
+	if a.Algorithm == "" {
+		a.Algorithm = AlgorithmVersion
+	}
+	if rec.Replaced != "" {
+		a.ReplacedReviewerID = &rec.Replaced
+	}
+	if rec.Reason != "" {
+		a.Reason = &rec.Reason
+	}
+	raw, err := json.Marshal(rec.Details)
+	if err != nil {
+		return Assignment{}, err
+	}
+	err = q.QueryRow(ctx, `
+INSERT INTO pr_assignments(pull_request_id, kind, strategy, algorithm, seed, replaced_reviewer_id, reason, reviewers, details)
+VALUES($1,$2,$3,$4,$5,NULLIF($6,''),NULLIF($7,''),$8,$9)
+RETURNING id, created_at
+`, a.PullRequestID, a.Kind, a.Strategy, a.Algorithm, a.Seed, rec.Replaced, rec.Reason, a.Reviewers, raw).Scan(&a.ID, &a.CreatedAt)
+	return a, err
+}
+
+func (s *Store) GetAssignments(ctx context.Context, prID string) ([]Assignment, error) {
+	var exists bool
+	if err := s.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id=$1)`, prID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	rows, err := s.pool.Query(ctx, `
+SELECT id, pull_request_id, kind, strategy, algorithm, seed, replaced_reviewer_id, reason, reviewers, created_at
+FROM pr_assignments
+WHERE pull_request_id=$1
+ORDER BY id
+`, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []Assignment{}
+	for rows.Next() {
+		var a Assignment
+		if err := rows.Scan(&a.ID, &a.PullRequestID, &a.Kind, &a.Strategy, &a.Algorithm, &a.Seed, &a.ReplacedReviewerID, &a.Reason, &a.Reviewers, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, a)
+	}
+	return res, rows.Err()
+}
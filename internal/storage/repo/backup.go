@@ -0,0 +1,193 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// BackupTable описывает таблицу снимка. Key — первичный ключ, Refs — внешние
+// ключи (колонка → таблица, на первичный ключ которой она ссылается).
+type BackupTable struct {
+	Name    string
+	OrderBy string
+	Key     []string
+	Refs    map[string]string
+	// Serial — id из BIGSERIAL: после восстановления сдвигается последовательность.
+	Serial bool
+}
+
+// BackupTables — таблицы снимка в порядке зависимостей. idempotency_keys не
+// входит: это кэш ответов, а не состояние сервиса.
+var BackupTables = []BackupTable{
+	{Name: "teams", OrderBy: "team_name", Key: []string{"team_name"}},
+	{Name: "team_policies", OrderBy: "team_name", Key: []string{"team_name"}, Refs: map[string]string{"team_name": "teams"}},
+	{Name: "team_policy_history", OrderBy: "team_name, version", Key: []string{"team_name", "version"}, Refs: map[string]string{"team_name": "teams"}},
+	{Name: "team_holidays", OrderBy: "team_name, day", Key: []string{"team_name", "day"}, Refs: map[string]string{"team_name": "teams"}},
+	{Name: "users", OrderBy: "user_id", Key: []string{"user_id"}, Refs: map[string]string{"team_name": "teams"}},
+	{Name: "user_skills", OrderBy: "user_id, tag", Key: []string{"user_id", "tag"}, Refs: map[string]string{"user_id": "users"}},
+	{Name: "user_holidays", OrderBy: "user_id, day", Key: []string{"user_id", "day"}, Refs: map[string]string{"user_id": "users"}},
+	{Name: "pull_requests", OrderBy: "pull_request_id", Key: []string{"pull_request_id"}, Refs: map[string]string{"author_id": "users"}},
+	{Name: "pull_request_tags", OrderBy: "pull_request_id, tag", Key: []string{"pull_request_id", "tag"}, Refs: map[string]string{"pull_request_id": "pull_requests"}},
+	{Name: "pr_assignments", OrderBy: "id", Key: []string{"id"}, Refs: map[string]string{"pull_request_id": "pull_requests"}, Serial: true},
+	{Name: "pr_reviewers", OrderBy: "pull_request_id, reviewer_id", Key: []string{"pull_request_id", "reviewer_id"},
+		Refs: map[string]string{"pull_request_id": "pull_requests", "reviewer_id": "users", "assignment_id": "pr_assignments"}},
+	{Name: "review_declines", OrderBy: "id", Key: []string{"id"}, Refs: map[string]string{"pull_request_id": "pull_requests", "user_id": "users"}, Serial: true},
+	{Name: "events", OrderBy: "id", Key: []string{"id"}, Serial: true},
+}
+
+// ExportRows отдаёт строки всех таблиц снимка в fn в виде JSON-объектов
+// «колонка → значение». Чтение идёт в одной транзакции REPEATABLE READ, поэтому
+// снимок согласован, даже если сервис в это время принимает запросы.
+func (s *Store) ExportRows(ctx context.Context, fn func(table string, row []byte) error) error {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, t := range BackupTables {
+		rows, err := tx.Query(ctx, fmt.Sprintf(`SELECT row_to_json(t)::text FROM %s t ORDER BY %s`, t.Name, t.OrderBy))
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var row []byte
+			if err := rows.Scan(&row); err != nil {
+				rows.Close()
+				return err
+			}
+			if err := fn(t.Name, row); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreTable — строки одной таблицы снимка; у всех строк набор колонок Columns.
+type RestoreTable struct {
+	Name    string
+	Columns []string
+	Rows    []json.RawMessage
+}
+
+type RestoreResult struct {
+	Tables map[string]int `json:"tables"`
+	Rows   int            `json:"rows"`
+	DryRun bool           `json:"dry_run"`
+}
+
+// restoreChunk — сколько строк вставляется одним запросом.
+const restoreChunk = 500
+
+// Restore загружает снимок в пустую базу одной транзакцией. Таблицы вставляются
+// в порядке BackupTables независимо от порядка в tables; внешние ключи базы
+// проверяют ссылки ещё раз. С dryRun транзакция откатывается.
+func (s *Store) Restore(ctx context.Context, tables []RestoreTable, dryRun bool) (RestoreResult, error) {
+	res := RestoreResult{Tables: map[string]int{}, DryRun: dryRun}
+	byName := map[string]RestoreTable{}
+	for _, t := range tables {
+		byName[t.Name] = t
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback(ctx)
+
+	// блокировка не даёт двум восстановлениям одновременно увидеть пустую базу
+	if _, err := tx.Exec(ctx, `LOCK TABLE teams IN EXCLUSIVE MODE`); err != nil {
+		return res, err
+	}
+	var used bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM teams) OR EXISTS (SELECT 1 FROM users)
+		    OR EXISTS (SELECT 1 FROM pull_requests) OR EXISTS (SELECT 1 FROM events)`).Scan(&used)
+	if err != nil {
+		return res, err
+	}
+	if used {
+		return res, ErrNotEmpty
+	}
+
+	for _, spec := range BackupTables {
+		t, ok := byName[spec.Name]
+		if !ok || len(t.Rows) == 0 {
+			continue
+		}
+		if err := checkColumns(ctx, tx, t); err != nil {
+			return res, err
+		}
+		cols := strings.Join(t.Columns, ", ")
+		query := fmt.Sprintf(`INSERT INTO %[1]s (%[2]s) SELECT %[2]s FROM json_populate_recordset(NULL::%[1]s, $1::json)`, t.Name, cols)
+		for from := 0; from < len(t.Rows); from += restoreChunk {
+			chunk := t.Rows[from:min(from+restoreChunk, len(t.Rows))]
+			batch, err := json.Marshal(chunk)
+			if err != nil {
+				return res, err
+			}
+			if _, err := tx.Exec(ctx, query, string(batch)); err != nil {
+				// нарушения ограничений и неверные значения — проблема снимка
+				var pgErr *pgconn.PgError
+				if errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")) {
+					return res, &Error{Code: CodeInvalidBackup, Message: fmt.Sprintf("table %s: %s", t.Name, pgErr.Message)}
+				}
+				return res, fmt.Errorf("restore %s: %w", t.Name, err)
+			}
+		}
+		if spec.Serial {
+			_, err := tx.Exec(ctx, fmt.Sprintf(
+				`SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM %[1]s`, t.Name))
+			if err != nil {
+				return res, err
+			}
+		}
+		res.Tables[t.Name] = len(t.Rows)
+		res.Rows += len(t.Rows)
+	}
+
+	if dryRun {
+		return res, nil
+	}
+	return res, tx.Commit(ctx)
+}
+
+// checkColumns сверяет колонки снимка со схемой: имена попадают в текст
+// запроса, поэтому неизвестная колонка — ошибка снимка, а не базы.
+func checkColumns(ctx context.Context, q querier, t RestoreTable) error {
+	rows, err := q.Query(ctx, `SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1`, t.Name)
+	if err != nil {
+		return err
+	}
+	known := map[string]bool{}
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			rows.Close()
+			return err
+		}
+		known[c] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, c := range t.Columns {
+		if !known[c] {
+			return &Error{Code: CodeInvalidBackup, Message: fmt.Sprintf("table %s has no column %s", t.Name, c)}
+		}
+	}
+	return nil
+}
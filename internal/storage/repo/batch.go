@@ -0,0 +1,169 @@
+package repo
+
+import (
+	"context"
+	"time"
+)
+
+// Пакетные выборки для GraphQL: каждая загружает сразу все запрошенные ключи
+// одним запросом, чтобы вложенные поля не превращались в N+1 запросов.
+// Отсутствующие ключи просто не попадают в результат.
+
+func (s *Store) TeamsByName(ctx context.Context, names []string) (map[string]Team, error) {
+	res := map[string]Team{}
+	if len(names) == 0 {
+		return res, nil
+	}
+	rows, err := s.pool.Query(ctx, `SELECT team_name, version FROM teams WHERE team_name = ANY($1)`, names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t Team
+		if err := rows.Scan(&t.TeamName, &t.Version); err != nil {
+			return nil, err
+		}
+		res[t.TeamName] = t
+	}
+	return res, rows.Err()
+}
+
+const userColumns = `user_id, username, team_name, is_active, level, max_open_reviews, away_until`
+
+func (s *Store) UsersByID(ctx context.Context, ids []string) (map[string]User, error) {
+	res := map[string]User{}
+	if len(ids) == 0 {
+		return res, nil
+	}
+	list, err := s.queryUsers(ctx, `SELECT `+userColumns+` FROM users WHERE user_id = ANY($1)`, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range list {
+		res[u.UserID] = u
+	}
+	return res, nil
+}
+
+// UsersByTeam возвращает участников каждой команды, упорядоченных по user_id.
+func (s *Store) UsersByTeam(ctx context.Context, teams []string) (map[string][]User, error) {
+	res := map[string][]User{}
+	if len(teams) == 0 {
+		return res, nil
+	}
+	list, err := s.queryUsers(ctx, `SELECT `+userColumns+` FROM users WHERE team_name = ANY($1) ORDER BY user_id`, teams)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range list {
+		res[u.TeamName] = append(res[u.TeamName], u)
+	}
+	return res, nil
+}
+
+func (s *Store) queryUsers(ctx context.Context, sql string, args ...any) ([]User, error) {
+	rows, err := s.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := []User{}
+	for rows.Next() {
+		var u User
+		if err := rows.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive, &u.Level, &u.MaxOpenReviews, &u.AwayUntil); err != nil {
+			return nil, err
+		}
+		res = append(res, u)
+	}
+	return res, rows.Err()
+}
+
+// PullRequestsByID загружает PR вместе с ревьюверами и тегами тремя запросами на весь пакет.
+func (s *Store) PullRequestsByID(ctx context.Context, ids []string) (map[string]PullRequest, error) {
+	res := map[string]PullRequest{}
+	if len(ids) == 0 {
+		return res, nil
+	}
+	rows, err := s.pool.Query(ctx, `
+SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, version
+FROM pull_requests WHERE pull_request_id = ANY($1)
+`, ids)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var pr PullRequest
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &pr.Version); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		pr.AssignedReviewers, pr.RequiredTags = []string{}, []string{}
+		res[pr.PullRequestID] = pr
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	pairs := func(sql string, add func(pr *PullRequest, v string)) error {
+		rows, err := s.pool.Query(ctx, sql, ids)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id, v string
+			if err := rows.Scan(&id, &v); err != nil {
+				return err
+			}
+			pr := res[id]
+			add(&pr, v)
+			res[id] = pr
+		}
+		return rows.Err()
+	}
+	if err := pairs(`SELECT pull_request_id, reviewer_id FROM pr_reviewers WHERE pull_request_id = ANY($1) ORDER BY reviewer_id`,
+		func(pr *PullRequest, v string) { pr.AssignedReviewers = append(pr.AssignedReviewers, v) }); err != nil {
+		return nil, err
+	}
+	if err := pairs(`SELECT pull_request_id, tag FROM pull_request_tags WHERE pull_request_id = ANY($1) ORDER BY tag`,
+		func(pr *PullRequest, v string) { pr.RequiredTags = append(pr.RequiredTags, v) }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// OpenReview — открытый PR, на который назначен ревьювер.
+type OpenReview struct {
+	PullRequestID string
+	AssignedAt    time.Time
+}
+
+// OpenReviewsByReviewer возвращает открытые ревью каждого пользователя, старые первыми.
+func (s *Store) OpenReviewsByReviewer(ctx context.Context, userIDs []string) (map[string][]OpenReview, error) {
+	res := map[string][]OpenReview{}
+	if len(userIDs) == 0 {
+		return res, nil
+	}
+	rows, err := s.pool.Query(ctx, `
+SELECT r.reviewer_id, r.pull_request_id, r.assigned_at
+FROM pr_reviewers r
+JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+WHERE r.reviewer_id = ANY($1) AND pr.status = 'OPEN'
+ORDER BY r.assigned_at, r.pull_request_id
+`, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var user string
+		var o OpenReview
+		if err := rows.Scan(&user, &o.PullRequestID, &o.AssignedAt); err != nil {
+			return nil, err
+		}
+		res[user] = append(res[user], o)
+	}
+	return res, rows.Err()
+}
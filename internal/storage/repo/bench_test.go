@@ -0,0 +1,364 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Бенчмарки и проверка числа запросов работают с настоящим Postgres: DSN
+// берётся из PR_REVIEWER_TEST_DB, без него тесты пропускаются. Схема
+// применяется из migrations/init.sql, данные создаются с уникальным префиксом
+// и удаляются после теста, поэтому подойдёт и база с другими данными.
+//
+//	PR_REVIEWER_TEST_DB=postgres://... go test ./internal/storage/repo -run QueryCounts -bench . -benchtime 50x
+
+// queryCounter считает запросы, отправленные в базу, включая BEGIN и COMMIT.
+type queryCounter struct {
+	n atomic.Int64
+}
+
+func (c *queryCounter) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	c.n.Add(1)
+	return ctx
+}
+
+func (c *queryCounter) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+// count возвращает число запросов, выполненных за время call.
+func (c *queryCounter) count(call func()) int64 {
+	before := c.n.Load()
+	call()
+	return c.n.Load() - before
+}
+
+func openTestStore(tb testing.TB) (*Store, *pgxpool.Pool, *queryCounter) {
+	tb.Helper()
+	dsn := os.Getenv("PR_REVIEWER_TEST_DB")
+	if dsn == "" {
+		tb.Skip("PR_REVIEWER_TEST_DB is not set")
+	}
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	counter := &queryCounter{}
+	cfg.ConnConfig.Tracer = counter
+	ctx := context.Background()
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(pool.Close)
+
+	schema, err := os.ReadFile("../migrations/init.sql")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	for _, q := range strings.Split(string(schema), ";") {
+		if q = strings.TrimSpace(q); q == "" {
+			continue
+		}
+		if _, err := pool.Exec(ctx, q); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return New(pool, Options{}), pool, counter
+}
+
+// dataset — синтетические данные: команды по teamSize человек (резервная
+// команда — следующая по кругу), пользователи с навыками и PR с двумя
+// ревьюверами из команды автора; open процентов PR открыты.
+type dataset struct {
+	prefix   string
+	users    int
+	prs      int
+	teamSize int
+	open     int
+}
+
+func (d dataset) teams() int {
+	return (d.users + d.teamSize - 1) / d.teamSize
+}
+
+func (d dataset) team(i int) string {
+	return d.prefix + "-t" + strconv.Itoa(i)
+}
+
+var sizes = []struct {
+	name       string
+	users, prs int
+}{
+	{"users=100,prs=1000", 100, 1000},
+	{"users=1000,prs=10000", 1000, 10000},
+}
+
+var datasetSeq atomic.Int64
+
+func seedDataset(tb testing.TB, pool *pgxpool.Pool, users, prs int) dataset {
+	tb.Helper()
+	d := dataset{
+		prefix:   fmt.Sprintf("bench%d-%d", time.Now().Unix(), datasetSeq.Add(1)),
+		users:    users,
+		prs:      prs,
+		teamSize: 50,
+		open:     30,
+	}
+	ctx := context.Background()
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer tx.Rollback(ctx)
+
+	steps := []struct {
+		sql  string
+		args []any
+	}{
+		{`INSERT INTO teams(team_name) SELECT $1 || '-t' || g FROM generate_series(1, $2) g`, []any{d.prefix, d.teams()}},
+		{`
+INSERT INTO team_policies(team_name, fallback_teams)
+SELECT $1 || '-t' || g, ARRAY[$1 || '-t' || (g % $2 + 1)] FROM generate_series(1, $2) g
+`, []any{d.prefix, d.teams()}},
+		{`
+INSERT INTO users(user_id, username, team_name, is_active, level)
+SELECT $1 || '-u' || g, 'Bench user ' || g, $1 || '-t' || ((g - 1) / $3 + 1), true,
+  (ARRAY['junior','middle','senior','lead'])[1 + g % 4]
+FROM generate_series(1, $2) g
+`, []any{d.prefix, d.users, d.teamSize}},
+		{`
+INSERT INTO user_skills(user_id, tag, level)
+SELECT $1 || '-u' || g, (ARRAY['go','sql','frontend','infra'])[1 + g % 4], 1 + g % 5
+FROM generate_series(1, $2) g
+`, []any{d.prefix, d.users}},
+		{`
+INSERT INTO pull_requests(pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
+SELECT $1 || '-pr' || g, 'Bench PR ' || g, $1 || '-u' || (1 + (g::bigint * 7919) % $3),
+  CASE WHEN g % 100 < $4 THEN 'OPEN' ELSE 'MERGED' END,
+  now() - make_interval(mins => $2 - g),
+  CASE WHEN g % 100 < $4 THEN NULL ELSE now() - make_interval(mins => $2 - g) + interval '1 hour' END
+FROM generate_series(1, $2) g
+`, []any{d.prefix, d.prs, d.users, d.open}},
+		{`
+INSERT INTO pull_request_tags(pull_request_id, tag)
+SELECT $1 || '-pr' || g, (ARRAY['go','sql','frontend','infra'])[1 + g % 4] FROM generate_series(1, $2) g
+`, []any{d.prefix, d.prs}},
+		{`
+INSERT INTO pr_reviewers(pull_request_id, reviewer_id, assigned_at)
+SELECT pr.pull_request_id, c.user_id, pr.created_at
+FROM pull_requests pr
+JOIN users a ON a.user_id = pr.author_id
+CROSS JOIN LATERAL (
+  SELECT u.user_id FROM users u
+  WHERE u.team_name = a.team_name AND u.user_id <> a.user_id
+  ORDER BY md5(u.user_id || pr.pull_request_id)
+  LIMIT 2
+) c
+WHERE pr.pull_request_id LIKE $1 || '-pr%'
+`, []any{d.prefix}},
+	}
+	for _, st := range steps {
+		if _, err := tx.Exec(ctx, st.sql, st.args...); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		tb.Fatal(err)
+	}
+	if _, err := pool.Exec(ctx, `ANALYZE users, user_skills, pull_requests, pull_request_tags, pr_reviewers`); err != nil {
+		tb.Fatal(err)
+	}
+
+	tb.Cleanup(func() {
+		for _, sql := range []string{
+			`DELETE FROM events WHERE team_name LIKE $1 || '-t%' OR pull_request_id LIKE $1 || '-pr%'`,
+			`DELETE FROM pull_requests WHERE pull_request_id LIKE $1 || '-pr%'`,
+			`DELETE FROM teams WHERE team_name LIKE $1 || '-t%'`,
+		} {
+			if _, err := pool.Exec(ctx, sql, d.prefix); err != nil {
+				tb.Error(err)
+			}
+		}
+	})
+	return d
+}
+
+// openSlots возвращает открытые PR набора и по одному их ревьюверу.
+func openSlots(tb testing.TB, pool *pgxpool.Pool, d dataset) [][2]string {
+	tb.Helper()
+	rows, err := pool.Query(context.Background(), `
+SELECT pr.pull_request_id, MIN(r.reviewer_id)
+FROM pull_requests pr
+JOIN pr_reviewers r ON r.pull_request_id = pr.pull_request_id
+WHERE pr.pull_request_id LIKE $1 || '-pr%' AND pr.status = 'OPEN'
+GROUP BY pr.pull_request_id
+ORDER BY pr.pull_request_id
+`, d.prefix)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) ([2]string, error) {
+		var s [2]string
+		err := row.Scan(&s[0], &s[1])
+		return s, err
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if len(res) == 0 {
+		tb.Fatal("dataset has no open pull requests")
+	}
+	return res
+}
+
+// reopen возвращает PR в OPEN, чтобы MergePR можно было вызвать снова.
+func reopen(tb testing.TB, pool *pgxpool.Pool, prID string) {
+	tb.Helper()
+	if _, err := pool.Exec(context.Background(), `UPDATE pull_requests SET status='OPEN', merged_at=NULL WHERE pull_request_id=$1`, prID); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+// reactivate включает пользователей команды обратно после BulkDeactivateTeam.
+func reactivate(tb testing.TB, pool *pgxpool.Pool, team string) {
+	tb.Helper()
+	if _, err := pool.Exec(context.Background(), `UPDATE users SET is_active=true WHERE team_name=$1`, team); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+// TestQueryCounts проверяет, что число запросов на вызов не растёт вместе с
+// числом PR и пользователей: в набор в 10 раз больше — столько же запросов.
+func TestQueryCounts(t *testing.T) {
+	store, pool, counter := openTestStore(t)
+	ctx := context.Background()
+
+	type counts struct{ merge, reassign, deactivate int64 }
+	var got []counts
+	for _, size := range sizes {
+		d := seedDataset(t, pool, size.users, size.prs)
+		slots := openSlots(t, pool, d)
+		var c counts
+
+		for _, s := range slots[:5] {
+			n := counter.count(func() {
+				if _, err := store.ReassignReviewer(ctx, s[0], s[1], 0); err != nil {
+					t.Fatalf("%s: reassign %s: %v", size.name, s[0], err)
+				}
+			})
+			c.reassign = max(c.reassign, n)
+		}
+		for _, s := range slots[5:10] {
+			n := counter.count(func() {
+				if _, err := store.MergePR(ctx, s[0], 0); err != nil {
+					t.Fatalf("%s: merge %s: %v", size.name, s[0], err)
+				}
+			})
+			c.merge = max(c.merge, n)
+		}
+		var reassigned int
+		n := counter.count(func() {
+			res, err := store.BulkDeactivateTeam(ctx, d.team(1), 0)
+			if err != nil {
+				t.Fatalf("%s: deactivate: %v", size.name, err)
+			}
+			reassigned = res.ReassignedPRsCount
+		})
+		c.deactivate = n
+
+		t.Logf("%s: MergePR %d, ReassignReviewer %d, BulkDeactivateTeam %d queries (%d PRs reassigned)",
+			size.name, c.merge, c.reassign, c.deactivate, reassigned)
+		got = append(got, c)
+	}
+
+	small, large := got[0], got[len(got)-1]
+	if large.merge > small.merge || large.reassign > small.reassign || large.deactivate > small.deactivate {
+		t.Errorf("query count grows with data size: %+v -> %+v", small, large)
+	}
+}
+
+func BenchmarkMergePR(b *testing.B) {
+	store, pool, counter := openTestStore(b)
+	ctx := context.Background()
+	for _, size := range sizes {
+		d := seedDataset(b, pool, size.users, size.prs)
+		slots := openSlots(b, pool, d)
+		b.Run(size.name, func(b *testing.B) {
+			var queries int64
+			for i := 0; i < b.N; i++ {
+				pr := slots[i%len(slots)][0]
+				if i >= len(slots) {
+					b.StopTimer()
+					reopen(b, pool, pr)
+					b.StartTimer()
+				}
+				queries += counter.count(func() {
+					if _, err := store.MergePR(ctx, pr, 0); err != nil {
+						b.Fatal(err)
+					}
+				})
+			}
+			b.ReportMetric(float64(queries)/float64(b.N), "queries/op")
+		})
+	}
+}
+
+func BenchmarkReassignReviewer(b *testing.B) {
+	store, pool, counter := openTestStore(b)
+	ctx := context.Background()
+	for _, size := range sizes {
+		d := seedDataset(b, pool, size.users, size.prs)
+		slots := openSlots(b, pool, d)
+		b.Run(size.name, func(b *testing.B) {
+			var queries int64
+			for i := 0; i < b.N; i++ {
+				s := &slots[i%len(slots)]
+				queries += counter.count(func() {
+					newID, err := store.ReassignReviewer(ctx, s[0], s[1], 0)
+					if err != nil {
+						b.Fatal(err)
+					}
+					// на следующем круге заменяется уже новый ревьювер
+					s[1] = newID
+				})
+			}
+			b.ReportMetric(float64(queries)/float64(b.N), "queries/op")
+		})
+	}
+}
+
+func BenchmarkBulkDeactivateTeam(b *testing.B) {
+	store, pool, counter := openTestStore(b)
+	ctx := context.Background()
+	for _, size := range sizes {
+		d := seedDataset(b, pool, size.users, size.prs)
+		b.Run(size.name, func(b *testing.B) {
+			var queries int64
+			var reassigned int
+			for i := 0; i < b.N; i++ {
+				// каждая вторая команда, чтобы у резервной оставались активные люди
+				team := d.team(1 + (2*i)%d.teams())
+				b.StopTimer()
+				reactivate(b, pool, team)
+				b.StartTimer()
+				queries += counter.count(func() {
+					res, err := store.BulkDeactivateTeam(ctx, team, 0)
+					if err != nil {
+						b.Fatal(err)
+					}
+					reassigned += res.ReassignedPRsCount
+				})
+			}
+			b.ReportMetric(float64(queries)/float64(b.N), "queries/op")
+			b.ReportMetric(float64(reassigned)/float64(b.N), "reassigned/op")
+		})
+	}
+}
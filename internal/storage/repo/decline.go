@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"context"
+	"strings"
+)
+
+// Причины отказа ревьювера от назначения.
+const (
+	DeclineBusy      = "busy"
+	DeclineNoContext = "no_context"
+	DeclineConflict  = "conflict_of_interest"
+	DeclineOther     = "other"
+)
+
+func ValidDeclineReason(r string) bool {
+	switch r {
+	case DeclineBusy, DeclineNoContext, DeclineConflict, DeclineOther:
+		return true
+	}
+	return false
+}
+
+// DeclineReview снимает ревьювера с PR по его собственной просьбе и сразу
+// подбирает замену той же стратегией, что и ReassignReviewer.
+func (s *Store) DeclineReview(ctx context.Context, prID, userID, reason, comment string, expectedVersion int64) (string, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	note := "declined: " + reason
+	if c := strings.TrimSpace(comment); c != "" {
+		note += " (" + c + ")"
+	}
+	newID, err := s.reassign(ctx, tx, prID, userID, expectedVersion, AssignmentDecline, note)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := tx.Exec(ctx, `
+INSERT INTO review_declines(pull_request_id, user_id, reason, comment, replaced_by)
+VALUES($1, $2, $3, NULLIF($4,''), $5)
+`, prID, userID, reason, strings.TrimSpace(comment), newID); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", err
+	}
+	return newID, nil
+}
+
+// loadDeclines возвращает число отказов каждого пользователя по причинам.
+func loadDeclines(ctx context.Context, q querier) (map[string]map[string]int64, error) {
+	rows, err := q.Query(ctx, `SELECT user_id, reason, COUNT(*) FROM review_declines GROUP BY user_id, reason`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := map[string]map[string]int64{}
+	for rows.Next() {
+		var user, reason string
+		var n int64
+		if err := rows.Scan(&user, &reason, &n); err != nil {
+			return nil, err
+		}
+		if res[user] == nil {
+			res[user] = map[string]int64{}
+		}
+		res[user][reason] = n
+	}
+	return res, rows.Err()
+}
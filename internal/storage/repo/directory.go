@@ -0,0 +1,187 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Операции над каталогом пользователей и команд по одному пользователю — для
+// провижининга из внешних систем (SCIM), где изменения приходят не командой целиком.
+
+// ListUsers возвращает всех пользователей, упорядоченных по user_id.
+func (s *Store) ListUsers(ctx context.Context) ([]User, error) {
+	return s.queryUsers(ctx, `SELECT `+userColumns+` FROM users ORDER BY user_id`)
+}
+
+// ListTeams возвращает все команды (без участников), упорядоченные по имени.
+func (s *Store) ListTeams(ctx context.Context) ([]Team, error) {
+	rows, err := s.pool.Query(ctx, `SELECT team_name, version FROM teams ORDER BY team_name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := []Team{}
+	for rows.Next() {
+		var t Team
+		if err := rows.Scan(&t.TeamName, &t.Version); err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	return res, rows.Err()
+}
+
+// EnsureTeam создаёт пустую команду, если её ещё нет; created — была ли она создана.
+func (s *Store) EnsureTeam(ctx context.Context, teamName string) (bool, error) {
+	cmd, err := s.pool.Exec(ctx, `INSERT INTO teams(team_name) VALUES($1) ON CONFLICT DO NOTHING`, teamName)
+	if err != nil {
+		return false, err
+	}
+	return cmd.RowsAffected() > 0, nil
+}
+
+// AddUser добавляет нового пользователя в u.TeamName (команда создаётся при
+// необходимости). Пустой Level — уровень по умолчанию.
+func (s *Store) AddUser(ctx context.Context, u User) (User, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return User{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	created, err := tx.Exec(ctx, `INSERT INTO teams(team_name) VALUES($1) ON CONFLICT DO NOTHING`, u.TeamName)
+	if err != nil {
+		return User{}, err
+	}
+	if created.RowsAffected() == 0 {
+		if err := lockTeam(ctx, tx, u.TeamName, 0); err != nil {
+			return User{}, err
+		}
+	}
+	var level *string
+	if u.Level != "" {
+		level = &u.Level
+	}
+	cmd, err := tx.Exec(ctx, `
+INSERT INTO users(user_id, username, team_name, is_active, level) VALUES($1,$2,$3,$4,COALESCE($5,'middle'))
+ON CONFLICT (user_id) DO NOTHING
+`, u.UserID, u.Username, u.TeamName, u.IsActive, level)
+	if err != nil {
+		return User{}, err
+	}
+	if cmd.RowsAffected() == 0 {
+		return User{}, ErrUserExists
+	}
+	if created.RowsAffected() == 0 {
+		if _, err := bumpTeamVersion(ctx, tx, u.TeamName); err != nil {
+			return User{}, err
+		}
+	}
+
+	res, err := getUser(ctx, tx, u.UserID)
+	if err != nil {
+		return User{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return User{}, err
+	}
+	return res, nil
+}
+
+// RenameUser меняет отображаемое имя пользователя.
+func (s *Store) RenameUser(ctx context.Context, userID, username string) (User, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return User{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var team, was string
+	if err := tx.QueryRow(ctx, `SELECT team_name, username FROM users WHERE user_id=$1 FOR UPDATE`, userID).Scan(&team, &was); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return User{}, ErrNotFound
+		}
+		return User{}, err
+	}
+	if was != username {
+		if _, err := tx.Exec(ctx, `UPDATE users SET username=$1 WHERE user_id=$2`, username, userID); err != nil {
+			return User{}, err
+		}
+		if _, err := bumpTeamVersion(ctx, tx, team); err != nil {
+			return User{}, err
+		}
+	}
+
+	u, err := getUser(ctx, tx, userID)
+	if err != nil {
+		return User{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return User{}, err
+	}
+	return u, nil
+}
+
+// MoveUsers переводит пользователей ids в команду to (она создаётся при
+// необходимости). Если from не пуст, переводятся только те, кто сейчас в from;
+// остальные не трогаются. Неизвестный пользователь — ErrNotFound, и ничего не меняется.
+func (s *Store) MoveUsers(ctx context.Context, ids []string, from, to string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	ids = slices.Compact(slices.Sorted(slices.Values(ids)))
+	if _, err := tx.Exec(ctx, `INSERT INTO teams(team_name) VALUES($1) ON CONFLICT DO NOTHING`, to); err != nil {
+		return err
+	}
+	var found int
+	if err := tx.QueryRow(ctx, `SELECT count(*) FROM users WHERE user_id = ANY($1)`, ids).Scan(&found); err != nil {
+		return err
+	}
+	if found != len(ids) {
+		return ErrNotFound
+	}
+
+	// версии всех затронутых команд растут, как при переносе в upsertTeam
+	rows, err := tx.Query(ctx, `
+WITH moved AS (
+  SELECT user_id, team_name AS old_team FROM users
+  WHERE user_id = ANY($1) AND team_name <> $3 AND ($2 = '' OR team_name = $2)
+  FOR UPDATE
+), upd AS (
+  UPDATE users u SET team_name = $3 FROM moved m WHERE u.user_id = m.user_id
+)
+SELECT DISTINCT old_team FROM moved
+`, ids, from, to)
+	if err != nil {
+		return err
+	}
+	var teams []string
+	for rows.Next() {
+		var t string
+		if err := rows.Scan(&t); err != nil {
+			rows.Close()
+			return err
+		}
+		teams = append(teams, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(teams) > 0 {
+		teams = append(teams, to)
+	}
+	for _, t := range teams {
+		if _, err := bumpTeamVersion(ctx, tx, t); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
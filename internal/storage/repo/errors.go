@@ -0,0 +1,80 @@
+package repo
+
+// Коды ошибок хранилища. Они же отдаются клиентам API в поле error.code.
+const (
+	CodeNotFound    = "NOT_FOUND"
+	CodePRExists    = "PR_EXISTS"
+	CodeUserExists  = "USER_EXISTS"
+	CodePRMerged    = "PR_MERGED"
+	CodeNotAssigned = "NOT_ASSIGNED"
+	CodeNoCandidate = "NO_CANDIDATE"
+	CodeSeniority   = "SENIORITY_UNSATISFIED"
+	CodeVersion     = "PRECONDITION_FAILED"
+	CodeIneligible  = "REVIEWER_INELIGIBLE"
+	CodeTooMany     = "TOO_MANY_REVIEWERS"
+	// CodeInvalidPolicy — политика команды не прошла проверку.
+	CodeInvalidPolicy = "INVALID_POLICY"
+	// CodeInvalidBackup — снимок не подходит к схеме базы.
+	CodeInvalidBackup = "INVALID_BACKUP"
+	// CodeNotEmpty — снимок восстанавливается только в пустую базу.
+	CodeNotEmpty = "NOT_EMPTY"
+
+	CodeIdempotencyMismatch   = "IDEMPOTENCY_KEY_REUSED"
+	CodeIdempotencyInProgress = "IDEMPOTENCY_IN_PROGRESS"
+)
+
+// Codes возвращает все коды ошибок хранилища. Новый код добавляется и сюда:
+// по этому списку тесты HTTP и gRPC проверяют, что коду сопоставлен статус.
+func Codes() []string {
+	return []string{
+		CodeNotFound,
+		CodePRExists,
+		CodeUserExists,
+		CodePRMerged,
+		CodeNotAssigned,
+		CodeNoCandidate,
+		CodeSeniority,
+		CodeVersion,
+		CodeIneligible,
+		CodeTooMany,
+		CodeInvalidPolicy,
+		CodeInvalidBackup,
+		CodeNotEmpty,
+		CodeIdempotencyMismatch,
+		CodeIdempotencyInProgress,
+	}
+}
+
+// Error — ожидаемая ошибка предметной области. Всё, что не является *Error,
+// считается внутренней ошибкой (сбой БД и т.п.) и не показывается клиенту.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Is сравнивает по коду, поэтому errors.Is находит ошибку и после обёртки
+// через fmt.Errorf("...: %w", err).
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+var (
+	ErrNotFound    = &Error{Code: CodeNotFound, Message: "not found"}
+	ErrPRExists    = &Error{Code: CodePRExists, Message: "pr already exists"}
+	ErrUserExists  = &Error{Code: CodeUserExists, Message: "user already exists"}
+	ErrPRMerged    = &Error{Code: CodePRMerged, Message: "pr is merged"}
+	ErrNotAssigned = &Error{Code: CodeNotAssigned, Message: "reviewer not assigned"}
+	ErrNoCandidate = &Error{Code: CodeNoCandidate, Message: "no candidate found"}
+	ErrSeniority   = &Error{Code: CodeSeniority, Message: "seniority rule not satisfied"}
+	// ErrVersionMismatch — объект изменили после того, как клиент его прочитал.
+	ErrVersionMismatch = &Error{Code: CodeVersion, Message: "resource was modified by someone else"}
+	ErrIneligible      = &Error{Code: CodeIneligible, Message: "reviewer is not eligible"}
+	ErrTooMany         = &Error{Code: CodeTooMany, Message: "too many reviewers"}
+	ErrNotEmpty        = &Error{Code: CodeNotEmpty, Message: "database is not empty: restore requires an empty database"}
+
+	ErrIdempotencyMismatch   = &Error{Code: CodeIdempotencyMismatch, Message: "idempotency key was used with a different request"}
+	ErrIdempotencyInProgress = &Error{Code: CodeIdempotencyInProgress, Message: "request with this idempotency key is still in progress"}
+)
@@ -0,0 +1,188 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// EventsChannel — канал LISTEN/NOTIFY, в который публикуются id новых событий.
+const EventsChannel = "pr_events"
+
+const (
+	EventPRCreated              = "pr.created"
+	EventPRReviewersChanged     = "pr.reviewers_changed"
+	EventPRMerged               = "pr.merged"
+	EventUserActiveChanged      = "user.active_changed"
+	EventReviewReminder         = "review.reminder"
+	EventReviewEscalated        = "review.escalated"
+	EventReviewEscalationFailed = "review.escalation_failed"
+)
+
+// Event — запись журнала событий. UserID — основной участник события,
+// RelatedUsers — остальные затронутые пользователи (автор, ревьюверы).
+type Event struct {
+	ID            int64          `json:"id"`
+	Type          string         `json:"type"`
+	TeamName      string         `json:"team_name,omitempty"`
+	UserID        string         `json:"user_id,omitempty"`
+	PullRequestID string         `json:"pull_request_id,omitempty"`
+	RelatedUsers  []string       `json:"related_users,omitempty"`
+	Payload       map[string]any `json:"payload,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+// EventFilter — пустые поля не ограничивают выборку.
+type EventFilter struct {
+	TeamName string
+	UserID   string
+}
+
+func (f EventFilter) Match(e Event) bool {
+	if f.TeamName != "" && e.TeamName != f.TeamName {
+		return false
+	}
+	if f.UserID != "" && e.UserID != f.UserID {
+		for _, u := range e.RelatedUsers {
+			if u == f.UserID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// insertEvent пишет событие в журнал и уведомляет слушателей; уведомление
+// доставляется только после коммита транзакции.
+func insertEvent(ctx context.Context, q querier, e Event) (int64, error) {
+	payload := e.Payload
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+	related := e.RelatedUsers
+	if related == nil {
+		related = []string{}
+	}
+	var id int64
+	err = q.QueryRow(ctx, `
+INSERT INTO events(type, team_name, user_id, pull_request_id, related_users, payload)
+VALUES($1, NULLIF($2,''), NULLIF($3,''), NULLIF($4,''), $5, $6)
+RETURNING id
+`, e.Type, e.TeamName, e.UserID, e.PullRequestID, related, raw).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := q.Exec(ctx, `SELECT pg_notify($1, $2)`, EventsChannel, strconv.FormatInt(id, 10)); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// insertEvents пишет пачку событий одним запросом и уведомляет о каждом в
+// порядке пачки.
+func insertEvents(ctx context.Context, q querier, events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	n := len(events)
+	types, teams, users, prs := make([]string, n), make([]string, n), make([]string, n), make([]string, n)
+	related, payloads := make([]string, n), make([]string, n)
+	for i, e := range events {
+		types[i], teams[i], users[i], prs[i] = e.Type, e.TeamName, e.UserID, e.PullRequestID
+		rel := e.RelatedUsers
+		if rel == nil {
+			rel = []string{}
+		}
+		payload := e.Payload
+		if payload == nil {
+			payload = map[string]any{}
+		}
+		rawRel, err := json.Marshal(rel)
+		if err != nil {
+			return err
+		}
+		rawPayload, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		related[i], payloads[i] = string(rawRel), string(rawPayload)
+	}
+	// связанных пользователей у событий разное число, поэтому они идут JSON-массивами
+	_, err := q.Exec(ctx, `
+WITH inserted AS (
+  INSERT INTO events(type, team_name, user_id, pull_request_id, related_users, payload)
+  SELECT e.type, NULLIF(e.team_name,''), NULLIF(e.user_id,''), NULLIF(e.pull_request_id,''),
+    ARRAY(SELECT jsonb_array_elements_text(e.related::jsonb)), e.payload::jsonb
+  FROM unnest($1::text[], $2::text[], $3::text[], $4::text[], $5::text[], $6::text[])
+    WITH ORDINALITY AS e(type, team_name, user_id, pull_request_id, related, payload, n)
+  ORDER BY e.n
+  RETURNING id
+)
+SELECT pg_notify($7, id::text) FROM inserted ORDER BY id
+`, types, teams, users, prs, related, payloads, EventsChannel)
+	return err
+}
+
+const eventColumns = `id, type, COALESCE(team_name,''), COALESCE(user_id,''), COALESCE(pull_request_id,''), related_users, payload, created_at`
+
+func scanEvent(row pgx.Row) (Event, error) {
+	var e Event
+	var raw []byte
+	if err := row.Scan(&e.ID, &e.Type, &e.TeamName, &e.UserID, &e.PullRequestID, &e.RelatedUsers, &raw, &e.CreatedAt); err != nil {
+		return Event{}, err
+	}
+	if err := json.Unmarshal(raw, &e.Payload); err != nil {
+		return Event{}, err
+	}
+	return e, nil
+}
+
+func (s *Store) GetEvent(ctx context.Context, id int64) (Event, error) {
+	e, err := scanEvent(s.pool.QueryRow(ctx, `SELECT `+eventColumns+` FROM events WHERE id=$1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Event{}, ErrNotFound
+	}
+	return e, err
+}
+
+// EventsSince возвращает до limit событий с id больше afterID в порядке возрастания.
+func (s *Store) EventsSince(ctx context.Context, afterID int64, f EventFilter, limit int) ([]Event, error) {
+	rows, err := s.pool.Query(ctx, `
+SELECT `+eventColumns+`
+FROM events
+WHERE id > $1
+  AND ($2 = '' OR team_name = $2)
+  AND ($3 = '' OR user_id = $3 OR $3 = ANY(related_users))
+ORDER BY id
+LIMIT $4
+`, afterID, f.TeamName, f.UserID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []Event{}
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+	return res, rows.Err()
+}
+
+func (s *Store) LastEventID(ctx context.Context) (int64, error) {
+	var id int64
+	err := s.pool.QueryRow(ctx, `SELECT COALESCE(MAX(id), 0) FROM events`).Scan(&id)
+	return id, err
+}
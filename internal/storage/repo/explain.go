@@ -0,0 +1,212 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Причины, по которым участник команды не попал в кандидаты.
+const (
+	ExcludedInactive     = "inactive"
+	ExcludedAuthor       = "author"
+	ExcludedAssigned     = "already_assigned"
+	ExcludedOverCapacity = "over_capacity"
+	ExcludedAway         = "away"
+	ExcludedOtherTeam    = "other_team"
+)
+
+// CandidateExplanation описывает, как участник команды был рассмотрен при назначении.
+// Position — место в ранжировании среди допущенных кандидатов (с 1).
+type CandidateExplanation struct {
+	UserID      string     `json:"user_id"`
+	Level       string     `json:"level"`
+	Excluded    []string   `json:"excluded,omitempty"`
+	TagOverlap  int        `json:"tag_overlap"`
+	SkillLevel  int        `json:"skill_level"`
+	Qualified   bool       `json:"meets_seniority"`
+	LastPaired  *time.Time `json:"last_paired_at,omitempty"`
+	Rank        string     `json:"rank,omitempty"`
+	Position    int        `json:"position,omitempty"`
+	Selected    bool       `json:"selected"`
+	OpenReviews int        `json:"open_reviews"`
+	// Fallback — кандидат из резервной команды политики.
+	Fallback bool `json:"fallback,omitempty"`
+}
+
+type Explanation struct {
+	Assignment
+	Tags       []string               `json:"required_tags"`
+	Rule       SeniorityRule          `json:"seniority_rule"`
+	Candidates []CandidateExplanation `json:"candidates"`
+	Manual     *ManualChange          `json:"manual,omitempty"`
+}
+
+// assignmentDetails сохраняется в pr_assignments.details.
+type assignmentDetails struct {
+	Tags       []string               `json:"required_tags"`
+	Rule       SeniorityRule          `json:"seniority_rule"`
+	Candidates []CandidateExplanation `json:"candidates"`
+	Manual     *ManualChange          `json:"manual,omitempty"`
+}
+
+type member struct {
+	UserID         string
+	TeamName       string
+	Level          string
+	IsActive       bool
+	MaxOpenReviews *int
+	AwayUntil      *time.Time
+	OpenReviews    int
+}
+
+const memberColumns = `u.user_id, u.team_name, u.level, u.is_active, u.max_open_reviews, u.away_until,
+  (SELECT COUNT(*) FROM pr_reviewers r JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+   WHERE r.reviewer_id = u.user_id AND pr.status = 'OPEN')`
+
+func loadMembers(ctx context.Context, q querier, teamName string) ([]member, error) {
+	return queryMembers(ctx, q, `SELECT `+memberColumns+` FROM users u WHERE u.team_name=$1 ORDER BY u.user_id`, teamName)
+}
+
+// loadUsers загружает пользователей по id независимо от команды.
+func loadUsers(ctx context.Context, q querier, ids []string) ([]member, error) {
+	return queryMembers(ctx, q, `SELECT `+memberColumns+` FROM users u WHERE u.user_id = ANY($1) ORDER BY u.user_id`, ids)
+}
+
+func queryMembers(ctx context.Context, q querier, sql string, arg any) ([]member, error) {
+	rows, err := q.Query(ctx, sql, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []member{}
+	for rows.Next() {
+		var m member
+		if err := rows.Scan(&m.UserID, &m.TeamName, &m.Level, &m.IsActive, &m.MaxOpenReviews, &m.AwayUntil, &m.OpenReviews); err != nil {
+			return nil, err
+		}
+		res = append(res, m)
+	}
+	return res, rows.Err()
+}
+
+// evaluate отсеивает участников команды, которых нельзя назначить, и записывает причины.
+func evaluate(members []member, authorID string, assigned map[string]bool, now time.Time) ([]member, []CandidateExplanation) {
+	eligible := make([]member, 0, len(members))
+	expl := make([]CandidateExplanation, 0, len(members))
+	for _, m := range members {
+		e := CandidateExplanation{UserID: m.UserID, Level: m.Level, OpenReviews: m.OpenReviews}
+		if !m.IsActive {
+			e.Excluded = append(e.Excluded, ExcludedInactive)
+		}
+		if m.UserID == authorID {
+			e.Excluded = append(e.Excluded, ExcludedAuthor)
+		}
+		if assigned[m.UserID] {
+			e.Excluded = append(e.Excluded, ExcludedAssigned)
+		}
+		if m.MaxOpenReviews != nil && m.OpenReviews >= *m.MaxOpenReviews {
+			e.Excluded = append(e.Excluded, ExcludedOverCapacity)
+		}
+		if m.AwayUntil != nil && m.AwayUntil.After(now) {
+			e.Excluded = append(e.Excluded, ExcludedAway)
+		}
+		if len(e.Excluded) == 0 {
+			eligible = append(eligible, m)
+		}
+		expl = append(expl, e)
+	}
+	return eligible, expl
+}
+
+// explain дополняет описание кандидатов очками, местом в ранжировании и итоговым выбором.
+func (sel selector) explain(expl []CandidateExplanation, cands []candidate, rule SeniorityRule, picked []string) []CandidateExplanation {
+	ordered := make([]candidate, len(cands))
+	copy(ordered, cands)
+	sort.Slice(ordered, func(i, j int) bool {
+		if sel.better(ordered[i], ordered[j], nil) {
+			return true
+		}
+		if sel.better(ordered[j], ordered[i], nil) {
+			return false
+		}
+		return sel.rank(ordered[i].UserID) < sel.rank(ordered[j].UserID)
+	})
+	byID := make(map[string]int, len(ordered))
+	for i, c := range ordered {
+		byID[c.UserID] = i
+	}
+	chosen := map[string]bool{}
+	for _, id := range picked {
+		chosen[id] = true
+	}
+
+	for i := range expl {
+		e := &expl[i]
+		e.Qualified = rule.satisfiedBy(e.Level)
+		pos, ok := byID[e.UserID]
+		if !ok {
+			continue
+		}
+		c := ordered[pos]
+		_, e.TagOverlap, e.SkillLevel = score(c, sel.tags, nil)
+		if !c.LastPaired.IsZero() {
+			t := c.LastPaired
+			e.LastPaired = &t
+		}
+		e.Rank = formatRank(sel.rank(c.UserID))
+		e.Position = pos + 1
+		e.Selected = chosen[e.UserID]
+	}
+	return expl
+}
+
+func formatRank(r uint64) string {
+	return strconv.FormatUint(r, 16)
+}
+
+func (s *Store) ExplainPR(ctx context.Context, prID string) (Explanation, error) {
+	var a Assignment
+	var details []byte
+	err := s.pool.QueryRow(ctx, `
+SELECT id, pull_request_id, kind, strategy, algorithm, seed, replaced_reviewer_id, reason, reviewers, created_at, details
+FROM pr_assignments
+WHERE pull_request_id=$1
+ORDER BY id DESC
+LIMIT 1
+`, prID).Scan(&a.ID, &a.PullRequestID, &a.Kind, &a.Strategy, &a.Algorithm, &a.Seed, &a.ReplacedReviewerID, &a.Reason, &a.Reviewers, &a.CreatedAt, &details)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Explanation{}, ErrNotFound
+	}
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	var d assignmentDetails
+	if len(details) > 0 {
+		if err := json.Unmarshal(details, &d); err != nil {
+			return Explanation{}, err
+		}
+	}
+	return explanationOf(a, d), nil
+}
+
+func explanationOf(a Assignment, d assignmentDetails) Explanation {
+	e := Explanation{Assignment: a, Tags: d.Tags, Rule: d.Rule, Candidates: d.Candidates, Manual: d.Manual}
+	if e.Reviewers == nil {
+		e.Reviewers = []string{}
+	}
+	if e.Tags == nil {
+		e.Tags = []string{}
+	}
+	if e.Candidates == nil {
+		e.Candidates = []CandidateExplanation{}
+	}
+	return e
+}
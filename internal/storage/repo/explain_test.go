@@ -0,0 +1,41 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCreatePRExplanation(t *testing.T) {
+	s, _, _ := openTestStore(t)
+	ctx := context.Background()
+	team := Team{TeamName: fmt.Sprintf("explain-%d", time.Now().UnixNano())}
+	for i := 1; i <= 4; i++ {
+		team.Members = append(team.Members, TeamMember{UserID: fmt.Sprintf("%s-u%d", team.TeamName, i), Username: "user", IsActive: true})
+	}
+	if _, err := s.CreateTeam(ctx, team, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	prID := team.TeamName + "-pr"
+	pr, expl, err := s.CreatePR(ctx, prID, "change", team.Members[0].UserID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expl.Kind != AssignmentCreate || expl.PullRequestID != prID {
+		t.Errorf("explanation of %s/%s, want %s/%s", expl.PullRequestID, expl.Kind, prID, AssignmentCreate)
+	}
+	if fmt.Sprint(expl.Reviewers) != fmt.Sprint(pr.AssignedReviewers) {
+		t.Errorf("explanation reviewers %v, PR reviewers %v", expl.Reviewers, pr.AssignedReviewers)
+	}
+
+	// объяснение из CreatePR совпадает с тем, что потом читает ExplainPR
+	stored, err := s.ExplainPR(ctx, prID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.ID != expl.ID || !stored.CreatedAt.Equal(expl.CreatedAt) || len(stored.Candidates) != len(expl.Candidates) {
+		t.Errorf("CreatePR explanation %+v differs from stored %+v", expl.Assignment, stored.Assignment)
+	}
+}
@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// StoredResponse — ответ, сохранённый под ключом идемпотентности.
+type StoredResponse struct {
+	Status  int
+	Headers map[string]string
+	Body    []byte
+}
+
+// ReserveIdempotencyKey занимает ключ за запросом с данным отпечатком.
+// Возвращает nil, если ключ свободен и запрос нужно выполнить, или
+// сохранённый ответ, если запрос с этим ключом уже выполнялся.
+func (s *Store) ReserveIdempotencyKey(ctx context.Context, key, fingerprint string, ttl time.Duration) (*StoredResponse, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// просроченный ключ можно использовать заново
+	if _, err := tx.Exec(ctx, `DELETE FROM idempotency_keys WHERE key=$1 AND expires_at <= now()`, key); err != nil {
+		return nil, err
+	}
+	cmd, err := tx.Exec(ctx, `
+INSERT INTO idempotency_keys(key, fingerprint, expires_at)
+VALUES($1, $2, now() + make_interval(secs => $3))
+ON CONFLICT (key) DO NOTHING
+`, key, fingerprint, ttl.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	if cmd.RowsAffected() == 1 {
+		return nil, tx.Commit(ctx)
+	}
+
+	var storedFP string
+	var status *int
+	var headers []byte
+	var body []byte
+	err = tx.QueryRow(ctx, `SELECT fingerprint, status, headers, body FROM idempotency_keys WHERE key=$1`, key).
+		Scan(&storedFP, &status, &headers, &body)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// ключ освободили между INSERT и SELECT — клиент может повторить запрос
+		return nil, ErrIdempotencyInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+	if storedFP != fingerprint {
+		return nil, ErrIdempotencyMismatch
+	}
+	if status == nil {
+		return nil, ErrIdempotencyInProgress
+	}
+
+	resp := &StoredResponse{Status: *status, Body: body}
+	if len(headers) > 0 {
+		if err := json.Unmarshal(headers, &resp.Headers); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// CompleteIdempotencyKey сохраняет ответ на запрос, занявший ключ.
+func (s *Store) CompleteIdempotencyKey(ctx context.Context, key string, resp StoredResponse) error {
+	headers, err := json.Marshal(resp.Headers)
+	if err != nil {
+		return err
+	}
+	_, err = s.pool.Exec(ctx, `UPDATE idempotency_keys SET status=$2, headers=$3, body=$4 WHERE key=$1`,
+		key, resp.Status, headers, resp.Body)
+	return err
+}
+
+// ReleaseIdempotencyKey освобождает ключ, если запрос не дал результата,
+// который стоит повторять (ошибка сервера, паника).
+func (s *Store) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE key=$1 AND status IS NULL`, key)
+	return err
+}
+
+// PurgeIdempotencyKeys удаляет просроченные ключи и возвращает их число.
+func (s *Store) PurgeIdempotencyKeys(ctx context.Context) (int64, error) {
+	cmd, err := s.pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= now()`)
+	if err != nil {
+		return 0, err
+	}
+	return cmd.RowsAffected(), nil
+}
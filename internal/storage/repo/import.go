@@ -0,0 +1,342 @@
+package repo
+
+import (
+	"context"
+	"slices"
+	"sort"
+)
+
+// Действия в плане импорта.
+const (
+	ImportCreate     = "create"
+	ImportUpdate     = "update"
+	ImportMove       = "move"
+	ImportDeactivate = "deactivate"
+)
+
+// ImportChange — изменение одного пользователя. Fields — какие поля изменятся
+// (для create пусто); FromTeam — прежняя команда при переносе.
+type ImportChange struct {
+	Action   string   `json:"action"`
+	UserID   string   `json:"user_id"`
+	TeamName string   `json:"team_name"`
+	FromTeam string   `json:"from_team,omitempty"`
+	Fields   []string `json:"fields,omitempty"`
+}
+
+type ImportTeam struct {
+	TeamName string `json:"team_name"`
+	Created  bool   `json:"created"`
+	Changed  bool   `json:"changed"`
+	Version  int64  `json:"version"`
+}
+
+type ImportOptions struct {
+	// DryRun — посчитать план, ничего не меняя.
+	DryRun bool
+	// DeactivateMissing деактивирует участников импортируемых команд, которых нет в файле.
+	DeactivateMissing bool
+}
+
+type ImportResult struct {
+	Teams     []ImportTeam   `json:"teams"`
+	Changes   []ImportChange `json:"changes"`
+	Unchanged int            `json:"unchanged"`
+	DryRun    bool           `json:"dry_run"`
+}
+
+// ImportTeams сравнивает команды из файла с текущим состоянием и применяет
+// разницу одной транзакцией: либо импортируется весь файл, либо ничего.
+// Команды меняются тем же upsertTeam, что и в CreateTeam; состояние читается
+// под блокировкой команд, поэтому план совпадает с тем, что будет записано.
+func (s *Store) ImportTeams(ctx context.Context, teams []Team, opts ImportOptions) (ImportResult, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	p, err := planImport(ctx, tx, teams, opts, false)
+	if err != nil {
+		return p.res, err
+	}
+	if opts.DryRun {
+		return p.res, nil
+	}
+	if err := p.apply(ctx, tx); err != nil {
+		return p.res, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return p.res, err
+	}
+	return p.res, nil
+}
+
+// importPlan — вычисленная разница между файлом и базой, общая для ImportTeams и SyncOrg.
+type importPlan struct {
+	res   ImportResult
+	teams []Team
+	// changed — команды, состав которых меняется (включая команды, откуда уходят участники)
+	changed map[string]bool
+	// missing — активные пользователи, которых нет в файле и которые будут деактивированы
+	missing []string
+	// смена активности существующих пользователей публикуется событием, как в SetUserActive
+	activity map[string]bool
+	teamOf   map[string]string
+}
+
+// planImport блокирует затронутые команды и считает план. При org файл описывает
+// всю организацию: блокируются все команды, а DeactivateMissing касается всех
+// пользователей, а не только участников команд из файла.
+func planImport(ctx context.Context, tx querier, teams []Team, opts ImportOptions, org bool) (importPlan, error) {
+	p := importPlan{
+		res:      ImportResult{Teams: []ImportTeam{}, Changes: []ImportChange{}, DryRun: opts.DryRun},
+		teams:    teams,
+		changed:  map[string]bool{},
+		activity: map[string]bool{},
+		teamOf:   map[string]string{},
+	}
+
+	names := make([]string, len(teams))
+	ids := []string{}
+	for i, t := range teams {
+		names[i] = t.TeamName
+		for _, m := range t.Members {
+			ids = append(ids, m.UserID)
+		}
+	}
+
+	existing := map[string]int64{}
+	rows, err := tx.Query(ctx, `SELECT team_name, version FROM teams WHERE $2 OR team_name = ANY($1) ORDER BY team_name FOR UPDATE`, names, org)
+	if err != nil {
+		return p, err
+	}
+	for rows.Next() {
+		var name string
+		var version int64
+		if err := rows.Scan(&name, &version); err != nil {
+			rows.Close()
+			return p, err
+		}
+		existing[name] = version
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return p, err
+	}
+
+	current, err := loadImportState(ctx, tx, ids, names, org)
+	if err != nil {
+		return p, err
+	}
+
+	listed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		listed[id] = true
+	}
+
+	for _, t := range teams {
+		for _, m := range t.Members {
+			ch, ok := diffMember(t.TeamName, m, current[m.UserID])
+			if !ok {
+				p.res.Unchanged++
+				continue
+			}
+			p.res.Changes = append(p.res.Changes, ch)
+			p.changed[t.TeamName] = true
+			if ch.FromTeam != "" {
+				p.changed[ch.FromTeam] = true
+			}
+			if slices.Contains(ch.Fields, "is_active") {
+				p.activity[m.UserID], p.teamOf[m.UserID] = m.IsActive, t.TeamName
+			}
+		}
+	}
+
+	if opts.DeactivateMissing {
+		for _, u := range current {
+			if listed[u.UserID] || !u.IsActive || (!org && !slices.Contains(names, u.TeamName)) {
+				continue
+			}
+			p.missing = append(p.missing, u.UserID)
+			p.res.Changes = append(p.res.Changes, ImportChange{Action: ImportDeactivate, UserID: u.UserID, TeamName: u.TeamName, Fields: []string{"is_active"}})
+			p.changed[u.TeamName] = true
+			p.activity[u.UserID], p.teamOf[u.UserID] = false, u.TeamName
+		}
+		sort.Strings(p.missing)
+	}
+	sortChanges(p.res.Changes)
+
+	for _, t := range teams {
+		_, found := existing[t.TeamName]
+		p.res.Teams = append(p.res.Teams, ImportTeam{
+			TeamName: t.TeamName,
+			Created:  !found,
+			Changed:  p.changed[t.TeamName],
+			Version:  existing[t.TeamName],
+		})
+	}
+	// команды вне файла, из которых ушли или деактивированы участники
+	var others []string
+	for name := range p.changed {
+		if !slices.Contains(names, name) {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		p.res.Teams = append(p.res.Teams, ImportTeam{TeamName: name, Changed: true, Version: existing[name]})
+	}
+	return p, nil
+}
+
+// apply записывает план в транзакцию tx; версии команд в результате обновляются.
+func (p *importPlan) apply(ctx context.Context, tx querier) error {
+	if len(p.missing) > 0 {
+		if _, err := tx.Exec(ctx, `UPDATE users SET is_active = false WHERE user_id = ANY($1)`, p.missing); err != nil {
+			return err
+		}
+	}
+	for i, t := range p.teams {
+		if !p.changed[t.TeamName] && !p.res.Teams[i].Created {
+			continue
+		}
+		v, err := upsertTeam(ctx, tx, t, 0)
+		if err != nil {
+			return err
+		}
+		p.res.Teams[i].Version = v
+	}
+	// остальные команды меняются только деактивацией или уходом участников;
+	// уход уже учтён в upsertTeam, поэтому версия читается, а не увеличивается повторно
+	for i := len(p.teams); i < len(p.res.Teams); i++ {
+		team := &p.res.Teams[i]
+		var err error
+		if slices.ContainsFunc(p.missing, func(id string) bool { return p.teamOf[id] == team.TeamName }) {
+			team.Version, err = bumpTeamVersion(ctx, tx, team.TeamName)
+		} else {
+			err = tx.QueryRow(ctx, `SELECT version FROM teams WHERE team_name=$1`, team.TeamName).Scan(&team.Version)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, ch := range p.res.Changes {
+		active, ok := p.activity[ch.UserID]
+		if !ok {
+			continue
+		}
+		if _, err := insertEvent(ctx, tx, Event{
+			Type:     EventUserActiveChanged,
+			TeamName: p.teamOf[ch.UserID],
+			UserID:   ch.UserID,
+			Payload:  map[string]any{"is_active": active, "source": "import"},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deactivated возвращает пользователей, которых план деактивирует.
+func (p *importPlan) deactivated() []string {
+	var res []string
+	for _, ch := range p.res.Changes {
+		if active, ok := p.activity[ch.UserID]; ok && !active {
+			res = append(res, ch.UserID)
+		}
+	}
+	return res
+}
+
+type importUser struct {
+	TeamMember
+	TeamName string
+}
+
+// loadImportState загружает пользователей из файла и всех участников импортируемых
+// команд, а при everyone — всех пользователей.
+func loadImportState(ctx context.Context, q querier, ids, teams []string, everyone bool) (map[string]importUser, error) {
+	rows, err := q.Query(ctx, `
+SELECT user_id, username, team_name, is_active, level FROM users
+WHERE $3 OR user_id = ANY($1) OR team_name = ANY($2)
+FOR UPDATE
+`, ids, teams, everyone)
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]importUser{}
+	all := []string{}
+	for rows.Next() {
+		var u importUser
+		if err := rows.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive, &u.Level); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		res[u.UserID] = u
+		all = append(all, u.UserID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	skills, err := loadSkills(ctx, q, all)
+	if err != nil {
+		return nil, err
+	}
+	for id, u := range res {
+		u.Skills = skills[id]
+		res[id] = u
+	}
+	return res, nil
+}
+
+// diffMember сравнивает участника из файла с сохранённым; ok=false — изменений нет.
+// Пустой level и отсутствующие навыки не меняют сохранённые значения, как и в CreateTeam.
+func diffMember(team string, m TeamMember, cur importUser) (ImportChange, bool) {
+	ch := ImportChange{UserID: m.UserID, TeamName: team}
+	if cur.UserID == "" {
+		ch.Action = ImportCreate
+		return ch, true
+	}
+
+	if cur.TeamName != team {
+		ch.FromTeam = cur.TeamName
+		ch.Fields = append(ch.Fields, "team_name")
+	}
+	if cur.Username != m.Username {
+		ch.Fields = append(ch.Fields, "username")
+	}
+	if cur.IsActive != m.IsActive {
+		ch.Fields = append(ch.Fields, "is_active")
+	}
+	if m.Level != "" && m.Level != cur.Level {
+		ch.Fields = append(ch.Fields, "level")
+	}
+	if m.Skills != nil && !slices.Equal(normalizeSkills(m.Skills), normalizeSkills(cur.Skills)) {
+		ch.Fields = append(ch.Fields, "skills")
+	}
+
+	switch {
+	case len(ch.Fields) == 0:
+		return ch, false
+	case ch.FromTeam != "":
+		ch.Action = ImportMove
+	case cur.IsActive && !m.IsActive:
+		ch.Action = ImportDeactivate
+	default:
+		ch.Action = ImportUpdate
+	}
+	return ch, true
+}
+
+func sortChanges(changes []ImportChange) {
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].TeamName != changes[j].TeamName {
+			return changes[i].TeamName < changes[j].TeamName
+		}
+		return changes[i].UserID < changes[j].UserID
+	})
+}
@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffMember(t *testing.T) {
+	cur := importUser{
+		TeamMember: TeamMember{UserID: "u1", Username: "Alice", IsActive: true, Level: "senior", Skills: []Skill{{Tag: "go", Level: 3}}},
+		TeamName:   "backend",
+	}
+	same := cur.TeamMember
+
+	with := func(change func(*TeamMember)) TeamMember {
+		m := same
+		change(&m)
+		return m
+	}
+
+	tests := []struct {
+		name   string
+		team   string
+		member TeamMember
+		cur    importUser
+		want   ImportChange
+		ok     bool
+	}{
+		{"new user", "backend", same, importUser{},
+			ImportChange{Action: ImportCreate, UserID: "u1", TeamName: "backend"}, true},
+		{"no changes", "backend", same, cur, ImportChange{UserID: "u1", TeamName: "backend"}, false},
+		{"empty level keeps stored", "backend", with(func(m *TeamMember) { m.Level = "" }), cur,
+			ImportChange{UserID: "u1", TeamName: "backend"}, false},
+		{"nil skills keep stored", "backend", with(func(m *TeamMember) { m.Skills = nil }), cur,
+			ImportChange{UserID: "u1", TeamName: "backend"}, false},
+		{"skills compared normalized", "backend", with(func(m *TeamMember) { m.Skills = []Skill{{Tag: " Go ", Level: 3}} }), cur,
+			ImportChange{UserID: "u1", TeamName: "backend"}, false},
+
+		{"rename", "backend", with(func(m *TeamMember) { m.Username = "Alicia" }), cur,
+			ImportChange{Action: ImportUpdate, UserID: "u1", TeamName: "backend", Fields: []string{"username"}}, true},
+		{"level and skills", "backend", with(func(m *TeamMember) { m.Level = "lead"; m.Skills = []Skill{} }), cur,
+			ImportChange{Action: ImportUpdate, UserID: "u1", TeamName: "backend", Fields: []string{"level", "skills"}}, true},
+		{"reactivate is an update", "backend", same, importUser{TeamMember: with(func(m *TeamMember) { m.IsActive = false }), TeamName: "backend"},
+			ImportChange{Action: ImportUpdate, UserID: "u1", TeamName: "backend", Fields: []string{"is_active"}}, true},
+
+		{"deactivate", "backend", with(func(m *TeamMember) { m.IsActive = false }), cur,
+			ImportChange{Action: ImportDeactivate, UserID: "u1", TeamName: "backend", Fields: []string{"is_active"}}, true},
+		{"deactivate with other fields", "backend", with(func(m *TeamMember) { m.IsActive = false; m.Username = "A" }), cur,
+			ImportChange{Action: ImportDeactivate, UserID: "u1", TeamName: "backend", Fields: []string{"username", "is_active"}}, true},
+
+		{"move", "frontend", same, cur,
+			ImportChange{Action: ImportMove, UserID: "u1", TeamName: "frontend", FromTeam: "backend", Fields: []string{"team_name"}}, true},
+		{"move wins over deactivate", "frontend", with(func(m *TeamMember) { m.IsActive = false }), cur,
+			ImportChange{Action: ImportMove, UserID: "u1", TeamName: "frontend", FromTeam: "backend", Fields: []string{"team_name", "is_active"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := diffMember(tt.team, tt.member, tt.cur)
+			if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, %v; want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
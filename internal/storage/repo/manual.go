@@ -0,0 +1,237 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxReviewers — сколько ревьюверов назначается на PR автоматически и сколько
+// можно назначить вручную без override, если политика команды не задаёт иное.
+const MaxReviewers = 2
+
+// StrategyManual записывается в историю назначений для ручных изменений.
+const StrategyManual = "manual"
+
+// Причины, которые нельзя обойти даже с override.
+var hardExclusions = map[string]bool{
+	ExcludedAuthor:   true,
+	ExcludedAssigned: true,
+}
+
+type ReviewerChange struct {
+	PullRequestID string
+	Add           []string
+	Remove        []string
+	// Override разрешает назначить ревьювера в обход мягких ограничений: неактивность,
+	// отсутствие, лимит открытых ревью, команда вне политики, правило старшинства и число ревьюверов.
+	Override bool
+	Reason   string
+}
+
+// ManualChange сохраняется в деталях назначения вида manual.
+type ManualChange struct {
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+	Override bool     `json:"override"`
+	// Overridden — какие ограничения были обойдены для каждого добавленного ревьювера.
+	Overridden map[string][]string `json:"overridden,omitempty"`
+	// SeniorityOverridden — итоговый состав не удовлетворяет правилу старшинства команды.
+	SeniorityOverridden bool `json:"seniority_overridden,omitempty"`
+}
+
+// ChangeReviewers вручную добавляет и снимает ревьюверов открытого PR. Новые
+// ревьюверы проверяются по тем же правилам, что и при автоматическом назначении.
+func (s *Store) ChangeReviewers(ctx context.Context, ch ReviewerChange, expectedVersion int64) (PullRequest, error) {
+	add, remove := dedupe(ch.Add), dedupe(ch.Remove)
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return PullRequest{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	status, version, err := lockPR(ctx, tx, ch.PullRequestID)
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	var author, team string
+	if err := tx.QueryRow(ctx, `
+SELECT pr.author_id, u.team_name FROM pull_requests pr JOIN users u ON u.user_id = pr.author_id
+WHERE pr.pull_request_id=$1
+`, ch.PullRequestID).Scan(&author, &team); err != nil {
+		return PullRequest{}, err
+	}
+	policy, err := loadPolicy(ctx, tx, team)
+	if err != nil {
+		return PullRequest{}, err
+	}
+	if status == "MERGED" && !policy.AllowMergedChanges {
+		return PullRequest{}, ErrPRMerged
+	}
+	if err := checkVersion(version, expectedVersion); err != nil {
+		return PullRequest{}, err
+	}
+
+	current, err := loadReviewerLevels(ctx, tx, ch.PullRequestID)
+	if err != nil {
+		return PullRequest{}, err
+	}
+	for _, id := range remove {
+		if _, ok := current[id]; !ok {
+			return PullRequest{}, fmt.Errorf("%w: %s", ErrNotAssigned, id)
+		}
+	}
+	remaining := map[string]bool{}
+	for id := range current {
+		remaining[id] = true
+	}
+	for _, id := range remove {
+		delete(remaining, id)
+	}
+
+	users, err := loadUsers(ctx, tx, add)
+	if err != nil {
+		return PullRequest{}, err
+	}
+	if len(users) != len(add) {
+		return PullRequest{}, fmt.Errorf("%w: reviewer %s", ErrNotFound, missing(add, users))
+	}
+	if err := applyCapacityDefaults(ctx, tx, users, map[string]TeamPolicy{policy.TeamName: policy}); err != nil {
+		return PullRequest{}, err
+	}
+	_, checks := evaluate(users, author, remaining, time.Now())
+
+	allowedTeams := map[string]bool{team: true}
+	for _, t := range policy.FallbackTeams {
+		allowedTeams[t] = true
+	}
+	overridden := map[string][]string{}
+	for i, c := range checks {
+		reasons := c.Excluded
+		if !allowedTeams[users[i].TeamName] {
+			reasons = append(reasons, ExcludedOtherTeam)
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+		for _, r := range reasons {
+			if hardExclusions[r] || !ch.Override {
+				return PullRequest{}, &Error{
+					Code:    CodeIneligible,
+					Message: fmt.Sprintf("user %s cannot review this pr: %s", c.UserID, strings.Join(reasons, ", ")),
+				}
+			}
+		}
+		overridden[c.UserID] = reasons
+	}
+
+	levels := map[string]string{}
+	for id := range remaining {
+		levels[id] = current[id]
+	}
+	for _, u := range users {
+		levels[u.UserID] = u.Level
+	}
+	if len(levels) > policy.ReviewerCount && !ch.Override {
+		return PullRequest{}, fmt.Errorf("%w: at most %d reviewers without override", ErrTooMany, policy.ReviewerCount)
+	}
+
+	rule := policy.Rule()
+	qualified := 0
+	for _, level := range levels {
+		if rule.satisfiedBy(level) {
+			qualified++
+		}
+	}
+	seniorityBroken := qualified < rule.required(len(levels))
+	if seniorityBroken && !ch.Override {
+		return PullRequest{}, ErrSeniority
+	}
+
+	manual := &ManualChange{Added: add, Removed: remove, Override: ch.Override, SeniorityOverridden: seniorityBroken}
+	if len(overridden) > 0 {
+		manual.Overridden = overridden
+	}
+	rec := assignmentRecord{
+		Kind:      AssignmentManual,
+		Algorithm: StrategyManual,
+		Reason:    ch.Reason,
+		Reviewers: add,
+		Details:   assignmentDetails{Tags: []string{}, Rule: rule, Candidates: []CandidateExplanation{}, Manual: manual},
+	}
+	if len(remove) == 1 {
+		rec.Replaced = remove[0]
+	}
+	assignment, err := recordAssignment(ctx, tx, selector{prID: ch.PullRequestID, strategy: StrategyManual}, rec)
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM pr_reviewers WHERE pull_request_id=$1 AND reviewer_id = ANY($2)`, ch.PullRequestID, remove); err != nil {
+		return PullRequest{}, err
+	}
+	for _, id := range add {
+		if _, err := tx.Exec(ctx, `INSERT INTO pr_reviewers(pull_request_id, reviewer_id, assignment_id) VALUES($1,$2,$3)`, ch.PullRequestID, id, assignment.ID); err != nil {
+			return PullRequest{}, err
+		}
+	}
+	if err := bumpPRVersion(ctx, tx, ch.PullRequestID); err != nil {
+		return PullRequest{}, err
+	}
+
+	if _, err := insertEvent(ctx, tx, Event{
+		Type:          EventPRReviewersChanged,
+		TeamName:      team,
+		UserID:        author,
+		PullRequestID: ch.PullRequestID,
+		RelatedUsers:  append(append([]string{}, add...), remove...),
+		Payload: map[string]any{
+			"kind":     AssignmentManual,
+			"added":    add,
+			"removed":  remove,
+			"override": ch.Override,
+			"reason":   ch.Reason,
+		},
+	}); err != nil {
+		return PullRequest{}, err
+	}
+
+	pr, err := getPR(ctx, tx, ch.PullRequestID)
+	if err != nil {
+		return PullRequest{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return PullRequest{}, err
+	}
+	return pr, nil
+}
+
+func dedupe(ids []string) []string {
+	seen := map[string]bool{}
+	res := []string{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			res = append(res, id)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+func missing(ids []string, found []member) string {
+	have := map[string]bool{}
+	for _, m := range found {
+		have[m.UserID] = true
+	}
+	for _, id := range ids {
+		if !have[id] {
+			return id
+		}
+	}
+	return ""
+}
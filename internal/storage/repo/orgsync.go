@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+)
+
+// ErrPlanChanged — состояние изменилось после того, как клиент посмотрел план.
+var ErrPlanChanged = &Error{Code: CodeVersion, Message: "org state changed since the plan was computed"}
+
+type SyncOptions struct {
+	// DryRun — посчитать план и откатить транзакцию.
+	DryRun bool
+	// Plan — хеш плана, который видел клиент; пусто — без проверки.
+	Plan string
+}
+
+// SyncResult — план синхронизации и, если не DryRun, результат его применения.
+type SyncResult struct {
+	ImportResult
+	// Plan — хеш плана; его можно передать в SyncOptions.Plan при применении.
+	Plan               string            `json:"plan"`
+	ReassignedPRsCount int               `json:"reassigned_prs_count"`
+	ReassignFailures   map[string]string `json:"reassign_failures"` // prID -> error
+}
+
+// SyncOrg приводит организацию к документу teams, который описывает её целиком:
+// команды и участники создаются и обновляются как при импорте, все, кого в
+// документе нет, деактивируются, а открытые ревью деактивированных переназначаются
+// так же, как при BulkDeactivateTeam. Повторное применение того же документа
+// ничего не меняет. DryRun выполняет всё в транзакции и откатывает её, поэтому
+// план показывает и исход переназначений; версии команд в нём — текущие.
+func (s *Store) SyncOrg(ctx context.Context, teams []Team, opts SyncOptions) (SyncResult, error) {
+	res := SyncResult{ReassignFailures: map[string]string{}}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback(ctx)
+
+	p, err := planImport(ctx, tx, teams, ImportOptions{DryRun: opts.DryRun, DeactivateMissing: true}, true)
+	if err != nil {
+		return res, err
+	}
+	// apply обновляет версии в p.res.Teams на месте, а план и ответ dry run
+	// показывают текущие: откаченные версии нельзя передать в If-Match
+	res.ImportResult = p.res
+	res.Teams = slices.Clone(p.res.Teams)
+	if res.Plan, err = planHash(res.ImportResult); err != nil {
+		return res, err
+	}
+	if opts.Plan != "" && opts.Plan != res.Plan {
+		return res, ErrPlanChanged
+	}
+
+	if err := p.apply(ctx, tx); err != nil {
+		return res, err
+	}
+	if deactivated := p.deactivated(); len(deactivated) > 0 {
+		if res.ReassignedPRsCount, res.ReassignFailures, err = s.reassignInactive(ctx, tx, deactivated, "removed by org sync"); err != nil {
+			return res, err
+		}
+	}
+
+	if opts.DryRun {
+		return res, tx.Rollback(ctx)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return res, err
+	}
+	res.Teams = p.res.Teams
+	return res, nil
+}
+
+// planHash считается по плану до применения: версии команд в нём текущие, поэтому
+// любое изменение затронутых команд после просмотра плана меняет и хеш.
+func planHash(res ImportResult) (string, error) {
+	b, err := json.Marshal(struct {
+		Teams   []ImportTeam
+		Changes []ImportChange
+	}{res.Teams, res.Changes})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8]), nil
+}
@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSyncOrgDryRunKeepsVersions(t *testing.T) {
+	s, _, _ := openTestStore(t)
+	ctx := context.Background()
+	team := Team{TeamName: fmt.Sprintf("sync-%d", time.Now().UnixNano())}
+	for i := 1; i <= 2; i++ {
+		team.Members = append(team.Members, TeamMember{UserID: fmt.Sprintf("%s-u%d", team.TeamName, i), Username: "user", IsActive: true})
+	}
+	if _, err := s.CreateTeam(ctx, team, 0); err != nil {
+		t.Fatal(err)
+	}
+	before, err := s.GetTeam(ctx, team.TeamName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// документ переименовывает участника: при применении версия команды выросла бы.
+	// SyncOrg описывает всю организацию, поэтому только dry run — остальные
+	// данные в базе не трогаются
+	doc := Team{TeamName: team.TeamName, Members: slices.Clone(team.Members)}
+	doc.Members[0].Username = "renamed"
+	res, err := s.SyncOrg(ctx, []Team{doc}, SyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var found bool
+	for _, rt := range res.Teams {
+		if rt.TeamName != team.TeamName {
+			continue
+		}
+		found = true
+		if !rt.Changed {
+			t.Error("team is not marked as changed")
+		}
+		if rt.Version != before.Version {
+			t.Errorf("dry run version %d, committed version %d", rt.Version, before.Version)
+		}
+	}
+	if !found {
+		t.Fatalf("team %s is missing from the plan", team.TeamName)
+	}
+
+	after, err := s.GetTeam(ctx, team.TeamName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Version != before.Version {
+		t.Errorf("dry run changed the version: %d -> %d", before.Version, after.Version)
+	}
+}
@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"context"
+	"time"
+)
+
+type ReviewPair struct {
+	AuthorID       string    `json:"author_id"`
+	ReviewerID     string    `json:"reviewer_id"`
+	Count          int64     `json:"review_count"`
+	LastAssignedAt time.Time `json:"last_assigned_at"`
+}
+
+// PairMatrix — матрица автор×ревьювер внутри команды: Matrix[i][j] — сколько раз
+// Members[j] ревьюил PR автора Members[i].
+type PairMatrix struct {
+	TeamName string       `json:"team_name"`
+	Members  []string     `json:"members"`
+	Matrix   [][]int64    `json:"matrix"`
+	Pairs    []ReviewPair `json:"pairs"`
+}
+
+func (s *Store) GetPairMatrix(ctx context.Context, teamName string) (PairMatrix, error) {
+	res := PairMatrix{TeamName: teamName, Members: []string{}, Pairs: []ReviewPair{}}
+
+	rows, err := s.pool.Query(ctx, `SELECT user_id FROM users WHERE team_name=$1 ORDER BY user_id`, teamName)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return res, err
+		}
+		res.Members = append(res.Members, id)
+	}
+	if err := rows.Err(); err != nil {
+		return res, err
+	}
+	if len(res.Members) == 0 {
+		return res, ErrNotFound
+	}
+
+	pRows, err := s.pool.Query(ctx, `
+SELECT pr.author_id, r.reviewer_id, COUNT(*), MAX(r.assigned_at)
+FROM pr_reviewers r
+JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+JOIN users a ON a.user_id = pr.author_id
+JOIN users u ON u.user_id = r.reviewer_id
+WHERE a.team_name=$1 AND u.team_name=$1
+GROUP BY pr.author_id, r.reviewer_id
+ORDER BY pr.author_id, r.reviewer_id
+`, teamName)
+	if err != nil {
+		return res, err
+	}
+	defer pRows.Close()
+	for pRows.Next() {
+		var p ReviewPair
+		if err := pRows.Scan(&p.AuthorID, &p.ReviewerID, &p.Count, &p.LastAssignedAt); err != nil {
+			return res, err
+		}
+		res.Pairs = append(res.Pairs, p)
+	}
+	if err := pRows.Err(); err != nil {
+		return res, err
+	}
+
+	idx := make(map[string]int, len(res.Members))
+	for i, id := range res.Members {
+		idx[id] = i
+	}
+	res.Matrix = make([][]int64, len(res.Members))
+	for i := range res.Matrix {
+		res.Matrix[i] = make([]int64, len(res.Members))
+	}
+	for _, p := range res.Pairs {
+		res.Matrix[idx[p.AuthorID]][idx[p.ReviewerID]] = p.Count
+	}
+	return res, nil
+}
@@ -0,0 +1,395 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// MaxReviewerCount — верхняя граница reviewer_count в политике.
+const MaxReviewerCount = 10
+
+// TeamPolicy — все настройки назначения ревьюверов для PR авторов команды.
+// Политика версионируется: каждое изменение увеличивает Version и попадает в историю.
+type TeamPolicy struct {
+	TeamName      string `json:"team_name"`
+	ReviewerCount int    `json:"reviewer_count"`
+	// Strategy переопределяет стратегию сервиса; nil — стратегия по умолчанию.
+	Strategy *string `json:"strategy"`
+	// FallbackTeams — откуда брать ревьюверов по порядку, если в своей команде их не хватает.
+	FallbackTeams []string `json:"fallback_teams"`
+	MinLevel      string   `json:"min_level"`
+	MinCount      int      `json:"min_count"`
+	// DefaultMaxOpenReviews — лимит открытых ревью для участников без личного лимита.
+	DefaultMaxOpenReviews *int `json:"default_max_open_reviews"`
+	ReminderAfterHours    *int `json:"reminder_after_hours"`
+	EscalateAfterHours    *int `json:"escalate_after_hours"`
+	// AllowMergedChanges разрешает менять ревьюверов уже слитых PR.
+	AllowMergedChanges bool       `json:"allow_merged_changes"`
+	Version            int64      `json:"version"`
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
+}
+
+type PolicyRevision struct {
+	Version   int64      `json:"version"`
+	Policy    TeamPolicy `json:"policy"`
+	ChangedAt time.Time  `json:"changed_at"`
+}
+
+func DefaultPolicy(teamName string) TeamPolicy {
+	return TeamPolicy{
+		TeamName:      teamName,
+		ReviewerCount: MaxReviewers,
+		FallbackTeams: []string{},
+		MinLevel:      LevelSenior,
+	}
+}
+
+func (p TeamPolicy) Rule() SeniorityRule {
+	return SeniorityRule{TeamName: p.TeamName, MinLevel: p.MinLevel, MinCount: p.MinCount}
+}
+
+func (p TeamPolicy) SLA() SLAPolicy {
+	return SLAPolicy{TeamName: p.TeamName, ReminderAfterHours: p.ReminderAfterHours, EscalateAfterHours: p.EscalateAfterHours}
+}
+
+func invalidPolicy(format string, args ...any) error {
+	return &Error{Code: CodeInvalidPolicy, Message: fmt.Sprintf(format, args...)}
+}
+
+// Validate проверяет политику без обращения к БД; существование резервных
+// команд проверяет SetTeamPolicy.
+func (p TeamPolicy) Validate() error {
+	if p.ReviewerCount < 0 || p.ReviewerCount > MaxReviewerCount {
+		return invalidPolicy("reviewer_count must be between 0 and %d", MaxReviewerCount)
+	}
+	if p.Strategy != nil && !ValidStrategy(*p.Strategy) {
+		return invalidPolicy("unknown strategy %q", *p.Strategy)
+	}
+	seen := map[string]bool{}
+	for _, t := range p.FallbackTeams {
+		if t == "" || t == p.TeamName {
+			return invalidPolicy("fallback_teams must not contain empty names or the team itself")
+		}
+		if seen[t] {
+			return invalidPolicy("duplicate fallback team %q", t)
+		}
+		seen[t] = true
+	}
+	if !ValidLevel(p.MinLevel) {
+		return invalidPolicy("unknown min_level %q", p.MinLevel)
+	}
+	if p.MinCount < 0 {
+		return invalidPolicy("min_count must be non-negative")
+	}
+	if p.DefaultMaxOpenReviews != nil && *p.DefaultMaxOpenReviews < 0 {
+		return invalidPolicy("default_max_open_reviews must be non-negative")
+	}
+	if (p.ReminderAfterHours != nil && *p.ReminderAfterHours <= 0) || (p.EscalateAfterHours != nil && *p.EscalateAfterHours <= 0) {
+		return invalidPolicy("sla thresholds must be positive")
+	}
+	if p.ReminderAfterHours != nil && p.EscalateAfterHours != nil && *p.EscalateAfterHours <= *p.ReminderAfterHours {
+		return invalidPolicy("escalate_after_hours must be greater than reminder_after_hours")
+	}
+	return nil
+}
+
+func (s *Store) GetTeamPolicy(ctx context.Context, teamName string) (TeamPolicy, error) {
+	if err := teamExists(ctx, s.pool, teamName); err != nil {
+		return TeamPolicy{}, err
+	}
+	return loadPolicy(ctx, s.pool, teamName)
+}
+
+// SetTeamPolicy целиком заменяет политику команды. expectedVersion — версия,
+// которую видел клиент (0 — без проверки).
+func (s *Store) SetTeamPolicy(ctx context.Context, p TeamPolicy, expectedVersion int64) (TeamPolicy, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return TeamPolicy{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	p, err = savePolicy(ctx, tx, p, expectedVersion)
+	if err != nil {
+		return TeamPolicy{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return TeamPolicy{}, err
+	}
+	return p, nil
+}
+
+// updatePolicy меняет часть политики, не трогая остальные настройки.
+func (s *Store) updatePolicy(ctx context.Context, teamName string, change func(*TeamPolicy)) (TeamPolicy, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return TeamPolicy{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := teamExists(ctx, tx, teamName); err != nil {
+		return TeamPolicy{}, err
+	}
+	p, err := loadPolicyForUpdate(ctx, tx, teamName)
+	if err != nil {
+		return TeamPolicy{}, err
+	}
+	change(&p)
+	if p, err = savePolicy(ctx, tx, p, 0); err != nil {
+		return TeamPolicy{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return TeamPolicy{}, err
+	}
+	return p, nil
+}
+
+func savePolicy(ctx context.Context, tx querier, p TeamPolicy, expectedVersion int64) (TeamPolicy, error) {
+	if p.FallbackTeams == nil {
+		p.FallbackTeams = []string{}
+	}
+	if err := p.Validate(); err != nil {
+		return TeamPolicy{}, err
+	}
+	if err := teamExists(ctx, tx, p.TeamName); err != nil {
+		return TeamPolicy{}, err
+	}
+	for _, t := range p.FallbackTeams {
+		if err := teamExists(ctx, tx, t); errors.Is(err, ErrNotFound) {
+			return TeamPolicy{}, invalidPolicy("fallback team %q not found", t)
+		} else if err != nil {
+			return TeamPolicy{}, err
+		}
+	}
+
+	current, err := loadPolicyForUpdate(ctx, tx, p.TeamName)
+	if err != nil {
+		return TeamPolicy{}, err
+	}
+	if err := checkVersion(current.Version, expectedVersion); err != nil {
+		return TeamPolicy{}, err
+	}
+
+	err = tx.QueryRow(ctx, `
+INSERT INTO team_policies(team_name, reviewer_count, strategy, fallback_teams, min_level, min_level_count,
+  default_max_open_reviews, sla_reminder_hours, sla_escalate_hours, allow_merged_changes, version, updated_at)
+VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,now())
+ON CONFLICT (team_name) DO UPDATE SET
+  reviewer_count=EXCLUDED.reviewer_count, strategy=EXCLUDED.strategy, fallback_teams=EXCLUDED.fallback_teams,
+  min_level=EXCLUDED.min_level, min_level_count=EXCLUDED.min_level_count,
+  default_max_open_reviews=EXCLUDED.default_max_open_reviews,
+  sla_reminder_hours=EXCLUDED.sla_reminder_hours, sla_escalate_hours=EXCLUDED.sla_escalate_hours,
+  allow_merged_changes=EXCLUDED.allow_merged_changes, version=EXCLUDED.version, updated_at=EXCLUDED.updated_at
+RETURNING version, updated_at
+`, p.TeamName, p.ReviewerCount, p.Strategy, p.FallbackTeams, p.MinLevel, p.MinCount,
+		p.DefaultMaxOpenReviews, p.ReminderAfterHours, p.EscalateAfterHours, p.AllowMergedChanges, current.Version+1).
+		Scan(&p.Version, &p.UpdatedAt)
+	if err != nil {
+		return TeamPolicy{}, err
+	}
+
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return TeamPolicy{}, err
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO team_policy_history(team_name, version, policy) VALUES($1,$2,$3)`, p.TeamName, p.Version, raw); err != nil {
+		return TeamPolicy{}, err
+	}
+	return p, nil
+}
+
+func (s *Store) GetPolicyHistory(ctx context.Context, teamName string) ([]PolicyRevision, error) {
+	if err := teamExists(ctx, s.pool, teamName); err != nil {
+		return nil, err
+	}
+	rows, err := s.pool.Query(ctx, `SELECT version, policy, changed_at FROM team_policy_history WHERE team_name=$1 ORDER BY version`, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []PolicyRevision{}
+	for rows.Next() {
+		var r PolicyRevision
+		var raw []byte
+		if err := rows.Scan(&r.Version, &raw, &r.ChangedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &r.Policy); err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+	return res, rows.Err()
+}
+
+const policyColumns = `reviewer_count, strategy, fallback_teams, min_level, min_level_count,
+  default_max_open_reviews, sla_reminder_hours, sla_escalate_hours, allow_merged_changes, version, updated_at`
+
+// loadPolicy возвращает политику команды или политику по умолчанию (Version 0),
+// если команда её не настраивала.
+func loadPolicy(ctx context.Context, q querier, teamName string) (TeamPolicy, error) {
+	return scanPolicy(q.QueryRow(ctx, `SELECT `+policyColumns+` FROM team_policies WHERE team_name=$1`, teamName), teamName)
+}
+
+func loadPolicyForUpdate(ctx context.Context, q querier, teamName string) (TeamPolicy, error) {
+	return scanPolicy(q.QueryRow(ctx, `SELECT `+policyColumns+` FROM team_policies WHERE team_name=$1 FOR UPDATE`, teamName), teamName)
+}
+
+// loadPolicies догружает в policies политики команд teams, которых там ещё нет,
+// одним запросом; команды без своей политики получают политику по умолчанию.
+func loadPolicies(ctx context.Context, q querier, policies map[string]TeamPolicy, teams []string) error {
+	var missing []string
+	for _, t := range teams {
+		if _, ok := policies[t]; !ok {
+			policies[t] = DefaultPolicy(t)
+			missing = append(missing, t)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	rows, err := q.Query(ctx, `SELECT team_name, `+policyColumns+` FROM team_policies WHERE team_name = ANY($1)`, missing)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		p := DefaultPolicy("")
+		if err := rows.Scan(append([]any{&name}, policyFields(&p)...)...); err != nil {
+			return err
+		}
+		p.TeamName = name
+		policies[name] = p
+	}
+	return rows.Err()
+}
+
+// policyFields — поля политики в порядке policyColumns.
+func policyFields(p *TeamPolicy) []any {
+	return []any{&p.ReviewerCount, &p.Strategy, &p.FallbackTeams, &p.MinLevel, &p.MinCount,
+		&p.DefaultMaxOpenReviews, &p.ReminderAfterHours, &p.EscalateAfterHours, &p.AllowMergedChanges, &p.Version, &p.UpdatedAt}
+}
+
+func scanPolicy(row pgx.Row, teamName string) (TeamPolicy, error) {
+	p := DefaultPolicy(teamName)
+	err := row.Scan(policyFields(&p)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return DefaultPolicy(teamName), nil
+	}
+	if err != nil {
+		return TeamPolicy{}, err
+	}
+	return p, nil
+}
+
+func teamExists(ctx context.Context, q querier, teamName string) error {
+	var exists bool
+	if err := q.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name=$1)`, teamName).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: team %s", ErrNotFound, teamName)
+	}
+	return nil
+}
+
+// withPolicy применяет стратегию из политики команды, если она задана.
+func (sel selector) withPolicy(p TeamPolicy) selector {
+	if p.Strategy != nil {
+		sel.strategy = *p.Strategy
+	}
+	return sel
+}
+
+// applyCapacityDefaults подставляет участникам без личного лимита открытых ревью
+// лимит из политики их команды. policies — уже загруженные политики; недостающие
+// догружаются одним запросом и остаются в карте.
+func applyCapacityDefaults(ctx context.Context, q querier, members []member, policies map[string]TeamPolicy) error {
+	var teams []string
+	for _, m := range members {
+		if m.MaxOpenReviews == nil {
+			teams = append(teams, m.TeamName)
+		}
+	}
+	if err := loadPolicies(ctx, q, policies, teams); err != nil {
+		return err
+	}
+	for i := range members {
+		m := &members[i]
+		if m.MaxOpenReviews != nil {
+			continue
+		}
+		if p := policies[m.TeamName]; p.DefaultMaxOpenReviews != nil {
+			limit := *p.DefaultMaxOpenReviews
+			m.MaxOpenReviews = &limit
+		}
+	}
+	return nil
+}
+
+// collectCandidates собирает кандидатов сначала из команды primary, затем из
+// резервных команд политики по порядку — пока не наберётся want кандидатов,
+// из них need квалифицированных по правилу старшинства.
+func collectCandidates(ctx context.Context, q querier, p TeamPolicy, primary, authorID string, assigned map[string]bool, want, need int) ([]candidate, []CandidateExplanation, error) {
+	policies := map[string]TeamPolicy{p.TeamName: p}
+	return gatherCandidates(p, primary, want, need, func(team string) ([]candidate, []CandidateExplanation, error) {
+		members, err := loadMembers(ctx, q, team)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := applyCapacityDefaults(ctx, q, members, policies); err != nil {
+			return nil, nil, err
+		}
+		eligible, e := evaluate(members, authorID, assigned, time.Now())
+		found, err := loadCandidates(ctx, q, eligible, authorID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return found, e, nil
+	})
+}
+
+// gatherCandidates обходит команду primary и резервные команды политики и
+// берёт кандидатов из load; симуляция подставляет сюда загрузку из памяти.
+func gatherCandidates(p TeamPolicy, primary string, want, need int, load func(team string) ([]candidate, []CandidateExplanation, error)) ([]candidate, []CandidateExplanation, error) {
+	rule := p.Rule()
+	cands := []candidate{}
+	expl := []CandidateExplanation{}
+	qualified := 0
+	seen := map[string]bool{}
+	tier := 0
+	for _, team := range append([]string{primary}, p.FallbackTeams...) {
+		if seen[team] {
+			continue
+		}
+		seen[team] = true
+		if tier > 0 && len(cands) >= want && qualified >= need {
+			break
+		}
+
+		found, e, err := load(team)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range found {
+			found[i].Tier = tier
+			if rule.satisfiedBy(found[i].Level) {
+				qualified++
+			}
+		}
+		for i := range e {
+			e[i].Fallback = tier > 0
+		}
+		cands = append(cands, found...)
+		expl = append(expl, e...)
+		tier++
+	}
+	return cands, expl, nil
+}
@@ -0,0 +1,326 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// prReviewers и prTags — ревьюверы и теги PR с псевдонимом pr массивами в
+// одной строке, без отдельных запросов.
+const (
+	prReviewers = `COALESCE((SELECT array_agg(x.reviewer_id ORDER BY x.reviewer_id) FROM pr_reviewers x WHERE x.pull_request_id = pr.pull_request_id), '{}')`
+	prTags      = `COALESCE((SELECT array_agg(x.tag ORDER BY x.tag) FROM pull_request_tags x WHERE x.pull_request_id = pr.pull_request_id), '{}')`
+	prLevels    = `COALESCE((SELECT array_agg(u.level ORDER BY x.reviewer_id) FROM pr_reviewers x JOIN users u ON u.user_id = x.reviewer_id WHERE x.pull_request_id = pr.pull_request_id), '{}')`
+)
+
+// prSlot — PR, на котором заменяются ревьюверы; общий для всех замен на нём.
+type prSlot struct {
+	ID         string
+	AuthorID   string
+	AuthorTeam string
+	Tags       []string
+	// Levels — текущие ревьюверы и их уровни; меняется по мере подбора замен.
+	Levels map[string]string
+}
+
+const slotColumns = `pr.pull_request_id, pr.author_id, au.team_name, ` + prTags + `, ` + prReviewers + `, ` + prLevels
+
+func scanSlot(row pgx.Row, extra ...any) (*prSlot, error) {
+	pr := &prSlot{Levels: map[string]string{}}
+	var reviewers, levels []string
+	if err := row.Scan(append([]any{&pr.ID, &pr.AuthorID, &pr.AuthorTeam, &pr.Tags, &reviewers, &levels}, extra...)...); err != nil {
+		return nil, err
+	}
+	for i, id := range reviewers {
+		pr.Levels[id] = levels[i]
+	}
+	return pr, nil
+}
+
+// replacement — замена одного ревьювера. Если подобрать замену не удалось,
+// NewID пуст, а Err — доменная ошибка (ErrNoCandidate, ErrSeniority).
+type replacement struct {
+	PR      *prSlot
+	OldID   string
+	OldTeam string
+	NewID   string
+	Err     error
+
+	sel     selector
+	details assignmentDetails
+}
+
+// loadSlot загружает PR для замены reviewerID одним запросом. Строка PR
+// должна быть уже заблокирована: запрос после ожидания блокировки видит то,
+// что успели закоммитить конкуренты. oldTeam пуст, если ревьювер не назначен.
+func loadSlot(ctx context.Context, q querier, prID, reviewerID string) (*prSlot, string, error) {
+	var oldTeam string
+	pr, err := scanSlot(q.QueryRow(ctx, `
+SELECT `+slotColumns+`,
+  COALESCE((SELECT u.team_name FROM pr_reviewers x JOIN users u ON u.user_id = x.reviewer_id
+   WHERE x.pull_request_id = pr.pull_request_id AND x.reviewer_id = $2), '')
+FROM pull_requests pr
+JOIN users au ON au.user_id = pr.author_id
+WHERE pr.pull_request_id = $1
+`, prID, reviewerID), &oldTeam)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, "", ErrNotFound
+	}
+	return pr, oldTeam, err
+}
+
+// lockInactiveSlots блокирует открытые PR, которые ревьюят неактивные
+// пользователи из users, и возвращает замены по порядку PR и ревьюверов.
+func lockInactiveSlots(ctx context.Context, q querier, users []string) ([]replacement, error) {
+	const from = `
+FROM pr_reviewers r
+JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+JOIN users ou ON ou.user_id = r.reviewer_id
+JOIN users au ON au.user_id = pr.author_id
+WHERE pr.status = 'OPEN' AND NOT ou.is_active AND r.reviewer_id = ANY($1)
+`
+	// сначала блокировка, потом чтение — как в loadSlot
+	if _, err := q.Exec(ctx, `SELECT pr.pull_request_id`+from+`ORDER BY pr.pull_request_id FOR UPDATE OF pr`, users); err != nil {
+		return nil, err
+	}
+	rows, err := q.Query(ctx, `SELECT `+slotColumns+`, r.reviewer_id, ou.team_name`+from+`ORDER BY r.pull_request_id, r.reviewer_id`, users)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []replacement
+	var last *prSlot
+	for rows.Next() {
+		var r replacement
+		pr, err := scanSlot(rows, &r.OldID, &r.OldTeam)
+		if err != nil {
+			return nil, err
+		}
+		if last == nil || last.ID != pr.ID {
+			last = pr
+		}
+		r.PR = last
+		res = append(res, r)
+	}
+	return res, rows.Err()
+}
+
+// planReplacements подбирает замены по порядку так же, как если бы они делались
+// по одной: каждая следующая видит ревьюверов и нагрузку после предыдущих. Всё
+// нужное для выбора загружается заранее, пачкой запросов на все замены сразу.
+// policies — уже загруженные политики; недостающие догружаются в карту.
+func (s *Store) planReplacements(ctx context.Context, q querier, reps []replacement, policies map[string]TeamPolicy) error {
+	if len(reps) == 0 {
+		return nil
+	}
+	var authorTeams, teams, authors, ids []string
+	for _, r := range reps {
+		authorTeams = append(authorTeams, r.PR.AuthorTeam)
+		authors = append(authors, r.PR.AuthorID)
+	}
+	if err := loadPolicies(ctx, q, policies, authorTeams); err != nil {
+		return err
+	}
+	// замену ищут в команде заменяемого, затем в резервных командах автора
+	for _, r := range reps {
+		teams = append(teams, r.OldTeam)
+		teams = append(teams, policies[r.PR.AuthorTeam].FallbackTeams...)
+	}
+	teams = dedupe(teams)
+	members, err := queryMembers(ctx, q, `SELECT `+memberColumns+` FROM users u WHERE u.team_name = ANY($1) ORDER BY u.user_id`, teams)
+	if err != nil {
+		return err
+	}
+	if err := applyCapacityDefaults(ctx, q, members, policies); err != nil {
+		return err
+	}
+
+	byTeam := map[string][]member{}
+	open := map[string]int{}
+	for _, m := range members {
+		byTeam[m.TeamName] = append(byTeam[m.TeamName], m)
+		open[m.UserID] = m.OpenReviews
+		ids = append(ids, m.UserID)
+	}
+	memberIDs := slices.Clone(ids)
+	for _, r := range reps {
+		for id := range r.PR.Levels {
+			ids = append(ids, id)
+		}
+	}
+	skills, err := loadSkills(ctx, q, dedupe(ids))
+	if err != nil {
+		return err
+	}
+	paired, err := loadPairs(ctx, q, dedupe(authors), memberIDs)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range reps {
+		r := &reps[i]
+		pr := r.PR
+		policy := policies[pr.AuthorTeam]
+
+		// нельзя заменить единственного senior на junior: если без старого ревьювера
+		// правило команды нарушается, замена обязана быть квалифицированной
+		rule := policy.Rule()
+		remaining := 0
+		onPR := make(map[string]bool, len(pr.Levels))
+		for id, level := range pr.Levels {
+			onPR[id] = true
+			if id != r.OldID && rule.satisfiedBy(level) {
+				remaining++
+			}
+		}
+		need := 0
+		if rule.required(len(pr.Levels)) > remaining {
+			need = 1
+		}
+
+		cands, expl, _ := gatherCandidates(policy, r.OldTeam, 1, need, func(team string) ([]candidate, []CandidateExplanation, error) {
+			list := slices.Clone(byTeam[team])
+			for j := range list {
+				list[j].OpenReviews = open[list[j].UserID]
+			}
+			eligible, e := evaluate(list, pr.AuthorID, onPR, now)
+			found := make([]candidate, 0, len(eligible))
+			for _, m := range eligible {
+				found = append(found, newCandidate(m, skills[m.UserID], paired[pr.AuthorID][m.UserID]))
+			}
+			return found, e, nil
+		})
+		if len(cands) == 0 {
+			r.Err = ErrNoCandidate
+			continue
+		}
+
+		covered := map[string]bool{}
+		for id := range pr.Levels {
+			if id == r.OldID {
+				continue
+			}
+			for _, sk := range skills[id] {
+				if slices.Contains(pr.Tags, sk.Tag) {
+					covered[sk.Tag] = true
+				}
+			}
+		}
+
+		sel := s.selector(pr.ID, pr.Tags).withPolicy(policy)
+		picked, err := sel.selectReviewers(cands, covered, 1, need, rule)
+		if err != nil {
+			r.Err = err
+			continue
+		}
+		r.NewID, r.sel = picked[0], sel
+		r.details = assignmentDetails{Tags: pr.Tags, Rule: rule, Candidates: sel.explain(expl, cands, rule, picked)}
+
+		for _, c := range cands {
+			if c.UserID == r.NewID {
+				pr.Levels[r.NewID] = c.Level
+			}
+		}
+		delete(pr.Levels, r.OldID)
+		open[r.OldID]--
+		open[r.NewID]++
+		if paired[pr.AuthorID] == nil {
+			paired[pr.AuthorID] = map[string]time.Time{}
+		}
+		paired[pr.AuthorID][r.NewID] = now
+	}
+	return nil
+}
+
+// applyReplacements записывает подобранные замены — историю назначений,
+// ревьюверов, версии PR и события — постоянным числом запросов, сколько бы
+// замен ни было. Неудавшиеся замены пропускаются.
+func applyReplacements(ctx context.Context, q querier, reps []replacement, kind, reason string) error {
+	var prs, olds, news, strategies, details []string
+	var seeds []int64
+	var events []Event
+	for _, r := range reps {
+		if r.NewID == "" {
+			continue
+		}
+		raw, err := json.Marshal(r.details)
+		if err != nil {
+			return err
+		}
+		prs, olds, news = append(prs, r.PR.ID), append(olds, r.OldID), append(news, r.NewID)
+		strategies, seeds, details = append(strategies, r.sel.strategy), append(seeds, r.sel.seed), append(details, string(raw))
+		events = append(events, Event{
+			Type:          EventPRReviewersChanged,
+			TeamName:      r.PR.AuthorTeam,
+			UserID:        r.PR.AuthorID,
+			PullRequestID: r.PR.ID,
+			RelatedUsers:  []string{r.OldID, r.NewID},
+			Payload: map[string]any{
+				"kind":            kind,
+				"old_reviewer_id": r.OldID,
+				"new_reviewer_id": r.NewID,
+				"reason":          reason,
+			},
+		})
+	}
+	if len(prs) == 0 {
+		return nil
+	}
+
+	rows, err := q.Query(ctx, `
+INSERT INTO pr_assignments(pull_request_id, kind, strategy, algorithm, seed, replaced_reviewer_id, reason, reviewers, details)
+SELECT c.pr, $1, c.strategy, $2, c.seed, c.old_id, NULLIF($3,''), ARRAY[c.new_id], c.details::jsonb
+FROM unnest($4::text[], $5::text[], $6::bigint[], $7::text[], $8::text[], $9::text[])
+  WITH ORDINALITY AS c(pr, strategy, seed, old_id, new_id, details, n)
+ORDER BY c.n
+RETURNING id, pull_request_id, replaced_reviewer_id
+`, kind, AlgorithmVersion, reason, prs, strategies, seeds, olds, news, details)
+	if err != nil {
+		return err
+	}
+	// заменяемый ревьювер на PR один, поэтому пара (PR, заменяемый) уникальна
+	assignment := map[[2]string]int64{}
+	for rows.Next() {
+		var id int64
+		var pr, old string
+		if err := rows.Scan(&id, &pr, &old); err != nil {
+			rows.Close()
+			return err
+		}
+		assignment[[2]string{pr, old}] = id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	ids := make([]int64, len(prs))
+	for i := range prs {
+		ids[i] = assignment[[2]string{prs[i], olds[i]}]
+	}
+
+	// новый ревьювер не был назначен на PR, а заменяемые в пачке неактивны и
+	// новыми стать не могут, поэтому удаление и вставка не задевают одну строку
+	if _, err := q.Exec(ctx, `
+WITH c AS (
+  SELECT * FROM unnest($1::text[], $2::text[], $3::text[], $4::bigint[]) AS c(pr, old_id, new_id, assignment_id)
+), removed AS (
+  DELETE FROM pr_reviewers r USING c WHERE r.pull_request_id = c.pr AND r.reviewer_id = c.old_id
+), added AS (
+  INSERT INTO pr_reviewers(pull_request_id, reviewer_id, assignment_id) SELECT pr, new_id, assignment_id FROM c
+)
+UPDATE pull_requests p SET version = p.version + n.changes
+FROM (SELECT pr, COUNT(*) AS changes FROM c GROUP BY pr) n
+WHERE p.pull_request_id = n.pr
+`, prs, olds, news, ids); err != nil {
+		return err
+	}
+
+	return insertEvents(ctx, q, events)
+}
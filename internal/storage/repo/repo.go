@@ -3,8 +3,9 @@ package repo
 import (
 	"context"
 	"errors"
-	"fmt"
 	"math/rand"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -12,14 +13,17 @@ import (
 )
 
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID   string  `json:"user_id"`
+	Username string  `json:"username"`
+	IsActive bool    `json:"is_active"`
+	Level    string  `json:"level,omitempty"`
+	Skills   []Skill `json:"skills,omitempty"`
 }
 
 type Team struct {
 	TeamName string       `json:"team_name"`
 	Members  []TeamMember `json:"members"`
+	Version  int64        `json:"version,omitempty"`
 }
 
 type User struct {
@@ -27,6 +31,10 @@ type User struct {
 	Username string `json:"username"`
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
+	Level    string `json:"level"`
+	// MaxOpenReviews — сколько открытых ревью можно назначить одновременно (nil — без ограничения).
+	MaxOpenReviews *int       `json:"max_open_reviews,omitempty"`
+	AwayUntil      *time.Time `json:"away_until,omitempty"`
 }
 
 type PullRequest struct {
@@ -35,8 +43,10 @@ type PullRequest struct {
 	AuthorID          string     `json:"author_id"`
 	Status            string     `json:"status"`
 	AssignedReviewers []string   `json:"assigned_reviewers"`
+	RequiredTags      []string   `json:"required_tags,omitempty"`
 	CreatedAt         time.Time  `json:"createdAt"`
 	MergedAt          *time.Time `json:"mergedAt"`
+	Version           int64      `json:"version"`
 }
 
 type PullRequestShort struct {
@@ -44,144 +54,322 @@ type PullRequestShort struct {
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
 	Status          string `json:"status"`
+	// ReviewAgeSeconds — рабочее время ревьювера с момента назначения до слияния (или до сейчас).
+	AssignedAt       *time.Time `json:"assigned_at,omitempty"`
+	ReviewAgeSeconds *int64     `json:"review_age_seconds,omitempty"`
+	ReviewAge        string     `json:"review_age,omitempty"`
 }
 
-var (
-	ErrNotFound    = errors.New("not found")
-	ErrPRExists    = errors.New("pr exists")
-	ErrPRMerged    = errors.New("pr merged")
-	ErrNotAssigned = errors.New("not assigned")
-	ErrNoCandidate = errors.New("no candidate")
-)
+type Options struct {
+	// Strategy — стратегия выбора ревьюверов среди равных по навыкам кандидатов.
+	Strategy string
+	// Mode: random — seed каждого назначения случайный, deterministic — всегда Seed.
+	Mode string
+	Seed int64
+}
 
 type Store struct {
 	pool *pgxpool.Pool
+	mu   sync.Mutex
 	rnd  *rand.Rand
+	opts Options
+}
+
+func New(pool *pgxpool.Pool, opts Options) *Store {
+	if opts.Strategy == "" {
+		opts.Strategy = StrategyRandom
+	}
+	if opts.Mode == "" {
+		opts.Mode = ModeRandom
+	}
+	return &Store{pool: pool, rnd: rand.New(rand.NewSource(time.Now().UnixNano())), opts: opts}
 }
 
-func New(pool *pgxpool.Pool) *Store {
-	return &Store{pool: pool, rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+func (s *Store) selector(prID string, tags []string) selector {
+	seed := s.opts.Seed
+	if s.opts.Mode == ModeRandom {
+		s.mu.Lock()
+		seed = s.rnd.Int63()
+		s.mu.Unlock()
+	}
+	return selector{seed: seed, prID: prID, strategy: s.opts.Strategy, tags: tags}
 }
 
-func (s *Store) CreateTeam(ctx context.Context, t Team) error {
+// CreateTeam создаёт команду или обновляет её состав и возвращает новую версию команды.
+func (s *Store) CreateTeam(ctx context.Context, t Team, expectedVersion int64) (int64, error) {
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer tx.Rollback(ctx)
 
-	if _, err := tx.Exec(
+	version, err := upsertTeam(ctx, tx, t, expectedVersion)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// upsertTeam создаёт команду или обновляет её состав в транзакции вызывающего
+// и возвращает новую версию команды.
+func upsertTeam(ctx context.Context, q querier, t Team, expectedVersion int64) (int64, error) {
+	cmd, err := q.Exec(
 		ctx,
 		`INSERT INTO teams(team_name) VALUES($1) ON CONFLICT DO NOTHING`,
-		t.TeamName); err != nil {
-		return err
+		t.TeamName)
+	if err != nil {
+		return 0, err
+	}
+	if cmd.RowsAffected() == 0 {
+		if err := lockTeam(ctx, q, t.TeamName, expectedVersion); err != nil {
+			return 0, err
+		}
+	} else if expectedVersion != 0 {
+		// If-Match для ещё не существовавшей команды не может совпасть
+		return 0, ErrVersionMismatch
+	}
+
+	ids := make([]string, len(t.Members))
+	for i, m := range t.Members {
+		ids[i] = m.UserID
+	}
+	// участники, переходящие из другой команды, меняют и её состав
+	if _, err := q.Exec(ctx, `
+UPDATE teams SET version = version + 1
+WHERE team_name <> $2 AND team_name IN (SELECT team_name FROM users WHERE user_id = ANY($1))
+`, ids, t.TeamName); err != nil {
+		return 0, err
 	}
 
 	for _, m := range t.Members {
-		if _, err := tx.Exec(
+		// пустой level не затирает уже сохранённый уровень
+		var level *string
+		if m.Level != "" {
+			level = &m.Level
+		}
+		if _, err := q.Exec(
 			ctx,
-			`INSERT INTO users(user_id, username, team_name, is_active) VALUES($1,$2,$3,$4)
-			 ON CONFLICT (user_id) DO UPDATE SET username=EXCLUDED.username, team_name=EXCLUDED.team_name, is_active=EXCLUDED.is_active`,
-			m.UserID, m.Username, t.TeamName, m.IsActive); err != nil {
-			return err
+			`INSERT INTO users(user_id, username, team_name, is_active, level) VALUES($1,$2,$3,$4,COALESCE($5,'middle'))
+			 ON CONFLICT (user_id) DO UPDATE SET username=EXCLUDED.username, team_name=EXCLUDED.team_name, is_active=EXCLUDED.is_active,
+			 level=COALESCE($5, users.level)`,
+			m.UserID, m.Username, t.TeamName, m.IsActive, level); err != nil {
+			return 0, err
+		}
+		// навыки перезаписываются, только если клиент их передал
+		if m.Skills != nil {
+			if err := replaceSkills(ctx, q, m.UserID, m.Skills); err != nil {
+				return 0, err
+			}
 		}
 	}
 
-	return tx.Commit(ctx)
+	version := int64(1)
+	if cmd.RowsAffected() == 0 {
+		if version, err = bumpTeamVersion(ctx, q, t.TeamName); err != nil {
+			return 0, err
+		}
+	}
+	return version, nil
 }
 
+// GetTeam читает состав и версию команды из одного снимка, чтобы ETag
+// ответа соответствовал именно этому составу, а не изменённому параллельно.
 func (s *Store) GetTeam(ctx context.Context, teamName string) (Team, error) {
-	rows, err := s.pool.Query(ctx, `SELECT user_id, username, is_active FROM users WHERE team_name=$1 ORDER BY user_id`, teamName)
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return Team{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+SELECT t.version, u.user_id, u.username, u.is_active, u.level
+FROM teams t JOIN users u ON u.team_name = t.team_name
+WHERE t.team_name=$1
+ORDER BY u.user_id
+`, teamName)
 	if err != nil {
 		return Team{}, err
 	}
 	defer rows.Close()
 
+	var version int64
 	members := make([]TeamMember, 0)
 	for rows.Next() {
 		var m TeamMember
-		if err := rows.Scan(&m.UserID, &m.Username, &m.IsActive); err != nil {
+		if err := rows.Scan(&version, &m.UserID, &m.Username, &m.IsActive, &m.Level); err != nil {
 			return Team{}, err
 		}
 		members = append(members, m)
 	}
+	if err := rows.Err(); err != nil {
+		return Team{}, err
+	}
 	if len(members) == 0 {
 		return Team{}, ErrNotFound
 	}
-	return Team{TeamName: teamName, Members: members}, nil
+
+	ids := make([]string, len(members))
+	for i, m := range members {
+		ids[i] = m.UserID
+	}
+	skills, err := loadSkills(ctx, tx, ids)
+	if err != nil {
+		return Team{}, err
+	}
+	for i := range members {
+		members[i].Skills = skills[members[i].UserID]
+	}
+	return Team{TeamName: teamName, Members: members, Version: version}, nil
 }
 
 func (s *Store) SetUserActive(ctx context.Context, userID string, isActive bool) (User, error) {
-	cmd, err := s.pool.Exec(ctx, `UPDATE users SET is_active=$1 WHERE user_id=$2`, isActive, userID)
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return User{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var was bool
+	var team string
+	if err := tx.QueryRow(ctx, `SELECT is_active, team_name FROM users WHERE user_id=$1 FOR UPDATE`, userID).Scan(&was, &team); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return User{}, ErrNotFound
+		}
+		return User{}, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE users SET is_active=$1 WHERE user_id=$2`, isActive, userID); err != nil {
+		return User{}, err
+	}
+	if was != isActive {
+		if _, err := bumpTeamVersion(ctx, tx, team); err != nil {
+			return User{}, err
+		}
+		if _, err := insertEvent(ctx, tx, Event{
+			Type:     EventUserActiveChanged,
+			TeamName: team,
+			UserID:   userID,
+			Payload:  map[string]any{"is_active": isActive},
+		}); err != nil {
+			return User{}, err
+		}
+	}
+
+	u, err := getUser(ctx, tx, userID)
+	if err != nil {
+		return User{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return User{}, err
+	}
+	return u, nil
+}
+
+// SetAvailability задаёт отсутствие до awayUntil и лимит открытых ревью; nil снимает ограничение.
+func (s *Store) SetAvailability(ctx context.Context, userID string, awayUntil *time.Time, maxOpenReviews *int) (User, error) {
+	cmd, err := s.pool.Exec(ctx, `UPDATE users SET away_until=$1, max_open_reviews=$2 WHERE user_id=$3`, awayUntil, maxOpenReviews, userID)
 	if err != nil {
 		return User{}, err
 	}
 	if cmd.RowsAffected() == 0 {
 		return User{}, ErrNotFound
 	}
+	return getUser(ctx, s.pool, userID)
+}
+
+func getUser(ctx context.Context, q querier, userID string) (User, error) {
 	var u User
-	if err := s.pool.QueryRow(ctx, `SELECT user_id, username, team_name, is_active FROM users WHERE user_id=$1`, userID).Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+	err := q.QueryRow(ctx, `SELECT user_id, username, team_name, is_active, level, max_open_reviews, away_until FROM users WHERE user_id=$1`, userID).
+		Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive, &u.Level, &u.MaxOpenReviews, &u.AwayUntil)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return User{}, ErrNotFound
+	}
+	if err != nil {
 		return User{}, err
 	}
 	return u, nil
 }
 
-func (s *Store) CreatePR(ctx context.Context, prID, prName, authorID string) (PullRequest, error) {
+// CreatePR создаёт PR с ревьюверами и возвращает объяснение именно этого
+// назначения, собранное в той же транзакции.
+func (s *Store) CreatePR(ctx context.Context, prID, prName, authorID string, tags []string) (PullRequest, Explanation, error) {
 	// start tx
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		return PullRequest{}, err
+		return PullRequest{}, Explanation{}, err
 	}
 	defer tx.Rollback(ctx)
 
 	var teamName string
 	if err := tx.QueryRow(ctx, `SELECT team_name FROM users WHERE user_id=$1`, authorID).Scan(&teamName); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return PullRequest{}, ErrNotFound
+			return PullRequest{}, Explanation{}, ErrNotFound
 		}
-		return PullRequest{}, err
+		return PullRequest{}, Explanation{}, err
 	}
 
 	var existing string
 	if err := tx.QueryRow(ctx, `SELECT pull_request_id FROM pull_requests WHERE pull_request_id=$1`, prID).Scan(&existing); err == nil {
-		return PullRequest{}, ErrPRExists
+		return PullRequest{}, Explanation{}, ErrPRExists
 	} else if !errors.Is(err, pgx.ErrNoRows) {
-		return PullRequest{}, err
+		return PullRequest{}, Explanation{}, err
 	}
 
-	rows, err := tx.Query(ctx, `SELECT user_id FROM users WHERE team_name=$1 AND is_active=true AND user_id<>$2`, teamName, authorID)
+	policy, err := loadPolicy(ctx, tx, teamName)
 	if err != nil {
-		return PullRequest{}, err
+		return PullRequest{}, Explanation{}, err
 	}
-	defer rows.Close()
+	rule := policy.Rule()
 
-	candidates := make([]string, 0)
-	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
-			return PullRequest{}, err
-		}
-		candidates = append(candidates, id)
+	candidates, expl, err := collectCandidates(ctx, tx, policy, teamName, authorID, nil, policy.ReviewerCount, rule.required(policy.ReviewerCount))
+	if err != nil {
+		return PullRequest{}, Explanation{}, err
 	}
 
-	s.rnd.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
-	assign := candidates
-	if len(assign) > 2 {
-		assign = assign[:2]
+	tags = normalizeTags(tags)
+	total := min(policy.ReviewerCount, len(candidates))
+	sel := s.selector(prID, tags).withPolicy(policy)
+	assign, err := sel.selectReviewers(candidates, nil, total, rule.required(total), rule)
+	if err != nil {
+		return PullRequest{}, Explanation{}, err
 	}
 
 	if _, err := tx.Exec(ctx, `INSERT INTO pull_requests(pull_request_id, pull_request_name, author_id, status, created_at) VALUES($1,$2,$3,'OPEN',now())`, prID, prName, authorID); err != nil {
-		return PullRequest{}, err
+		return PullRequest{}, Explanation{}, err
+	}
+
+	for _, t := range tags {
+		if _, err := tx.Exec(ctx, `INSERT INTO pull_request_tags(pull_request_id, tag) VALUES($1,$2)`, prID, t); err != nil {
+			return PullRequest{}, Explanation{}, err
+		}
 	}
 
+	details := assignmentDetails{Tags: tags, Rule: rule, Candidates: sel.explain(expl, candidates, rule, assign)}
+	assignment, err := recordAssignment(ctx, tx, sel, assignmentRecord{Kind: AssignmentCreate, Reviewers: assign, Details: details})
+	if err != nil {
+		return PullRequest{}, Explanation{}, err
+	}
 	for _, a := range assign {
-		if _, err := tx.Exec(ctx, `INSERT INTO pr_reviewers(pull_request_id, reviewer_id) VALUES($1,$2)`, prID, a); err != nil {
-			return PullRequest{}, err
+		if _, err := tx.Exec(ctx, `INSERT INTO pr_reviewers(pull_request_id, reviewer_id, assignment_id) VALUES($1,$2,$3)`, prID, a, assignment.ID); err != nil {
+			return PullRequest{}, Explanation{}, err
 		}
 	}
 
+	if _, err := insertEvent(ctx, tx, Event{
+		Type:          EventPRCreated,
+		TeamName:      teamName,
+		UserID:        authorID,
+		PullRequestID: prID,
+		RelatedUsers:  assign,
+		Payload:       map[string]any{"pull_request_name": prName, "assigned_reviewers": assign},
+	}); err != nil {
+		return PullRequest{}, Explanation{}, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
-		return PullRequest{}, err
+		return PullRequest{}, Explanation{}, err
 	}
 
 	pr := PullRequest{
@@ -190,56 +378,72 @@ func (s *Store) CreatePR(ctx context.Context, prID, prName, authorID string) (Pu
 		AuthorID:          authorID,
 		Status:            "OPEN",
 		AssignedReviewers: assign,
+		RequiredTags:      tags,
+		Version:           1,
 	}
-	return pr, nil
+	return pr, explanationOf(assignment, details), nil
+}
+
+func (s *Store) GetPR(ctx context.Context, prID string) (PullRequest, error) {
+	return getPR(ctx, s.pool, prID)
+}
+
+const prColumns = `pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at, pr.version, ` +
+	prReviewers + `, ` + prTags
+
+func scanPR(row pgx.Row, extra ...any) (PullRequest, error) {
+	var pr PullRequest
+	err := row.Scan(append([]any{&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &pr.Version,
+		&pr.AssignedReviewers, &pr.RequiredTags}, extra...)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return PullRequest{}, ErrNotFound
+	}
+	return pr, err
 }
 
-func (s *Store) MergePR(ctx context.Context, prID string) (PullRequest, error) {
+func getPR(ctx context.Context, q querier, prID string) (PullRequest, error) {
+	return scanPR(q.QueryRow(ctx, `SELECT `+prColumns+` FROM pull_requests pr WHERE pr.pull_request_id=$1`, prID))
+}
+
+func (s *Store) MergePR(ctx context.Context, prID string, expectedVersion int64) (PullRequest, error) {
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return PullRequest{}, err
 	}
 	defer tx.Rollback(ctx)
 
-	var status string
-	if err := tx.QueryRow(ctx, `SELECT status FROM pull_requests WHERE pull_request_id=$1`, prID).Scan(&status); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return PullRequest{}, ErrNotFound
-		}
+	status, version, err := lockPR(ctx, tx, prID)
+	if err != nil {
 		return PullRequest{}, err
 	}
 
+	// повторный merge идемпотентен и возвращает текущее состояние независимо от версии
 	if status == "MERGED" {
-		var pr PullRequest
-		if err := tx.QueryRow(ctx, `SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at FROM pull_requests WHERE pull_request_id=$1`, prID).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt); err != nil {
-			return PullRequest{}, err
-		}
-		rows, _ := tx.Query(ctx, `SELECT reviewer_id FROM pr_reviewers WHERE pull_request_id=$1`, prID)
-		defer rows.Close()
-		pr.AssignedReviewers = []string{}
-		for rows.Next() {
-			var r string
-			_ = rows.Scan(&r)
-			pr.AssignedReviewers = append(pr.AssignedReviewers, r)
-		}
-		return pr, nil
+		return getPR(ctx, tx, prID)
 	}
-
-	if _, err := tx.Exec(ctx, `UPDATE pull_requests SET status='MERGED', merged_at=now() WHERE pull_request_id=$1`, prID); err != nil {
+	if err := checkVersion(version, expectedVersion); err != nil {
 		return PullRequest{}, err
 	}
 
-	var pr PullRequest
-	if err := tx.QueryRow(ctx, `SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at FROM pull_requests WHERE pull_request_id=$1`, prID).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt); err != nil {
+	// PR, его ревьюверы и команда автора возвращаются тем же запросом, что и слияние
+	var team string
+	pr, err := scanPR(tx.QueryRow(ctx, `
+UPDATE pull_requests pr SET status='MERGED', merged_at=now(), version = pr.version + 1
+WHERE pr.pull_request_id=$1
+RETURNING `+prColumns+`, (SELECT u.team_name FROM users u WHERE u.user_id = pr.author_id)
+`, prID), &team)
+	if err != nil {
 		return PullRequest{}, err
 	}
-	rows, _ := tx.Query(ctx, `SELECT reviewer_id FROM pr_reviewers WHERE pull_request_id=$1`, prID)
-	defer rows.Close()
-	pr.AssignedReviewers = []string{}
-	for rows.Next() {
-		var r string
-		_ = rows.Scan(&r)
-		pr.AssignedReviewers = append(pr.AssignedReviewers, r)
+	if _, err := insertEvent(ctx, tx, Event{
+		Type:          EventPRMerged,
+		TeamName:      team,
+		UserID:        pr.AuthorID,
+		PullRequestID: prID,
+		RelatedUsers:  pr.AssignedReviewers,
+		Payload:       map[string]any{"merged_at": pr.MergedAt, "assigned_reviewers": pr.AssignedReviewers},
+	}); err != nil {
+		return PullRequest{}, err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -249,78 +453,73 @@ func (s *Store) MergePR(ctx context.Context, prID string) (PullRequest, error) {
 	return pr, nil
 }
 
-func (s *Store) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (string, error) {
+func (s *Store) ReassignReviewer(ctx context.Context, prID, oldReviewerID string, expectedVersion int64) (string, error) {
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return "", err
 	}
 	defer tx.Rollback(ctx)
 
-	var status string
-	if err := tx.QueryRow(ctx, `SELECT status FROM pull_requests WHERE pull_request_id=$1`, prID).Scan(&status); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return "", ErrNotFound
-		}
+	newID, err := s.reassign(ctx, tx, prID, oldReviewerID, expectedVersion, AssignmentReassign, "")
+	if err != nil {
 		return "", err
 	}
-	if status == "MERGED" {
-		return "", ErrPRMerged
-	}
 
-	var assigned bool
-	if err := tx.QueryRow(ctx, `SELECT true FROM pr_reviewers WHERE pull_request_id=$1 AND reviewer_id=$2`, prID, oldReviewerID).Scan(&assigned); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return "", ErrNotAssigned
-		}
+	if err := tx.Commit(ctx); err != nil {
 		return "", err
 	}
 
-	var team string
-	if err := tx.QueryRow(ctx, `SELECT team_name FROM users WHERE user_id=$1`, oldReviewerID).Scan(&team); err != nil {
+	return newID, nil
+}
+
+// reassign заменяет ревьювера внутри переданной транзакции; kind и reason
+// сохраняются в истории назначений.
+func (s *Store) reassign(ctx context.Context, tx querier, prID, oldReviewerID string, expectedVersion int64, kind, reason string) (string, error) {
+	// блокировка строки PR упорядочивает параллельные переназначения
+	status, version, err := lockPR(ctx, tx, prID)
+	if err != nil {
 		return "", err
 	}
-
-	var author string
-	if err := tx.QueryRow(ctx, `SELECT author_id FROM pull_requests WHERE pull_request_id=$1`, prID).Scan(&author); err != nil {
+	pr, oldTeam, err := loadSlot(ctx, tx, prID, oldReviewerID)
+	if err != nil {
 		return "", err
 	}
-
-	rows, err := tx.Query(ctx, `SELECT user_id FROM users WHERE team_name=$1 AND is_active=true AND user_id<>$2 AND user_id<>$3 AND user_id NOT IN (SELECT reviewer_id FROM pr_reviewers WHERE pull_request_id=$4)`, team, oldReviewerID, author, prID)
+	policy, err := loadPolicy(ctx, tx, pr.AuthorTeam)
 	if err != nil {
 		return "", err
 	}
-	defer rows.Close()
-
-	candidates := []string{}
-	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
-			return "", err
-		}
-		candidates = append(candidates, id)
+	if status == "MERGED" && !policy.AllowMergedChanges {
+		return "", ErrPRMerged
 	}
-	if len(candidates) == 0 {
-		return "", ErrNoCandidate
+	if err := checkVersion(version, expectedVersion); err != nil {
+		return "", err
+	}
+	if oldTeam == "" {
+		return "", ErrNotAssigned
 	}
 
-	newID := candidates[s.rnd.Intn(len(candidates))]
-
-	if _, err := tx.Exec(ctx, `DELETE FROM pr_reviewers WHERE pull_request_id=$1 AND reviewer_id=$2`, prID, oldReviewerID); err != nil {
+	reps := []replacement{{PR: pr, OldID: oldReviewerID, OldTeam: oldTeam}}
+	if err := s.planReplacements(ctx, tx, reps, map[string]TeamPolicy{policy.TeamName: policy}); err != nil {
 		return "", err
 	}
-	if _, err := tx.Exec(ctx, `INSERT INTO pr_reviewers(pull_request_id, reviewer_id) VALUES($1,$2)`, prID, newID); err != nil {
-		return "", err
+	if reps[0].Err != nil {
+		return "", reps[0].Err
 	}
-
-	if err := tx.Commit(ctx); err != nil {
+	if err := applyReplacements(ctx, tx, reps, kind, reason); err != nil {
 		return "", err
 	}
-
-	return newID, nil
+	return reps[0].NewID, nil
 }
 
 func (s *Store) GetPRsForReviewer(ctx context.Context, userID string) ([]PullRequestShort, error) {
-	rows, err := s.pool.Query(ctx, `SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status FROM pull_requests pr JOIN pr_reviewers r ON r.pull_request_id = pr.pull_request_id WHERE r.reviewer_id = $1`, userID)
+	schedules, err := loadSchedules(ctx, s.pool, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	sch := schedules[userID]
+	now := time.Now()
+
+	rows, err := s.pool.Query(ctx, `SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, r.assigned_at, pr.merged_at FROM pull_requests pr JOIN pr_reviewers r ON r.pull_request_id = pr.pull_request_id WHERE r.reviewer_id = $1`, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -329,24 +528,42 @@ func (s *Store) GetPRsForReviewer(ctx context.Context, userID string) ([]PullReq
 	res := []PullRequestShort{}
 	for rows.Next() {
 		var p PullRequestShort
-		if err := rows.Scan(&p.PullRequestID, &p.PullRequestName, &p.AuthorID, &p.Status); err != nil {
+		var assignedAt time.Time
+		var mergedAt *time.Time
+		if err := rows.Scan(&p.PullRequestID, &p.PullRequestName, &p.AuthorID, &p.Status, &assignedAt, &mergedAt); err != nil {
 			return nil, err
 		}
+		end := now
+		if mergedAt != nil {
+			end = *mergedAt
+		}
+		age := sch.Between(assignedAt, end)
+		secs := int64(age.Seconds())
+		p.AssignedAt, p.ReviewAgeSeconds, p.ReviewAge = &assignedAt, &secs, age.Round(time.Minute).String()
 		res = append(res, p)
 	}
-	return res, nil
+	return res, rows.Err()
 }
 
 type ReviewerStat struct {
-	UserID string `json:"user_id"`
-	Count  int64  `json:"review_count"`
+	UserID    string `json:"user_id"`
+	Count     int64  `json:"review_count"`
+	OpenCount int64  `json:"open_review_count"`
+	// OldestOpenAgeSeconds — рабочее время самого старого открытого ревью.
+	OldestOpenAgeSeconds int64 `json:"oldest_open_review_age_seconds"`
+	DeclineCount         int64 `json:"decline_count"`
+	// DeclinesByReason — отказы от назначений по причинам (busy, no_context, ...).
+	DeclinesByReason map[string]int64 `json:"declines_by_reason"`
 }
 
 func (s *Store) GetReviewerAssignmentStats(ctx context.Context) ([]ReviewerStat, error) {
 	rows, err := s.pool.Query(ctx, `
-SELECT reviewer_id, COUNT(*) as cnt
-FROM pr_reviewers
-GROUP BY reviewer_id
+SELECT r.reviewer_id, COUNT(*) as cnt,
+  COUNT(*) FILTER (WHERE pr.status = 'OPEN'),
+  MIN(r.assigned_at) FILTER (WHERE pr.status = 'OPEN')
+FROM pr_reviewers r
+JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+GROUP BY r.reviewer_id
 ORDER BY cnt DESC
 `)
 	if err != nil {
@@ -355,13 +572,61 @@ ORDER BY cnt DESC
 	defer rows.Close()
 
 	res := make([]ReviewerStat, 0)
+	oldest := map[string]time.Time{}
+	ids := []string{}
 	for rows.Next() {
 		var r ReviewerStat
-		if err := rows.Scan(&r.UserID, &r.Count); err != nil {
+		var first *time.Time
+		if err := rows.Scan(&r.UserID, &r.Count, &r.OpenCount, &first); err != nil {
 			return nil, err
 		}
+		if first != nil {
+			oldest[r.UserID] = *first
+		}
+		ids = append(ids, r.UserID)
 		res = append(res, r)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	schedules, err := loadSchedules(ctx, s.pool, ids)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range res {
+		if at, ok := oldest[res[i].UserID]; ok {
+			res[i].OldestOpenAgeSeconds = int64(schedules[res[i].UserID].Between(at, now).Seconds())
+		}
+	}
+
+	declines, err := loadDeclines(ctx, s.pool)
+	if err != nil {
+		return nil, err
+	}
+	// отказавшийся мог не остаться ни на одном PR, но в статистике он нужен
+	listed := map[string]bool{}
+	for _, r := range res {
+		listed[r.UserID] = true
+	}
+	extra := []string{}
+	for id := range declines {
+		if !listed[id] {
+			extra = append(extra, id)
+		}
+	}
+	sort.Strings(extra)
+	for _, id := range extra {
+		res = append(res, ReviewerStat{UserID: id})
+	}
+	for i := range res {
+		res[i].DeclinesByReason = map[string]int64{}
+		for reason, n := range declines[res[i].UserID] {
+			res[i].DeclinesByReason[reason] = n
+			res[i].DeclineCount += n
+		}
+	}
 	return res, nil
 }
 
@@ -370,93 +635,131 @@ type BulkDeactivateResult struct {
 	DeactivatedUsers   []string          `json:"deactivated_users"`
 	ReassignedPRsCount int               `json:"reassigned_prs_count"`
 	ReassignFailures   map[string]string `json:"reassign_failures"` // prID -> error
+	Version            int64             `json:"version"`
+	// DryRun — изменения посчитаны, но транзакция откатана.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+func (s *Store) BulkDeactivateTeam(ctx context.Context, teamName string, expectedVersion int64) (BulkDeactivateResult, error) {
+	return s.bulkDeactivate(ctx, teamName, expectedVersion, false)
+}
+
+// PreviewDeactivateTeam выполняет деактивацию в транзакции и откатывает её:
+// результат совпадает с тем, что сделал бы BulkDeactivateTeam прямо сейчас.
+func (s *Store) PreviewDeactivateTeam(ctx context.Context, teamName string, expectedVersion int64) (BulkDeactivateResult, error) {
+	return s.bulkDeactivate(ctx, teamName, expectedVersion, true)
 }
 
-func (s *Store) BulkDeactivateTeam(ctx context.Context, teamName string) (BulkDeactivateResult, error) {
-	res := BulkDeactivateResult{TeamName: teamName, ReassignFailures: map[string]string{}}
+func (s *Store) bulkDeactivate(ctx context.Context, teamName string, expectedVersion int64, dryRun bool) (BulkDeactivateResult, error) {
+	res := BulkDeactivateResult{TeamName: teamName, ReassignFailures: map[string]string{}, DryRun: dryRun}
 
-	// 1) Получить список пользователей команды
-	rows, err := s.pool.Query(ctx, `SELECT user_id FROM users WHERE team_name=$1`, teamName)
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return res, err
 	}
-	defer rows.Close()
+	defer tx.Rollback(ctx)
 
+	if err := lockTeam(ctx, tx, teamName, expectedVersion); err != nil {
+		return res, err
+	}
+
+	// деактивация, список участников и версии команды до и после — одним
+	// запросом; основной SELECT видит участников и версию до изменений
+	rows, err := tx.Query(ctx, `
+WITH changed AS (
+  UPDATE users SET is_active = false WHERE team_name=$1 AND is_active RETURNING user_id
+), bumped AS (
+  UPDATE teams SET version = version + 1 WHERE team_name=$1 AND EXISTS (SELECT 1 FROM changed) RETURNING version
+)
+SELECT u.user_id, u.user_id IN (SELECT user_id FROM changed), t.version, COALESCE((SELECT version FROM bumped), t.version)
+FROM users u JOIN teams t ON t.team_name = u.team_name
+WHERE u.team_name=$1
+ORDER BY u.user_id
+`, teamName)
+	if err != nil {
+		return res, err
+	}
 	users := []string{}
+	var events []Event
+	var current, next int64
 	for rows.Next() {
 		var id string
-		if err := rows.Scan(&id); err != nil {
+		var changed bool
+		if err := rows.Scan(&id, &changed, &current, &next); err != nil {
+			rows.Close()
 			return res, err
 		}
 		users = append(users, id)
+		if changed {
+			events = append(events, Event{
+				Type:     EventUserActiveChanged,
+				TeamName: teamName,
+				UserID:   id,
+				Payload:  map[string]any{"is_active": false},
+			})
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return res, err
 	}
-
 	if len(users) == 0 {
 		return res, ErrNotFound
 	}
-	tx, err := s.pool.Begin(ctx)
-	if err != nil {
+	if err := insertEvents(ctx, tx, events); err != nil {
 		return res, err
 	}
-	defer tx.Rollback(ctx)
 
-	if _, err := tx.Exec(ctx, `UPDATE users SET is_active = false WHERE team_name=$1`, teamName); err != nil {
+	reassigned, failures, err := s.reassignInactive(ctx, tx, users, "team deactivated")
+	if err != nil {
 		return res, err
 	}
+	res.ReassignFailures = failures
+	res.DeactivatedUsers = users
+	res.ReassignedPRsCount = reassigned
 
-	prRows, err := tx.Query(ctx, `
-SELECT DISTINCT pr.pull_request_id
-FROM pull_requests pr
-JOIN pr_reviewers r ON r.pull_request_id = pr.pull_request_id
-JOIN users u ON u.user_id = r.reviewer_id
-WHERE pr.status = 'OPEN' AND u.team_name = $1
-`, teamName)
-	if err != nil {
+	if dryRun {
+		// события, NOTIFY и новая версия откатываются вместе с транзакцией:
+		// отдаём действующую версию, чтобы её можно было передать в If-Match
+		res.Version = current
+		return res, tx.Rollback(ctx)
+	}
+	if err := tx.Commit(ctx); err != nil {
 		return res, err
 	}
-	defer prRows.Close()
 
-	prIDs := []string{}
-	for prRows.Next() {
-		var id string
-		if err := prRows.Scan(&id); err != nil {
-			return res, err
-		}
-		prIDs = append(prIDs, id)
+	res.Version = next
+	return res, nil
+}
+
+// reassignInactive переназначает открытые ревью неактивных пользователей из users
+// по тем же правилам, что и ReassignReviewer, но пачкой: число запросов не
+// зависит от числа PR. Если замену подобрать не удалось, PR попадает в failures,
+// а остальные замены выполняются.
+func (s *Store) reassignInactive(ctx context.Context, q querier, users []string, reason string) (int, map[string]string, error) {
+	failures := map[string]string{}
+	reps, err := lockInactiveSlots(ctx, q, users)
+	if err != nil {
+		return 0, failures, err
 	}
+	if err := s.planReplacements(ctx, q, reps, map[string]TeamPolicy{}); err != nil {
+		return 0, failures, err
+	}
+	if err := applyReplacements(ctx, q, reps, AssignmentReassign, reason); err != nil {
+		return 0, failures, err
+	}
+
 	reassigned := 0
-	for _, prID := range prIDs {
-		rRows, err := tx.Query(ctx, `SELECT reviewer_id FROM pr_reviewers WHERE pull_request_id=$1`, prID)
-		if err != nil {
-			res.ReassignFailures[prID] = err.Error()
+	for _, r := range reps {
+		if r.Err == nil {
+			reassigned++
 			continue
 		}
-		cur := []string{}
-		for rRows.Next() {
-			var rid string
-			_ = rRows.Scan(&rid)
-			cur = append(cur, rid)
-		}
-		rRows.Close()
-		for _, old := range cur {
-			var team string
-			var isActive bool
-			if err := tx.QueryRow(ctx, `SELECT team_name, is_active FROM users WHERE user_id=$1`, old).Scan(&team, &isActive); err != nil {
-				res.ReassignFailures[prID] = fmt.Sprintf("check user: %v", err)
-				continue
-			}
-			if team != teamName || isActive {
-				continue
-			}
-			reassigned++
+		msg := r.OldID + ": " + r.Err.Error()
+		if prev, ok := failures[r.PR.ID]; ok {
+			msg = prev + "; " + msg
 		}
+		failures[r.PR.ID] = msg
 	}
-	res.DeactivatedUsers = users
-	res.ReassignedPRsCount = reassigned
-
-	if err := tx.Commit(ctx); err != nil {
-		return res, err
-	}
-
-	return res, nil
+	return reassigned, failures, nil
 }
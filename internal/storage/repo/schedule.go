@@ -0,0 +1,326 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"pr-reviewer-service/internal/lib/worktime"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// WorkSchedule — рабочее время пользователя или команды. Незаданные поля
+// наследуются: пользователь — от команды, команда — от значений по умолчанию
+// (UTC, пн–пт, 09:00–18:00).
+type WorkSchedule struct {
+	TimeZone  *string `json:"time_zone"`
+	WorkStart *string `json:"work_start"`
+	WorkEnd   *string `json:"work_end"`
+	// WorkDays — дни недели по ISO 8601: 1 — понедельник, 7 — воскресенье.
+	WorkDays []int `json:"work_days"`
+}
+
+type TeamSchedule struct {
+	TeamName string `json:"team_name"`
+	WorkSchedule
+	Holidays []string `json:"holidays"`
+}
+
+// UserSchedule — личное рабочее время и праздники пользователя. Праздники
+// добавляются к календарю команды, а не заменяют его.
+type UserSchedule struct {
+	WorkSchedule
+	Holidays []string `json:"holidays"`
+}
+
+func (u UserSchedule) Validate() error {
+	if err := u.WorkSchedule.Validate(); err != nil {
+		return err
+	}
+	for _, day := range u.Holidays {
+		if _, err := time.Parse(worktime.DateLayout, day); err != nil {
+			return errors.New("holidays must be dates in YYYY-MM-DD format")
+		}
+	}
+	return nil
+}
+
+func (w WorkSchedule) Validate() error {
+	if w.TimeZone != nil {
+		if _, err := time.LoadLocation(*w.TimeZone); err != nil {
+			return fmt.Errorf("unknown time zone %q", *w.TimeZone)
+		}
+	}
+	var start, end time.Duration
+	var err error
+	if w.WorkStart != nil {
+		if start, err = worktime.ParseClock(*w.WorkStart); err != nil {
+			return err
+		}
+	}
+	if w.WorkEnd != nil {
+		if end, err = worktime.ParseClock(*w.WorkEnd); err != nil {
+			return err
+		}
+	}
+	if w.WorkStart != nil && w.WorkEnd != nil && end <= start {
+		return errors.New("work_end must be after work_start")
+	}
+	if w.WorkDays != nil && len(w.WorkDays) == 0 {
+		return errors.New("work_days must not be empty")
+	}
+	for _, d := range w.WorkDays {
+		if d < 1 || d > 7 {
+			return fmt.Errorf("invalid work day %d: want 1..7", d)
+		}
+	}
+	return nil
+}
+
+func (s *Store) GetTeamSchedule(ctx context.Context, teamName string) (TeamSchedule, error) {
+	ts := TeamSchedule{TeamName: teamName, Holidays: []string{}}
+	var days []int32
+	err := s.pool.QueryRow(ctx, `SELECT time_zone, work_start, work_end, work_days FROM teams WHERE team_name=$1`, teamName).
+		Scan(&ts.TimeZone, &ts.WorkStart, &ts.WorkEnd, &days)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return TeamSchedule{}, ErrNotFound
+	}
+	if err != nil {
+		return TeamSchedule{}, err
+	}
+	ts.WorkDays = intsFrom(days)
+
+	holidays, err := loadHolidays(ctx, s.pool, []string{teamName})
+	if err != nil {
+		return TeamSchedule{}, err
+	}
+	for day := range holidays[teamName] {
+		ts.Holidays = append(ts.Holidays, day)
+	}
+	sort.Strings(ts.Holidays)
+	return ts, nil
+}
+
+// SetTeamSchedule задаёт рабочее время команды и полностью заменяет её календарь праздников.
+func (s *Store) SetTeamSchedule(ctx context.Context, ts TeamSchedule) (TeamSchedule, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return TeamSchedule{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	cmd, err := tx.Exec(ctx, `UPDATE teams SET time_zone=$2, work_start=$3, work_end=$4, work_days=$5 WHERE team_name=$1`,
+		ts.TeamName, ts.TimeZone, ts.WorkStart, ts.WorkEnd, ts.WorkDays)
+	if err != nil {
+		return TeamSchedule{}, err
+	}
+	if cmd.RowsAffected() == 0 {
+		return TeamSchedule{}, ErrNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM team_holidays WHERE team_name=$1`, ts.TeamName); err != nil {
+		return TeamSchedule{}, err
+	}
+	for _, day := range ts.Holidays {
+		if _, err := tx.Exec(ctx, `INSERT INTO team_holidays(team_name, day) VALUES($1,$2) ON CONFLICT DO NOTHING`, ts.TeamName, day); err != nil {
+			return TeamSchedule{}, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return TeamSchedule{}, err
+	}
+	return s.GetTeamSchedule(ctx, ts.TeamName)
+}
+
+// SetUserSchedule задаёт личное рабочее время и полностью заменяет личные праздники.
+func (s *Store) SetUserSchedule(ctx context.Context, userID string, us UserSchedule) (UserSchedule, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return UserSchedule{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	cmd, err := tx.Exec(ctx, `UPDATE users SET time_zone=$2, work_start=$3, work_end=$4, work_days=$5 WHERE user_id=$1`,
+		userID, us.TimeZone, us.WorkStart, us.WorkEnd, us.WorkDays)
+	if err != nil {
+		return UserSchedule{}, err
+	}
+	if cmd.RowsAffected() == 0 {
+		return UserSchedule{}, ErrNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM user_holidays WHERE user_id=$1`, userID); err != nil {
+		return UserSchedule{}, err
+	}
+	for _, day := range us.Holidays {
+		if _, err := tx.Exec(ctx, `INSERT INTO user_holidays(user_id, day) VALUES($1,$2) ON CONFLICT DO NOTHING`, userID, day); err != nil {
+			return UserSchedule{}, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return UserSchedule{}, err
+	}
+	us.Holidays = slices.Compact(slices.Sorted(slices.Values(us.Holidays)))
+	if us.Holidays == nil {
+		us.Holidays = []string{}
+	}
+	return us, nil
+}
+
+// loadSchedules возвращает итоговое рабочее время каждого пользователя.
+func loadSchedules(ctx context.Context, q querier, userIDs []string) (map[string]worktime.Schedule, error) {
+	res := map[string]worktime.Schedule{}
+	if len(userIDs) == 0 {
+		return res, nil
+	}
+	rows, err := q.Query(ctx, `
+SELECT u.user_id, u.team_name,
+  u.time_zone, u.work_start, u.work_end, u.work_days,
+  t.time_zone, t.work_start, t.work_end, t.work_days
+FROM users u
+JOIN teams t ON t.team_name = u.team_name
+WHERE u.user_id = ANY($1)
+`, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	type row struct {
+		team         string
+		user, def    WorkSchedule
+		uDays, tDays []int32
+	}
+	byUser := map[string]row{}
+	teams := []string{}
+	for rows.Next() {
+		var id string
+		var r row
+		if err := rows.Scan(&id, &r.team,
+			&r.user.TimeZone, &r.user.WorkStart, &r.user.WorkEnd, &r.uDays,
+			&r.def.TimeZone, &r.def.WorkStart, &r.def.WorkEnd, &r.tDays); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		r.user.WorkDays, r.def.WorkDays = intsFrom(r.uDays), intsFrom(r.tDays)
+		byUser[id] = r
+		teams = append(teams, r.team)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	holidays, err := loadHolidays(ctx, q, teams)
+	if err != nil {
+		return nil, err
+	}
+	personal, err := loadUserHolidays(ctx, q, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for id, r := range byUser {
+		res[id] = buildSchedule(r.user, r.def, mergeDays(holidays[r.team], personal[id]))
+	}
+	return res, nil
+}
+
+func loadHolidays(ctx context.Context, q querier, teams []string) (map[string]map[string]bool, error) {
+	return loadDays(ctx, q, `SELECT team_name, day FROM team_holidays WHERE team_name = ANY($1)`, teams)
+}
+
+func loadUserHolidays(ctx context.Context, q querier, userIDs []string) (map[string]map[string]bool, error) {
+	return loadDays(ctx, q, `SELECT user_id, day FROM user_holidays WHERE user_id = ANY($1)`, userIDs)
+}
+
+// loadDays выполняет запрос вида (ключ, дата) и группирует даты по ключу.
+func loadDays(ctx context.Context, q querier, sql string, keys []string) (map[string]map[string]bool, error) {
+	res := map[string]map[string]bool{}
+	if len(keys) == 0 {
+		return res, nil
+	}
+	rows, err := q.Query(ctx, sql, keys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		var day time.Time
+		if err := rows.Scan(&key, &day); err != nil {
+			return nil, err
+		}
+		if res[key] == nil {
+			res[key] = map[string]bool{}
+		}
+		res[key][day.Format(worktime.DateLayout)] = true
+	}
+	return res, rows.Err()
+}
+
+// mergeDays объединяет праздники команды и личные, не меняя исходные наборы.
+func mergeDays(team, user map[string]bool) map[string]bool {
+	if len(user) == 0 {
+		return team
+	}
+	res := make(map[string]bool, len(team)+len(user))
+	maps.Copy(res, team)
+	maps.Copy(res, user)
+	return res
+}
+
+// buildSchedule накладывает настройки пользователя на настройки команды.
+// Некорректные сохранённые значения игнорируются.
+func buildSchedule(user, team WorkSchedule, holidays map[string]bool) worktime.Schedule {
+	sch := worktime.Default()
+	sch.Holidays = holidays
+
+	pick := func(u, t *string) *string {
+		if u != nil {
+			return u
+		}
+		return t
+	}
+	if tz := pick(user.TimeZone, team.TimeZone); tz != nil {
+		if loc, err := time.LoadLocation(*tz); err == nil {
+			sch.Location = loc
+		}
+	}
+	if v := pick(user.WorkStart, team.WorkStart); v != nil {
+		if d, err := worktime.ParseClock(*v); err == nil {
+			sch.Start = d
+		}
+	}
+	if v := pick(user.WorkEnd, team.WorkEnd); v != nil {
+		if d, err := worktime.ParseClock(*v); err == nil {
+			sch.End = d
+		}
+	}
+	days := user.WorkDays
+	if days == nil {
+		days = team.WorkDays
+	}
+	if days != nil {
+		sch.Days = [7]bool{}
+		for _, d := range days {
+			sch.Days[time.Weekday(d%7)] = true
+		}
+	}
+	return sch
+}
+
+func intsFrom(v []int32) []int {
+	if v == nil {
+		return nil
+	}
+	res := make([]int, len(v))
+	for i, x := range v {
+		res[i] = int(x)
+	}
+	return res
+}
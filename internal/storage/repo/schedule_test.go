@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"testing"
+	_ "time/tzdata"
+)
+
+func TestBuildScheduleHolidays(t *testing.T) {
+	team := map[string]bool{"2026-01-01": true}
+	tz := "Europe/Moscow"
+	days := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name     string
+		personal map[string]bool
+		holidays []string
+		workdays []string
+	}{
+		{"team calendar only", nil, []string{"2026-01-01"}, []string{"2026-01-02", "2026-01-05"}},
+		{"personal days are added", map[string]bool{"2026-01-05": true}, []string{"2026-01-01", "2026-01-05"}, []string{"2026-01-02"}},
+		{"same day in both", map[string]bool{"2026-01-01": true}, []string{"2026-01-01"}, []string{"2026-01-02"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sch := buildSchedule(WorkSchedule{TimeZone: &tz}, WorkSchedule{WorkDays: days}, mergeDays(team, tt.personal))
+			if sch.Location.String() != tz {
+				t.Errorf("location %s, want %s", sch.Location, tz)
+			}
+			for _, day := range tt.holidays {
+				if !sch.Holidays[day] {
+					t.Errorf("%s is not a holiday", day)
+				}
+			}
+			for _, day := range tt.workdays {
+				if sch.Holidays[day] {
+					t.Errorf("%s is a holiday", day)
+				}
+			}
+		})
+	}
+	if len(team) != 1 {
+		t.Errorf("team calendar was modified: %v", team)
+	}
+}
+
+func TestUserScheduleValidate(t *testing.T) {
+	tz := "Mars/Olympus"
+	tests := []struct {
+		name    string
+		us      UserSchedule
+		wantErr bool
+	}{
+		{"empty", UserSchedule{}, false},
+		{"holidays", UserSchedule{Holidays: []string{"2026-01-01", "2026-05-09"}}, false},
+		{"bad holiday", UserSchedule{Holidays: []string{"01.01.2026"}}, true},
+		{"bad work schedule", UserSchedule{WorkSchedule: WorkSchedule{TimeZone: &tz}}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.us.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: err %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package repo
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Skill struct {
+	Tag   string `json:"tag"`
+	Level int    `json:"level"`
+}
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// candidate — пользователь, которого можно назначить ревьювером.
+type candidate struct {
+	UserID string
+	Level  string
+	Skills map[string]int
+	// LastPaired — когда кандидат последний раз ревьюил автора PR (нулевое, если никогда).
+	LastPaired time.Time
+	// Tier — 0 для своей команды, дальше порядковый номер резервной команды из политики.
+	Tier int
+}
+
+const (
+	StrategyRandom      = "random"
+	StrategyLeastRecent = "least_recent"
+)
+
+func ValidStrategy(s string) bool {
+	return s == StrategyRandom || s == StrategyLeastRecent
+}
+
+const (
+	ModeRandom        = "random"
+	ModeDeterministic = "deterministic"
+)
+
+func ValidMode(m string) bool {
+	return m == ModeRandom || m == ModeDeterministic
+}
+
+// AlgorithmVersion меняется при любом изменении правил ранжирования, чтобы
+// сохранённые назначения можно было пересчитать той же версией алгоритма.
+const AlgorithmVersion = "fnv1a-rank-v1"
+
+// selector хранит параметры одного выбора ревьюверов. Порядок среди равных
+// кандидатов задаётся хешем (seed, PR, user), поэтому при известном seed
+// выбор воспроизводим.
+type selector struct {
+	seed     int64
+	prID     string
+	strategy string
+	tags     []string
+}
+
+func (sel selector) rank(userID string) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(sel.seed))
+	_, _ = h.Write(buf[:])
+	_, _ = h.Write([]byte(sel.prID))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(userID))
+	return h.Sum64()
+}
+
+func normalizeTags(tags []string) []string {
+	seen := map[string]bool{}
+	res := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		res = append(res, t)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func normalizeSkills(skills []Skill) []Skill {
+	byTag := map[string]int{}
+	for _, sk := range skills {
+		tag := strings.ToLower(strings.TrimSpace(sk.Tag))
+		if tag == "" {
+			continue
+		}
+		lvl := sk.Level
+		if lvl < 1 {
+			lvl = 1
+		}
+		if lvl > 5 {
+			lvl = 5
+		}
+		byTag[tag] = lvl
+	}
+	res := make([]Skill, 0, len(byTag))
+	for tag, lvl := range byTag {
+		res = append(res, Skill{Tag: tag, Level: lvl})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Tag < res[j].Tag })
+	return res
+}
+
+// loadSkills возвращает навыки для переданных пользователей.
+func loadSkills(ctx context.Context, q querier, userIDs []string) (map[string][]Skill, error) {
+	res := map[string][]Skill{}
+	if len(userIDs) == 0 {
+		return res, nil
+	}
+	rows, err := q.Query(ctx, `SELECT user_id, tag, level FROM user_skills WHERE user_id = ANY($1) ORDER BY user_id, tag`, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var sk Skill
+		if err := rows.Scan(&id, &sk.Tag, &sk.Level); err != nil {
+			return nil, err
+		}
+		res[id] = append(res[id], sk)
+	}
+	return res, rows.Err()
+}
+
+func replaceSkills(ctx context.Context, q querier, userID string, skills []Skill) error {
+	if _, err := q.Exec(ctx, `DELETE FROM user_skills WHERE user_id=$1`, userID); err != nil {
+		return err
+	}
+	for _, sk := range normalizeSkills(skills) {
+		if _, err := q.Exec(ctx, `INSERT INTO user_skills(user_id, tag, level) VALUES($1,$2,$3)`, userID, sk.Tag, sk.Level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadCandidates(ctx context.Context, q querier, members []member, authorID string) ([]candidate, error) {
+	ids := make([]string, len(members))
+	for i, m := range members {
+		ids[i] = m.UserID
+	}
+	skills, err := loadSkills(ctx, q, ids)
+	if err != nil {
+		return nil, err
+	}
+	paired, err := loadLastPaired(ctx, q, authorID, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]candidate, 0, len(members))
+	for _, m := range members {
+		res = append(res, newCandidate(m, skills[m.UserID], paired[m.UserID]))
+	}
+	return res, nil
+}
+
+func newCandidate(m member, skills []Skill, lastPaired time.Time) candidate {
+	c := candidate{UserID: m.UserID, Level: m.Level, Skills: map[string]int{}, LastPaired: lastPaired}
+	for _, sk := range skills {
+		c.Skills[sk.Tag] = sk.Level
+	}
+	return c
+}
+
+// loadLastPaired — время последнего назначения каждого кандидата на PR автора.
+func loadLastPaired(ctx context.Context, q querier, authorID string, ids []string) (map[string]time.Time, error) {
+	pairs, err := loadPairs(ctx, q, []string{authorID}, ids)
+	if err != nil {
+		return nil, err
+	}
+	if pairs[authorID] == nil {
+		return map[string]time.Time{}, nil
+	}
+	return pairs[authorID], nil
+}
+
+// loadPairs — то же для нескольких авторов сразу: автор → ревьювер → время.
+func loadPairs(ctx context.Context, q querier, authors, ids []string) (map[string]map[string]time.Time, error) {
+	res := map[string]map[string]time.Time{}
+	if len(authors) == 0 || len(ids) == 0 {
+		return res, nil
+	}
+	rows, err := q.Query(ctx, `
+SELECT pr.author_id, r.reviewer_id, MAX(r.assigned_at)
+FROM pr_reviewers r
+JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+WHERE pr.author_id = ANY($1) AND r.reviewer_id = ANY($2)
+GROUP BY pr.author_id, r.reviewer_id
+`, authors, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var author, id string
+		var at time.Time
+		if err := rows.Scan(&author, &id, &at); err != nil {
+			return nil, err
+		}
+		if res[author] == nil {
+			res[author] = map[string]time.Time{}
+		}
+		res[author][id] = at
+	}
+	return res, rows.Err()
+}
+
+// selectReviewers выбирает до n ревьюверов с учётом правила старшинства:
+// сначала need человек из квалифицированных, затем остальные места из всех.
+func (sel selector) selectReviewers(cands []candidate, covered map[string]bool, n, need int, rule SeniorityRule) ([]string, error) {
+	qualified := make([]candidate, 0, len(cands))
+	for _, c := range cands {
+		if rule.satisfiedBy(c.Level) {
+			qualified = append(qualified, c)
+		}
+	}
+	if len(qualified) < need {
+		return nil, ErrSeniority
+	}
+
+	res := sel.pickReviewers(qualified, covered, need)
+	if len(res) == n {
+		return res, nil
+	}
+
+	chosen := map[string]bool{}
+	done := map[string]bool{}
+	for t := range covered {
+		done[t] = true
+	}
+	for _, c := range cands {
+		for _, id := range res {
+			if c.UserID == id {
+				chosen[id] = true
+				for t := range c.Skills {
+					done[t] = true
+				}
+			}
+		}
+	}
+	rest := make([]candidate, 0, len(cands))
+	for _, c := range cands {
+		if !chosen[c.UserID] {
+			rest = append(rest, c)
+		}
+	}
+	return append(res, sel.pickReviewers(rest, done, n-len(res))...), nil
+}
+
+// pickReviewers выбирает до n ревьюверов. Кандидаты упорядочиваются по rank, после чего
+// жадно берётся тот, кто закрывает больше ещё не покрытых тегов PR; при равенстве —
+// у кого больше пересечение с тегами и выше суммарный уровень владения, а для
+// стратегии least_recent — кто дольше всех не ревьюил автора.
+// covered — теги, которые уже закрыты оставшимися ревьюверами.
+func (sel selector) pickReviewers(cands []candidate, covered map[string]bool, n int) []string {
+	pool := make([]candidate, len(cands))
+	copy(pool, cands)
+	sort.Slice(pool, func(i, j int) bool { return sel.rank(pool[i].UserID) < sel.rank(pool[j].UserID) })
+
+	done := map[string]bool{}
+	for t := range covered {
+		done[t] = true
+	}
+
+	res := make([]string, 0, n)
+	for len(res) < n && len(pool) > 0 {
+		best := 0
+		for i := 1; i < len(pool); i++ {
+			if sel.better(pool[i], pool[best], done) {
+				best = i
+			}
+		}
+		chosen := pool[best]
+		for _, t := range sel.tags {
+			if chosen.Skills[t] > 0 {
+				done[t] = true
+			}
+		}
+		res = append(res, chosen.UserID)
+		pool = append(pool[:best], pool[best+1:]...)
+	}
+	return res
+}
+
+func (sel selector) better(a, b candidate, covered map[string]bool) bool {
+	// резервные команды используются только после своей
+	if a.Tier != b.Tier {
+		return a.Tier < b.Tier
+	}
+	aNew, aOverlap, aLevel := score(a, sel.tags, covered)
+	bNew, bOverlap, bLevel := score(b, sel.tags, covered)
+	if aNew != bNew {
+		return aNew > bNew
+	}
+	if aOverlap != bOverlap {
+		return aOverlap > bOverlap
+	}
+	if aLevel != bLevel {
+		return aLevel > bLevel
+	}
+	if sel.strategy == StrategyLeastRecent {
+		return a.LastPaired.Before(b.LastPaired)
+	}
+	return false
+}
+
+func score(c candidate, tags []string, covered map[string]bool) (newTags, overlap, level int) {
+	for _, t := range tags {
+		lvl := c.Skills[t]
+		if lvl == 0 {
+			continue
+		}
+		if !covered[t] {
+			newTags++
+		}
+		overlap++
+		level += lvl
+	}
+	return newTags, overlap, level
+}
@@ -0,0 +1,186 @@
+package repo
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// equalCandidates — n кандидатов без навыков из одной команды: порядок между
+// ними задаёт только rank.
+func equalCandidates(n int) []candidate {
+	res := make([]candidate, n)
+	for i := range res {
+		res[i] = candidate{UserID: fmt.Sprintf("u%d", i+1), Level: "middle"}
+	}
+	return res
+}
+
+func TestPickReviewersReproducible(t *testing.T) {
+	cands := equalCandidates(20)
+	reversed := slices.Clone(cands)
+	slices.Reverse(reversed)
+
+	tests := []struct {
+		name string
+		a, b selector
+		same bool
+	}{
+		{"same seed and PR", selector{seed: 42, prID: "pr-1"}, selector{seed: 42, prID: "pr-1"}, true},
+		{"same seed and PR with tags", selector{seed: 42, prID: "pr-1", tags: []string{"go"}}, selector{seed: 42, prID: "pr-1", tags: []string{"go"}}, true},
+		{"different seed", selector{seed: 42, prID: "pr-1"}, selector{seed: 43, prID: "pr-1"}, false},
+		{"different PR", selector{seed: 42, prID: "pr-1"}, selector{seed: 42, prID: "pr-2"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.a.pickReviewers(cands, nil, 3)
+			b := tt.b.pickReviewers(cands, nil, 3)
+			if got := slices.Equal(a, b); got != tt.same {
+				t.Errorf("%v and %v: equal=%v, want %v", a, b, got, tt.same)
+			}
+			// порядок кандидатов на входе (порядок строк из базы) не влияет на выбор
+			if again := tt.a.pickReviewers(reversed, nil, 3); !slices.Equal(a, again) {
+				t.Errorf("input order changed the result: %v vs %v", a, again)
+			}
+		})
+	}
+}
+
+func TestPickReviewersSeedsSpread(t *testing.T) {
+	cands := equalCandidates(10)
+	first := map[string]int{}
+	for seed := int64(1); seed <= 200; seed++ {
+		sel := selector{seed: seed, prID: "pr-1"}
+		first[sel.pickReviewers(cands, nil, 1)[0]]++
+	}
+	// при равных кандидатах разные seed должны доставаться разным людям
+	if len(first) != len(cands) {
+		t.Errorf("only %d of %d candidates were ever picked first: %v", len(first), len(cands), first)
+	}
+}
+
+func TestRank(t *testing.T) {
+	sel := selector{seed: 7, prID: "pr-1"}
+	if sel.rank("u1") != sel.rank("u1") {
+		t.Error("rank is not stable")
+	}
+	// разделитель между PR и пользователем: ("pr-1", "2u") и ("pr-12", "u") разные
+	if sel.rank("2u") == (selector{seed: 7, prID: "pr-12"}).rank("u") {
+		t.Error("rank does not separate PR id from user id")
+	}
+	if sel.rank("u1") == (selector{seed: 8, prID: "pr-1"}).rank("u1") {
+		t.Error("rank does not depend on seed")
+	}
+}
+
+func TestPickReviewersTieBreaking(t *testing.T) {
+	now := time.Now()
+	// ranked — кандидаты в порядке rank для seed 1 и pr-1: при полном равенстве
+	// выигрывает первый из них
+	base := selector{seed: 1, prID: "pr-1"}
+	ranked := equalCandidates(4)
+	slices.SortFunc(ranked, func(a, b candidate) int { return cmp.Compare(base.rank(a.UserID), base.rank(b.UserID)) })
+	last := ranked[len(ranked)-1].UserID
+
+	with := func(id string, change func(*candidate)) []candidate {
+		res := slices.Clone(ranked)
+		for i := range res {
+			if res[i].UserID == id {
+				change(&res[i])
+			}
+		}
+		return res
+	}
+
+	tests := []struct {
+		name     string
+		strategy string
+		tags     []string
+		covered  map[string]bool
+		cands    []candidate
+		want     string
+	}{
+		{"all equal: lowest rank", StrategyRandom, nil, nil, ranked, ranked[0].UserID},
+		{"own team before fallback", StrategyRandom, nil, nil,
+			with(ranked[0].UserID, func(c *candidate) { c.Tier = 1 }), ranked[1].UserID},
+		{"new tag coverage beats rank", StrategyRandom, []string{"go"}, nil,
+			with(last, func(c *candidate) { c.Skills = map[string]int{"go": 1} }), last},
+		{"overlap wins when the tag is already covered", StrategyRandom, []string{"go", "sql"}, map[string]bool{"go": true},
+			with(last, func(c *candidate) { c.Skills = map[string]int{"go": 5} }), last},
+		{"least_recent: longest since pairing", StrategyLeastRecent, nil, nil,
+			withPaired(ranked, now, last), last},
+		{"random ignores pairing history", StrategyRandom, nil, nil,
+			withPaired(ranked, now, last), ranked[0].UserID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel := selector{seed: base.seed, prID: base.prID, strategy: tt.strategy, tags: tt.tags}
+			got := sel.pickReviewers(tt.cands, tt.covered, 1)
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("got %v, want %s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("higher level wins at equal coverage", func(t *testing.T) {
+		cands := slices.Clone(ranked)
+		for i := range cands {
+			cands[i].Skills = map[string]int{"go": 1}
+		}
+		cands[len(cands)-1].Skills = map[string]int{"go": 4}
+		sel := selector{seed: base.seed, prID: base.prID, tags: []string{"go"}}
+		if got := sel.pickReviewers(cands, nil, 1); got[0] != last {
+			t.Errorf("got %v, want %s", got, last)
+		}
+	})
+
+	t.Run("second pick covers the remaining tag", func(t *testing.T) {
+		cands := slices.Clone(ranked)
+		cands[0].Skills = map[string]int{"go": 3}
+		cands[1].Skills = map[string]int{"go": 2}
+		cands[len(cands)-1].Skills = map[string]int{"sql": 1}
+		sel := selector{seed: base.seed, prID: base.prID, tags: []string{"go", "sql"}}
+		got := sel.pickReviewers(cands, nil, 2)
+		if want := []string{cands[0].UserID, last}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+// withPaired задаёт всем кандидатам недавнее ревью автора, кроме oldest,
+// который ревьюил его давно.
+func withPaired(cands []candidate, now time.Time, oldest string) []candidate {
+	res := slices.Clone(cands)
+	for i := range res {
+		res[i].LastPaired = now.Add(-time.Hour)
+		if res[i].UserID == oldest {
+			res[i].LastPaired = now.Add(-30 * 24 * time.Hour)
+		}
+	}
+	return res
+}
+
+func TestSelectReviewersSeniority(t *testing.T) {
+	cands := []candidate{
+		{UserID: "j1", Level: "junior"},
+		{UserID: "j2", Level: "junior"},
+		{UserID: "s1", Level: "senior"},
+	}
+	rule := SeniorityRule{MinLevel: "senior", MinCount: 1}
+	sel := selector{seed: 1, prID: "pr-1"}
+
+	got, err := sel.selectReviewers(cands, nil, 2, rule.required(2), rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || !slices.Contains(got, "s1") {
+		t.Errorf("got %v, want two reviewers including s1", got)
+	}
+
+	rule.MinCount = 2
+	if _, err := sel.selectReviewers(cands, nil, 2, rule.required(2), rule); err != ErrSeniority {
+		t.Errorf("err %v, want ErrSeniority", err)
+	}
+}
@@ -0,0 +1,78 @@
+package repo
+
+import "context"
+
+const (
+	LevelJunior = "junior"
+	LevelMiddle = "middle"
+	LevelSenior = "senior"
+	LevelLead   = "lead"
+)
+
+var levelRank = map[string]int{
+	LevelJunior: 1,
+	LevelMiddle: 2,
+	LevelSenior: 3,
+	LevelLead:   4,
+}
+
+func ValidLevel(level string) bool {
+	_, ok := levelRank[level]
+	return ok
+}
+
+// SeniorityRule — требование команды: среди ревьюверов не меньше MinCount
+// человек с уровнем не ниже MinLevel.
+type SeniorityRule struct {
+	TeamName string `json:"team_name"`
+	MinLevel string `json:"min_level"`
+	MinCount int    `json:"min_count"`
+}
+
+func (r SeniorityRule) satisfiedBy(level string) bool {
+	return levelRank[level] >= levelRank[r.MinLevel]
+}
+
+// required — сколько квалифицированных ревьюверов нужно при total ревьюверах.
+func (r SeniorityRule) required(total int) int {
+	if r.MinCount < total {
+		return r.MinCount
+	}
+	return total
+}
+
+func (s *Store) GetSeniorityRule(ctx context.Context, teamName string) (SeniorityRule, error) {
+	p, err := s.GetTeamPolicy(ctx, teamName)
+	if err != nil {
+		return SeniorityRule{}, err
+	}
+	return p.Rule(), nil
+}
+
+// SetSeniorityRule меняет только правило старшинства в политике команды.
+func (s *Store) SetSeniorityRule(ctx context.Context, rule SeniorityRule) (SeniorityRule, error) {
+	p, err := s.updatePolicy(ctx, rule.TeamName, func(p *TeamPolicy) {
+		p.MinLevel, p.MinCount = rule.MinLevel, rule.MinCount
+	})
+	if err != nil {
+		return SeniorityRule{}, err
+	}
+	return p.Rule(), nil
+}
+
+func loadReviewerLevels(ctx context.Context, q querier, prID string) (map[string]string, error) {
+	rows, err := q.Query(ctx, `SELECT u.user_id, u.level FROM pr_reviewers r JOIN users u ON u.user_id = r.reviewer_id WHERE r.pull_request_id=$1`, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := map[string]string{}
+	for rows.Next() {
+		var id, level string
+		if err := rows.Scan(&id, &level); err != nil {
+			return nil, err
+		}
+		res[id] = level
+	}
+	return res, rows.Err()
+}
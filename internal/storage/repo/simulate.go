@@ -0,0 +1,364 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// DefaultSimulationLimit — сколько последних PR прогоняется, если Limit не задан.
+	DefaultSimulationLimit = 1000
+	// MaxSimulationLimit — верхняя граница Limit.
+	MaxSimulationLimit = 10000
+)
+
+// SimulationRequest — что прогнать через выбор ревьюверов. Незаданные поля
+// политики берутся из текущей политики команды.
+type SimulationRequest struct {
+	TeamName              string     `json:"team_name"`
+	ReviewerCount         *int       `json:"reviewer_count"`
+	Strategy              *string    `json:"strategy"`
+	FallbackTeams         []string   `json:"fallback_teams"`
+	MinLevel              *string    `json:"min_level"`
+	MinCount              *int       `json:"min_count"`
+	DefaultMaxOpenReviews *int       `json:"default_max_open_reviews"`
+	From                  *time.Time `json:"from"`
+	To                    *time.Time `json:"to"`
+	// Limit — сколько последних PR окна прогнать; 0 — DefaultSimulationLimit.
+	Limit int   `json:"limit"`
+	Seed  int64 `json:"seed"`
+}
+
+// SimulationOutcomes — чем закончился выбор для прогнанных PR. Short — назначено
+// меньше reviewer_count, Rejected — PR не был бы создан из-за правила старшинства.
+type SimulationOutcomes struct {
+	Full         int     `json:"full"`
+	Short        int     `json:"short"`
+	Unassigned   int     `json:"unassigned"`
+	Rejected     int     `json:"rejected_seniority"`
+	UsedFallback int     `json:"used_fallback"`
+	RanOutRate   float64 `json:"ran_out_rate"`
+}
+
+// Fairness — как ровно назначения разошлись по ревьюверам. CV — stddev / mean,
+// Gini — 0 при идеально ровной нагрузке, ближе к 1 — всё на одном человеке.
+type Fairness struct {
+	Assignments int     `json:"assignments"`
+	Reviewers   int     `json:"reviewers"`
+	Min         int     `json:"min"`
+	Max         int     `json:"max"`
+	Mean        float64 `json:"mean"`
+	StdDev      float64 `json:"stddev"`
+	CV          float64 `json:"cv"`
+	Gini        float64 `json:"gini"`
+}
+
+// SimulatedReviewer — нагрузка одного ревьювера: в симуляции и на самом деле.
+type SimulatedReviewer struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+	Assigned int    `json:"assigned"`
+	Actual   int    `json:"actual"`
+	// PeakOpen — наибольшее число одновременно открытых ревью в симуляции.
+	PeakOpen int `json:"peak_open"`
+}
+
+type SimulationResult struct {
+	Policy    TeamPolicy          `json:"policy"`
+	PRs       int                 `json:"pull_requests"`
+	From      *time.Time          `json:"from,omitempty"`
+	To        *time.Time          `json:"to,omitempty"`
+	Outcomes  SimulationOutcomes  `json:"outcomes"`
+	Simulated Fairness            `json:"simulated"`
+	Actual    Fairness            `json:"actual"`
+	Reviewers []SimulatedReviewer `json:"reviewers"`
+}
+
+// simulatedPR — исторический PR команды с тегами и фактическими ревьюверами.
+type simulatedPR struct {
+	ID        string
+	AuthorID  string
+	CreatedAt time.Time
+	MergedAt  *time.Time
+	Tags      []string
+	Reviewers []string
+}
+
+// Simulate прогоняет исторические PR авторов команды через выбор ревьюверов с
+// заданной политикой и ничего не записывает. PR идут по времени создания;
+// нагрузка считается только по симулированным назначениям и снимается, когда
+// PR был слит. Состав команд, активность, навыки и личные лимиты берутся
+// текущие, отсутствие (away_until) не учитывается — его истории нет.
+func (s *Store) Simulate(ctx context.Context, req SimulationRequest) (SimulationResult, error) {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := teamExists(ctx, tx, req.TeamName); err != nil {
+		return SimulationResult{}, err
+	}
+	policy, err := loadPolicy(ctx, tx, req.TeamName)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	policy = req.apply(policy)
+	if err := policy.Validate(); err != nil {
+		return SimulationResult{}, err
+	}
+	for _, t := range policy.FallbackTeams {
+		if err := teamExists(ctx, tx, t); errors.Is(err, ErrNotFound) {
+			return SimulationResult{}, invalidPolicy("fallback team %q not found", t)
+		} else if err != nil {
+			return SimulationResult{}, err
+		}
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultSimulationLimit
+	}
+	limit = min(limit, MaxSimulationLimit)
+	prs, err := loadSimulatedPRs(ctx, tx, req.TeamName, req.From, req.To, limit)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	teams := map[string][]member{}
+	policies := map[string]TeamPolicy{policy.TeamName: policy}
+	byID := map[string]member{}
+	var ids []string
+	for _, t := range append([]string{policy.TeamName}, policy.FallbackTeams...) {
+		members, err := loadMembers(ctx, tx, t)
+		if err != nil {
+			return SimulationResult{}, err
+		}
+		if err := applyCapacityDefaults(ctx, tx, members, policies); err != nil {
+			return SimulationResult{}, err
+		}
+		teams[t] = members
+		for _, m := range members {
+			byID[m.UserID] = m
+			ids = append(ids, m.UserID)
+		}
+	}
+	skills, err := loadSkills(ctx, tx, ids)
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	res := SimulationResult{Policy: policy, PRs: len(prs), Reviewers: []SimulatedReviewer{}}
+	if len(prs) > 0 {
+		res.From, res.To = &prs[0].CreatedAt, &prs[len(prs)-1].CreatedAt
+	}
+
+	rule := policy.Rule()
+	want := policy.ReviewerCount
+	need := rule.required(want)
+	base := selector{seed: req.Seed, strategy: s.opts.Strategy}.withPolicy(policy)
+
+	// open — когда будут слиты PR, которые ревьюер держит в симуляции (nil — не слит)
+	open := map[string][]*time.Time{}
+	peak := map[string]int{}
+	assigned := map[string]int{}
+	actual := map[string]int{}
+	paired := map[string]map[string]time.Time{}
+
+	for _, pr := range prs {
+		for id, until := range open {
+			open[id] = slices.DeleteFunc(until, func(t *time.Time) bool { return t != nil && !t.After(pr.CreatedAt) })
+		}
+		for _, id := range pr.Reviewers {
+			actual[id]++
+		}
+
+		cands, _, _ := gatherCandidates(policy, policy.TeamName, want, need, func(team string) ([]candidate, []CandidateExplanation, error) {
+			var found []candidate
+			for _, m := range teams[team] {
+				if !m.IsActive || m.UserID == pr.AuthorID {
+					continue
+				}
+				if m.MaxOpenReviews != nil && len(open[m.UserID]) >= *m.MaxOpenReviews {
+					continue
+				}
+				found = append(found, newCandidate(m, skills[m.UserID], paired[pr.AuthorID][m.UserID]))
+			}
+			return found, nil, nil
+		})
+
+		total := min(want, len(cands))
+		sel := base
+		sel.prID, sel.tags = pr.ID, pr.Tags
+		picked, err := sel.selectReviewers(cands, nil, total, rule.required(total), rule)
+		switch {
+		case errors.Is(err, ErrSeniority):
+			res.Outcomes.Rejected++
+			continue
+		case len(picked) == want:
+			res.Outcomes.Full++
+		case len(picked) == 0:
+			res.Outcomes.Unassigned++
+		default:
+			res.Outcomes.Short++
+		}
+
+		fallback := false
+		for _, id := range picked {
+			assigned[id]++
+			open[id] = append(open[id], pr.MergedAt)
+			peak[id] = max(peak[id], len(open[id]))
+			if paired[pr.AuthorID] == nil {
+				paired[pr.AuthorID] = map[string]time.Time{}
+			}
+			paired[pr.AuthorID][id] = pr.CreatedAt
+			for _, c := range cands {
+				if c.UserID == id && c.Tier > 0 {
+					fallback = true
+				}
+			}
+		}
+		if fallback {
+			res.Outcomes.UsedFallback++
+		}
+	}
+	if res.PRs > 0 {
+		res.Outcomes.RanOutRate = float64(res.PRs-res.Outcomes.Full) / float64(res.PRs)
+	}
+
+	// в расчёт справедливости входят все активные участники команды, даже без
+	// назначений, и все, кого назначали в симуляции или на самом деле
+	population := map[string]bool{}
+	for _, m := range teams[policy.TeamName] {
+		if m.IsActive {
+			population[m.UserID] = true
+		}
+	}
+	for id := range assigned {
+		population[id] = true
+	}
+	for id := range actual {
+		population[id] = true
+	}
+	for id := range population {
+		team := byID[id].TeamName
+		if team == "" {
+			// фактический ревьюер не из команды и не из резервных
+			if err := tx.QueryRow(ctx, `SELECT team_name FROM users WHERE user_id=$1`, id).Scan(&team); err != nil {
+				return SimulationResult{}, err
+			}
+		}
+		res.Reviewers = append(res.Reviewers, SimulatedReviewer{
+			UserID:   id,
+			TeamName: team,
+			Assigned: assigned[id],
+			Actual:   actual[id],
+			PeakOpen: peak[id],
+		})
+	}
+	sort.Slice(res.Reviewers, func(i, j int) bool {
+		a, b := res.Reviewers[i], res.Reviewers[j]
+		if a.Assigned != b.Assigned {
+			return a.Assigned > b.Assigned
+		}
+		return a.UserID < b.UserID
+	})
+	sim := make([]int, len(res.Reviewers))
+	act := make([]int, len(res.Reviewers))
+	for i, r := range res.Reviewers {
+		sim[i], act[i] = r.Assigned, r.Actual
+	}
+	res.Simulated, res.Actual = fairness(sim), fairness(act)
+	return res, nil
+}
+
+// apply подставляет в политику заданные в запросе поля.
+func (req SimulationRequest) apply(p TeamPolicy) TeamPolicy {
+	if req.ReviewerCount != nil {
+		p.ReviewerCount = *req.ReviewerCount
+	}
+	if req.Strategy != nil {
+		p.Strategy = req.Strategy
+	}
+	if req.FallbackTeams != nil {
+		p.FallbackTeams = req.FallbackTeams
+	}
+	if req.MinLevel != nil {
+		p.MinLevel = *req.MinLevel
+	}
+	if req.MinCount != nil {
+		p.MinCount = *req.MinCount
+	}
+	if req.DefaultMaxOpenReviews != nil {
+		p.DefaultMaxOpenReviews = req.DefaultMaxOpenReviews
+	}
+	return p
+}
+
+// loadSimulatedPRs возвращает последние limit PR авторов команды в окне [from, to)
+// по возрастанию времени создания.
+func loadSimulatedPRs(ctx context.Context, q querier, teamName string, from, to *time.Time, limit int) ([]simulatedPR, error) {
+	rows, err := q.Query(ctx, `
+SELECT pr.pull_request_id, pr.author_id, pr.created_at, pr.merged_at,
+  COALESCE((SELECT array_agg(t.tag ORDER BY t.tag) FROM pull_request_tags t WHERE t.pull_request_id = pr.pull_request_id), '{}'),
+  COALESCE((SELECT array_agg(r.reviewer_id ORDER BY r.reviewer_id) FROM pr_reviewers r WHERE r.pull_request_id = pr.pull_request_id), '{}')
+FROM pull_requests pr
+JOIN users u ON u.user_id = pr.author_id
+WHERE u.team_name=$1
+  AND ($2::timestamptz IS NULL OR pr.created_at >= $2)
+  AND ($3::timestamptz IS NULL OR pr.created_at < $3)
+ORDER BY pr.created_at DESC, pr.pull_request_id DESC
+LIMIT $4
+`, teamName, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := []simulatedPR{}
+	for rows.Next() {
+		var pr simulatedPR
+		if err := rows.Scan(&pr.ID, &pr.AuthorID, &pr.CreatedAt, &pr.MergedAt, &pr.Tags, &pr.Reviewers); err != nil {
+			return nil, err
+		}
+		res = append(res, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	slices.Reverse(res)
+	return res, nil
+}
+
+func fairness(counts []int) Fairness {
+	f := Fairness{Reviewers: len(counts)}
+	if len(counts) == 0 {
+		return f
+	}
+	sorted := slices.Clone(counts)
+	slices.Sort(sorted)
+	f.Min, f.Max = sorted[0], sorted[len(sorted)-1]
+	weighted := 0
+	for i, n := range sorted {
+		f.Assignments += n
+		weighted += (i + 1) * n
+	}
+	if f.Assignments == 0 {
+		return f
+	}
+	n := float64(len(sorted))
+	f.Mean = float64(f.Assignments) / n
+	var sq float64
+	for _, c := range sorted {
+		sq += (float64(c) - f.Mean) * (float64(c) - f.Mean)
+	}
+	f.StdDev = math.Sqrt(sq / n)
+	f.CV = f.StdDev / f.Mean
+	f.Gini = 2*float64(weighted)/(n*float64(f.Assignments)) - (n+1)/n
+	return f
+}
@@ -0,0 +1,197 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// slaLockKey — ключ advisory-lock, чтобы проверку SLA в один момент выполняла одна реплика.
+const slaLockKey = 7_301_031
+
+// SLAPolicy — пороги SLA команды в рабочих часах ревьювера: после ReminderAfterHours ревьюверу
+// отправляется напоминание, после EscalateAfterHours ревью переназначается.
+// nil отключает соответствующий порог.
+type SLAPolicy struct {
+	TeamName           string `json:"team_name"`
+	ReminderAfterHours *int   `json:"reminder_after_hours"`
+	EscalateAfterHours *int   `json:"escalate_after_hours"`
+}
+
+type SLAReport struct {
+	Skipped   bool `json:"skipped"`
+	Reminded  int  `json:"reminded"`
+	Escalated int  `json:"escalated"`
+	Failed    int  `json:"failed"`
+}
+
+func (s *Store) GetSLAPolicy(ctx context.Context, teamName string) (SLAPolicy, error) {
+	p, err := s.GetTeamPolicy(ctx, teamName)
+	if err != nil {
+		return SLAPolicy{}, err
+	}
+	return p.SLA(), nil
+}
+
+// SetSLAPolicy меняет только пороги SLA в политике команды.
+func (s *Store) SetSLAPolicy(ctx context.Context, sla SLAPolicy) (SLAPolicy, error) {
+	p, err := s.updatePolicy(ctx, sla.TeamName, func(p *TeamPolicy) {
+		p.ReminderAfterHours, p.EscalateAfterHours = sla.ReminderAfterHours, sla.EscalateAfterHours
+	})
+	if err != nil {
+		return SLAPolicy{}, err
+	}
+	return p.SLA(), nil
+}
+
+type pendingReview struct {
+	PullRequestID string
+	ReviewerID    string
+	TeamName      string
+	AssignedAt    time.Time
+	Reminded      bool
+	Reminder      *int
+	Escalate      *int
+}
+
+// ProcessSLA находит просроченные назначения: отправляет напоминания и
+// переназначает ревью после второго порога. Если проверку уже выполняет другая
+// реплика, возвращается отчёт со Skipped=true.
+func (s *Store) ProcessSLA(ctx context.Context, now time.Time) (SLAReport, error) {
+	var rep SLAReport
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return rep, err
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, slaLockKey).Scan(&locked); err != nil {
+		return rep, err
+	}
+	if !locked {
+		rep.Skipped = true
+		return rep, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+SELECT r.pull_request_id, r.reviewer_id, a.team_name, r.assigned_at, r.reminded_at IS NOT NULL,
+  p.sla_reminder_hours, p.sla_escalate_hours
+FROM pr_reviewers r
+JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+JOIN users a ON a.user_id = pr.author_id
+JOIN team_policies p ON p.team_name = a.team_name
+WHERE pr.status = 'OPEN' AND r.escalated_at IS NULL
+  AND (p.sla_reminder_hours IS NOT NULL OR p.sla_escalate_hours IS NOT NULL)
+ORDER BY r.assigned_at
+`)
+	if err != nil {
+		return rep, err
+	}
+	pending := []pendingReview{}
+	for rows.Next() {
+		var p pendingReview
+		if err := rows.Scan(&p.PullRequestID, &p.ReviewerID, &p.TeamName, &p.AssignedAt, &p.Reminded, &p.Reminder, &p.Escalate); err != nil {
+			rows.Close()
+			return rep, err
+		}
+		pending = append(pending, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return rep, err
+	}
+
+	reviewers := make([]string, 0, len(pending))
+	for _, p := range pending {
+		reviewers = append(reviewers, p.ReviewerID)
+	}
+	schedules, err := loadSchedules(ctx, tx, reviewers)
+	if err != nil {
+		return rep, err
+	}
+
+	for _, p := range pending {
+		// возраст считается только по рабочему времени ревьювера
+		age := schedules[p.ReviewerID].Between(p.AssignedAt, now)
+		switch {
+		case p.Escalate != nil && age >= time.Duration(*p.Escalate)*time.Hour:
+			ok, err := s.escalate(ctx, tx, p, age)
+			if err != nil {
+				return rep, err
+			}
+			if ok {
+				rep.Escalated++
+			} else {
+				rep.Failed++
+			}
+		case p.Reminder != nil && !p.Reminded && age >= time.Duration(*p.Reminder)*time.Hour:
+			if _, err := tx.Exec(ctx, `UPDATE pr_reviewers SET reminded_at=$3 WHERE pull_request_id=$1 AND reviewer_id=$2`, p.PullRequestID, p.ReviewerID, now); err != nil {
+				return rep, err
+			}
+			if _, err := insertEvent(ctx, tx, Event{
+				Type:          EventReviewReminder,
+				TeamName:      p.TeamName,
+				UserID:        p.ReviewerID,
+				PullRequestID: p.PullRequestID,
+				Payload:       map[string]any{"assigned_at": p.AssignedAt, "age_seconds": int64(age.Seconds())},
+			}); err != nil {
+				return rep, err
+			}
+			rep.Reminded++
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return rep, err
+	}
+	return rep, nil
+}
+
+// escalate переназначает просроченное ревью тем же путём, что и ReassignReviewer.
+// Ошибка подбора замены не прерывает обработку: назначение помечается, чтобы не
+// повторять попытку на каждом тике, и публикуется событие о неудаче.
+func (s *Store) escalate(ctx context.Context, tx pgx.Tx, p pendingReview, age time.Duration) (bool, error) {
+	reason := fmt.Sprintf("sla breached: %s of working time since assignment", age.Round(time.Minute))
+
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	newID, cause := s.reassign(ctx, sp, p.PullRequestID, p.ReviewerID, 0, AssignmentEscalation, reason)
+	if cause == nil {
+		if err := sp.Commit(ctx); err != nil {
+			return false, err
+		}
+		_, err = insertEvent(ctx, tx, Event{
+			Type:          EventReviewEscalated,
+			TeamName:      p.TeamName,
+			UserID:        p.ReviewerID,
+			PullRequestID: p.PullRequestID,
+			Payload:       map[string]any{"replaced_by": newID, "reason": reason},
+		})
+		return err == nil, err
+	}
+	if rbErr := sp.Rollback(ctx); rbErr != nil {
+		return false, rbErr
+	}
+	if !errors.Is(cause, ErrNoCandidate) && !errors.Is(cause, ErrSeniority) {
+		return false, cause
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE pr_reviewers SET escalated_at=now() WHERE pull_request_id=$1 AND reviewer_id=$2`, p.PullRequestID, p.ReviewerID); err != nil {
+		return false, err
+	}
+	_, err = insertEvent(ctx, tx, Event{
+		Type:          EventReviewEscalationFailed,
+		TeamName:      p.TeamName,
+		UserID:        p.ReviewerID,
+		PullRequestID: p.PullRequestID,
+		Payload:       map[string]any{"reason": reason, "error": cause.Error()},
+	})
+	return false, err
+}
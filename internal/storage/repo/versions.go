@@ -0,0 +1,52 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Версии PR и команд растут на каждом изменении. Клиент передаёт версию,
+// которую видел (If-Match), и изменение применяется, только если она
+// всё ещё актуальна. Ожидаемая версия 0 означает «без проверки».
+
+func checkVersion(current, expected int64) error {
+	if expected != 0 && current != expected {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// lockPR блокирует строку PR до конца транзакции.
+func lockPR(ctx context.Context, q querier, prID string) (status string, version int64, err error) {
+	err = q.QueryRow(ctx, `SELECT status, version FROM pull_requests WHERE pull_request_id=$1 FOR UPDATE`, prID).Scan(&status, &version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", 0, ErrNotFound
+	}
+	return status, version, err
+}
+
+func bumpPRVersion(ctx context.Context, q querier, prID string) error {
+	_, err := q.Exec(ctx, `UPDATE pull_requests SET version = version + 1 WHERE pull_request_id=$1`, prID)
+	return err
+}
+
+// lockTeam блокирует строку команды до конца транзакции и сверяет версию.
+func lockTeam(ctx context.Context, q querier, teamName string, expected int64) error {
+	var version int64
+	err := q.QueryRow(ctx, `SELECT version FROM teams WHERE team_name=$1 FOR UPDATE`, teamName).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return checkVersion(version, expected)
+}
+
+func bumpTeamVersion(ctx context.Context, q querier, teamName string) (int64, error) {
+	var version int64
+	err := q.QueryRow(ctx, `UPDATE teams SET version = version + 1 WHERE team_name=$1 RETURNING version`, teamName).Scan(&version)
+	return version, err
+}